@@ -21,8 +21,16 @@ var (
 	addNotes            string
 	addGeneratePassword bool
 	addGenLength        int
-	addTOTPURI          string // TOTP otpauth:// URI
-	addTOTP             bool   // Prompt for TOTP secret interactively
+	addTOTPURI          string   // TOTP otpauth:// URI
+	addTOTP             bool     // Prompt for TOTP secret interactively
+	addFields           []string // Custom fields as name=value
+	addHiddenFields     []string // Hidden custom fields as name=value
+	addTags             []string // Tags to attach
+	addType             string   // Record type (login, note, card, ...)
+	addFieldFiles       []string // Custom fields read from files as name=path
+	addExpires          string   // Expiry date or duration from now
+	addRotateEvery      string   // Rotation interval
+	addMatchURLs        []string // Additional URLs with optional match rules
 )
 
 var addCmd = &cobra.Command{
@@ -31,17 +39,36 @@ var addCmd = &cobra.Command{
 	Short:   "Add a new credential to the vault",
 	Long: `Add stores a new credential (username and password) for a service in your vault.
 
+Use --type to store something other than a login. Each record type has its own
+fields, which are set with --field (or --field-file for multi-line values such
+as private keys). Required fields that are missing are prompted for.
+  login      username, password, url (default)
+  note       notes only
+  api-token  token (--password), key-id, expires
+  ssh-key    private-key, public-key, comment, fingerprint, agent-confirm,
+             agent-lifetime, passphrase (--password)
+  card       cardholder, number, expiry, cvv, pin
+  identity   full-name, email, phone, address, birth-date, id-number
+  database   username, password, engine, host, port, database
+
 You will be prompted for the username and password. The password input will be
 hidden for security. If you want to provide these values via flags, use:
   --username (-u) for the username
   --password (-p) for the password (not recommended for security)
   --generate (-g) to auto-generate a secure password
   --gen-length to specify generated password length (default: 20)
-  --category (-c) for organizing credentials (e.g., 'Cloud', 'Databases')
+  --category (-c) for a category or folder path (e.g., 'Cloud', 'Clients/Acme/Prod')
   --url for the service URL (e.g., login page URL)
+  --match-url for more URLs found by 'pass-cli find --url' (repeatable)
   --notes for additional information
   --totp-uri to add TOTP/2FA support with an otpauth:// URI
   --totp to be prompted for TOTP secret interactively
+  --field name=value to store a custom field (repeatable)
+  --hidden-field name=value to store a custom field masked like a password
+  --tag to attach one or more tags (repeatable or comma-separated)
+  --field-file name=path to store a file's contents as a hidden custom field
+  --expires for an expiry date (YYYY-MM-DD or a duration such as 90d)
+  --rotate-every for a rotation reminder interval (e.g. 90d, 12w, 1y)
 
 The service name should be descriptive and unique (e.g., "github", "aws-prod", "db-staging").`,
 	Example: `  # Add a credential with prompts
@@ -56,6 +83,9 @@ The service name should be descriptive and unique (e.g., "github", "aws-prod", "
   # Add with notes
   pass-cli add github --notes "My GitHub account"
 
+  # Add with extra URLs for URL lookup (any host of the domain, one exact host)
+  pass-cli add sso -u me --url https://example.com --match-url example.org --match-url host:login.example.net
+
   # Add with auto-generated password
   pass-cli add github -u user@example.com --generate
 
@@ -69,7 +99,25 @@ The service name should be descriptive and unique (e.g., "github", "aws-prod", "
   pass-cli add github -u user@example.com --totp-uri "otpauth://totp/GitHub:user?secret=JBSWY3DPEHPK3PXP&issuer=GitHub"
 
   # Add with interactive TOTP prompt
-  pass-cli add github -u user@example.com --totp`,
+  pass-cli add github -u user@example.com --totp
+
+  # Add with custom fields
+  pass-cli add aws -u admin --field account-id=123456789012 --hidden-field secret-key=wJalrXUtn
+
+  # Add a payment card (prompts for the card number)
+  pass-cli add visa --type card --field cardholder="Jane Doe" --field expiry=04/29
+
+  # Add an SSH key pair
+  pass-cli add deploy-key --type ssh-key --field-file private-key=~/.ssh/id_ed25519 --field-file public-key=~/.ssh/id_ed25519.pub
+
+  # Add a secure note
+  pass-cli add wifi --type note --notes "SSID: home / pass: hunter2"
+
+  # Add with tags
+  pass-cli add stripe -u billing@example.com --tag work,payments --tag prod
+
+  # Add an API token that expires, with a rotation reminder
+  pass-cli add ci-token --type api-token --expires 2026-12-31 --rotate-every 90d`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAdd,
 }
@@ -80,11 +128,19 @@ func init() {
 	addCmd.Flags().StringVarP(&addPassword, "password", "p", "", "password for the credential (not recommended, use prompt instead)")
 	addCmd.Flags().BoolVarP(&addGeneratePassword, "generate", "g", false, "auto-generate a secure password")
 	addCmd.Flags().IntVar(&addGenLength, "gen-length", 20, "length of generated password (default: 20)")
-	addCmd.Flags().StringVarP(&addCategory, "category", "c", "", "category for organizing credentials (e.g., 'Cloud', 'Databases')")
+	addCmd.Flags().StringVarP(&addCategory, "category", "c", "", "category or folder path (e.g., 'Cloud', 'Clients/Acme/Prod')")
 	addCmd.Flags().StringVar(&addURL, "url", "", "URL associated with the credential (e.g., login page)")
+	addCmd.Flags().StringArrayVar(&addMatchURLs, "match-url", nil, "additional URL, optionally prefixed with host:, prefix: or regex: (repeatable)")
 	addCmd.Flags().StringVar(&addNotes, "notes", "", "optional notes about the credential")
 	addCmd.Flags().StringVar(&addTOTPURI, "totp-uri", "", "TOTP/2FA otpauth:// URI (from QR code or authenticator app)")
 	addCmd.Flags().BoolVar(&addTOTP, "totp", false, "prompt for TOTP secret interactively")
+	addCmd.Flags().StringArrayVar(&addFields, "field", nil, "custom field as name=value (repeatable)")
+	addCmd.Flags().StringArrayVar(&addHiddenFields, "hidden-field", nil, "hidden custom field as name=value (repeatable)")
+	addCmd.Flags().StringSliceVar(&addTags, "tag", nil, "tag for the credential (repeatable or comma-separated)")
+	addCmd.Flags().StringVar(&addType, "type", vault.RecordTypeLogin, "record type: "+strings.Join(vault.RecordTypes(), ", "))
+	addCmd.Flags().StringArrayVar(&addFieldFiles, "field-file", nil, "hidden custom field read from a file as name=path (repeatable)")
+	addCmd.Flags().StringVar(&addExpires, "expires", "", "expiry date (YYYY-MM-DD) or duration from now (e.g. 90d)")
+	addCmd.Flags().StringVar(&addRotateEvery, "rotate-every", "", "rotation reminder interval (e.g. 90d, 12w, 1y)")
 
 	// Mark --password and --generate as mutually exclusive
 	addCmd.MarkFlagsMutuallyExclusive("password", "generate")
@@ -101,6 +157,38 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("service name cannot be empty")
 	}
 
+	// Parse custom fields up front so bad input fails before unlocking
+	customFields, err := parseCustomFieldFlags(addFields, addHiddenFields)
+	if err != nil {
+		return err
+	}
+	tags, err := normalizeTagFlags(addTags)
+	if err != nil {
+		return err
+	}
+	urls, err := parseURLRuleFlags(addMatchURLs)
+	if err != nil {
+		return err
+	}
+	recordType, err := vault.NormalizeRecordType(addType)
+	if err != nil {
+		return err
+	}
+	schema, _ := vault.GetRecordSchema(recordType)
+	fileFields, err := readCustomFieldFiles(addFieldFiles)
+	if err != nil {
+		return err
+	}
+	customFields = append(customFields, fileFields...)
+	expiresAt, rotationDays, err := parseExpiryFlags(addExpires, addRotateEvery)
+	if err != nil {
+		return err
+	}
+
+	if schema.PasswordLabel == "" && (addPassword != "" || addGeneratePassword) {
+		return fmt.Errorf("%s records have no password; store secrets with --field or --hidden-field", recordType)
+	}
+
 	vaultPath := GetVaultPath()
 
 	// Check if vault exists
@@ -123,8 +211,8 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 	defer vaultService.Lock()
 
-	// Get username if not provided
-	if addUsername == "" {
+	// Get username if not provided (logins only - other types treat it as optional)
+	if addUsername == "" && recordType == vault.RecordTypeLogin {
 		fmt.Print("Username: ")
 		if _, err := fmt.Scanln(&addUsername); err != nil {
 			return fmt.Errorf("failed to read username: %w", err)
@@ -132,8 +220,8 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		addUsername = strings.TrimSpace(addUsername)
 	}
 
-	// Get password if not provided
-	if addPassword == "" {
+	// Get password if not provided and the record type has one
+	if addPassword == "" && schema.PasswordLabel != "" {
 		if addGeneratePassword {
 			// Generate a secure password
 			generated, err := generatePasswordForAdd(addGenLength)
@@ -149,8 +237,12 @@ func runAdd(cmd *cobra.Command, args []string) error {
 				fmt.Println("🔐 Generated password (copied to clipboard)")
 			}
 		} else {
-			// Prompt for password
-			fmt.Print("Password: ")
+			// Prompt for password (optional passwords such as SSH passphrases may be left empty)
+			if schema.PasswordRequired {
+				fmt.Printf("%s: ", schema.PasswordLabel)
+			} else {
+				fmt.Printf("%s (leave empty for none): ", schema.PasswordLabel)
+			}
 			password, err := readPassword()
 			if err != nil {
 				return fmt.Errorf("failed to read password: %w", err)
@@ -161,15 +253,37 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate password is not empty
-	if addPassword == "" {
-		return fmt.Errorf("password cannot be empty")
+	if addPassword == "" && schema.PasswordRequired {
+		return fmt.Errorf("%s cannot be empty", strings.ToLower(schema.PasswordLabel))
+	}
+
+	// Prompt for required type-specific fields that were not given as flags
+	customFields, err = promptRequiredSchemaFields(schema, customFields)
+	if err != nil {
+		return err
 	}
 
 	// T020d: Convert string password to []byte for vault
 	passwordBytes := []byte(addPassword)
 
-	// Add credential to vault with all metadata fields
-	if err := vaultService.AddCredential(service, addUsername, passwordBytes, addCategory, addURL, addNotes); err != nil {
+	// Add credential to vault with all metadata fields, custom fields and tags
+	record := vault.Credential{
+		Service:      service,
+		Type:         recordType,
+		Username:     addUsername,
+		Password:     passwordBytes,
+		Category:     addCategory,
+		URL:          addURL,
+		Notes:        addNotes,
+		CustomFields: customFields,
+		Tags:         tags,
+		URLs:         urls,
+		ExpiresAt:    expiresAt,
+	}
+	if rotationDays != nil {
+		record.RotationDays = *rotationDays
+	}
+	if err := vaultService.AddRecord(record); err != nil {
 		return fmt.Errorf("failed to add credential: %w", err)
 	}
 
@@ -218,21 +332,42 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	// Success message
 	fmt.Printf("✅ Credential added successfully!\n")
 	fmt.Printf("📝 Service: %s\n", service)
+	if recordType != vault.RecordTypeLogin {
+		fmt.Printf("📇 Type: %s\n", recordType)
+	}
 	if addUsername != "" {
 		fmt.Printf("👤 Username: %s\n", addUsername)
 	}
-	if addCategory != "" {
-		fmt.Printf("🏷️  Category: %s\n", addCategory)
+	if folder := vault.NormalizeFolder(addCategory); folder != "" {
+		fmt.Printf("🏷️  Category: %s\n", folder)
+	}
+	if len(tags) > 0 {
+		fmt.Printf("🔖 Tags: %s\n", strings.Join(tags, ", "))
 	}
 	if addURL != "" {
 		fmt.Printf("🔗 URL: %s\n", addURL)
 	}
+	for _, rule := range urls {
+		fmt.Printf("🔗 Match URL: %s\n", rule)
+	}
 	if addNotes != "" {
 		fmt.Printf("📋 Notes: %s\n", addNotes)
 	}
 	if totpConfigured {
 		fmt.Printf("🔐 TOTP: configured\n")
 	}
+	if expiresAt != nil {
+		fmt.Printf("⏳ Expires: %s\n", formatExpiry(*expiresAt))
+	}
+	if rotationDays != nil && *rotationDays > 0 {
+		fmt.Printf("🔄 Rotate every: %d days\n", *rotationDays)
+	}
+	for _, field := range customFields {
+		if schemaField, ok := schema.Field(field.Name); ok {
+			field.Hidden = schemaField.Hidden
+		}
+		fmt.Printf("🔹 %s: %s\n", field.Name, displayCustomFieldValue(field, true))
+	}
 
 	syncPushAfterCommand(vaultService)
 	return nil
@@ -0,0 +1,22 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// attachCmd represents the attach command
+var attachCmd = &cobra.Command{
+	Use:     "attach",
+	GroupID: "credentials",
+	Short:   "Manage files attached to a credential",
+	Long: `Attach stores files such as recovery codes, certificates or key files
+alongside a credential.
+
+Attachments are encrypted with the vault's data key. Small files (up to 64 KiB)
+are kept inside the vault file; larger ones (up to 50 MiB) are stored as
+encrypted blobs in the vault.enc.attachments directory next to the vault and
+are carried along by backups, restores and sync. Large attachments require a
+v2 vault ('pass-cli vault migrate').`,
+}
+
+func init() {
+	rootCmd.AddCommand(attachCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var attachAddName string
+
+var attachAddCmd = &cobra.Command{
+	Use:   "add <service> <file>",
+	Short: "Attach a file to a credential",
+	Long: `Add reads a file and stores it encrypted with the credential.
+
+The attachment is named after the file unless --name is given. Names must be
+unique per credential.`,
+	Example: `  # Attach recovery codes
+  pass-cli attach add github ~/Downloads/github-recovery-codes.txt
+
+  # Attach under a different name
+  pass-cli attach add aws ./credentials.csv --name root-keys.csv`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAttachAdd,
+}
+
+func init() {
+	attachCmd.AddCommand(attachAddCmd)
+	attachAddCmd.Flags().StringVar(&attachAddName, "name", "", "attachment name (default: the file's name)")
+}
+
+func runAttachAdd(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	path := args[1]
+
+	name := attachAddName
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	if err := vault.ValidateAttachmentName(name); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	if info.Size() > vault.MaxAttachmentSize {
+		return fmt.Errorf("%s is too large (%s, limit %s)", path, formatSize(info.Size()), formatSize(vault.MaxAttachmentSize))
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- user-specified file to attach
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	attachment, err := vaultService.AddAttachment(service, name, data)
+	if err != nil {
+		return fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	fmt.Printf("✅ Attachment added\n")
+	fmt.Printf("📝 Service: %s\n", service)
+	fmt.Printf("📎 File: %s (%s, %s)\n", attachment.Name, formatSize(attachment.Size), attachmentStorage(attachment))
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
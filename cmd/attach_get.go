@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	attachGetOutput string
+	attachGetForce  bool
+)
+
+var attachGetCmd = &cobra.Command{
+	Use:   "get <service> <name>",
+	Short: "Save an attachment to a file",
+	Long: `Get decrypts an attachment and writes it to a file.
+
+By default the file is written to the current directory under the attachment's
+name. Use --output to choose a different path, or '--output -' to write the
+content to stdout. Existing files are not overwritten unless --force is given.
+Files are created with 0600 permissions.`,
+	Example: `  # Save to ./github-recovery-codes.txt
+  pass-cli attach get github github-recovery-codes.txt
+
+  # Save to a specific path
+  pass-cli attach get aws root-keys.csv --output /tmp/keys.csv
+
+  # Write to stdout
+  pass-cli attach get github github-recovery-codes.txt --output -`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAttachGet,
+}
+
+func init() {
+	attachCmd.AddCommand(attachGetCmd)
+	attachGetCmd.Flags().StringVarP(&attachGetOutput, "output", "o", "", "output path, or - for stdout (default: ./<name>)")
+	attachGetCmd.Flags().BoolVarP(&attachGetForce, "force", "f", false, "overwrite an existing output file")
+}
+
+func runAttachGet(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	name := args[1]
+
+	output := attachGetOutput
+	if output == "" {
+		if err := vault.ValidateAttachmentName(name); err != nil {
+			return err
+		}
+		output = name
+	}
+	if output != "-" && !attachGetForce {
+		if _, err := os.Stat(output); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", output)
+		}
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	attachment, data, err := vaultService.GetAttachment(service, name)
+	if err != nil {
+		return fmt.Errorf("failed to get attachment: %w", err)
+	}
+	defer func() {
+		for i := range data {
+			data[i] = 0
+		}
+	}()
+
+	if output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(output, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	fmt.Printf("✅ Saved %s (%s) to %s\n", attachment.Name, formatSize(attachment.Size), output)
+	return nil
+}
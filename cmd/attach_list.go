@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var attachListFormat string
+
+var attachListCmd = &cobra.Command{
+	Use:     "list <service>",
+	Aliases: []string{"ls"},
+	Short:   "List a credential's attachments",
+	Example: `  # List attachments
+  pass-cli attach list github
+
+  # JSON output for scripting
+  pass-cli attach list github --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAttachList,
+}
+
+func init() {
+	attachCmd.AddCommand(attachListCmd)
+	attachListCmd.Flags().StringVar(&attachListFormat, "format", "table", "output format: table, json")
+}
+
+// attachmentEntry is the JSON representation of an attachment
+type attachmentEntry struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+	AddedAt string `json:"added_at"` // ISO 8601
+	Storage string `json:"storage"`  // inline or sidecar
+}
+
+func runAttachList(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if attachListFormat != "table" && attachListFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", attachListFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	attachments, err := vaultService.ListAttachments(service)
+	if err != nil {
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	if attachListFormat == "json" {
+		entries := make([]attachmentEntry, 0, len(attachments))
+		for _, attachment := range attachments {
+			entries = append(entries, attachmentEntry{
+				Name:    attachment.Name,
+				Size:    attachment.Size,
+				SHA256:  attachment.SHA256,
+				AddedAt: attachment.AddedAt.Format("2006-01-02T15:04:05Z07:00"),
+				Storage: attachmentStorage(attachment),
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(attachments) == 0 {
+		fmt.Printf("No attachments for %s\n", service)
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Name", "Size", "Added", "Storage"})
+
+	var data [][]string
+	for _, attachment := range attachments {
+		data = append(data, []string{
+			attachment.Name,
+			formatSize(attachment.Size),
+			formatRelativeTime(attachment.AddedAt),
+			attachmentStorage(attachment),
+		})
+	}
+
+	_ = table.Bulk(data)
+	_ = table.Render()
+	return nil
+}
+
+// attachmentStorage describes where an attachment's content is kept
+func attachmentStorage(attachment vault.Attachment) string {
+	if attachment.Sidecar {
+		return "sidecar"
+	}
+	return "inline"
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var attachRmForce bool
+
+var attachRmCmd = &cobra.Command{
+	Use:     "rm <service> <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove an attachment from a credential",
+	Long: `Rm deletes an attachment. The previous vault backup still contains it
+until the next save replaces the backup.`,
+	Example: `  # Remove an attachment (asks for confirmation)
+  pass-cli attach rm github github-recovery-codes.txt
+
+  # Remove without confirmation
+  pass-cli attach rm github github-recovery-codes.txt --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAttachRm,
+}
+
+func init() {
+	attachCmd.AddCommand(attachRmCmd)
+	attachRmCmd.Flags().BoolVarP(&attachRmForce, "force", "f", false, "skip confirmation prompt")
+}
+
+func runAttachRm(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	name := args[1]
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	if !attachRmForce {
+		confirmed, err := promptYesNo(fmt.Sprintf("Remove attachment %s from %s?", name, service), false)
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Removal cancelled.")
+			return nil
+		}
+	}
+
+	if err := vaultService.RemoveAttachment(service, name); err != nil {
+		return fmt.Errorf("failed to remove attachment: %w", err)
+	}
+
+	fmt.Printf("✅ Attachment removed\n")
+	fmt.Printf("📝 Service: %s\n", service)
+	fmt.Printf("📎 File: %s\n", name)
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
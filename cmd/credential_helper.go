@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// unlockHelperVault unlocks the vault for a credential helper run by another
+// program, which owns the helper's stdin and stdout. The keychain and identity
+// are tried first; the master password is then asked for on the controlling
+// terminal, if there is one.
+func unlockHelperVault(vaultService *vault.VaultService) error {
+	// In test mode the password follows the request on stdin
+	if os.Getenv("PASS_CLI_TEST") == "1" {
+		return unlockVault(vaultService)
+	}
+
+	if err := vaultService.UnlockWithKeychain(); err == nil {
+		return nil
+	}
+	if unlockWithIdentity(vaultService) == nil {
+		return nil
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("vault is locked and there is no terminal to ask for the master password\nEnable keychain unlock with 'pass-cli keychain enable'")
+	}
+	defer func() { _ = tty.Close() }()
+
+	fmt.Fprint(tty, "pass-cli master password: ")
+	password, err := term.ReadPassword(int(tty.Fd()))
+	fmt.Fprintln(tty)
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	if err := vaultService.Unlock(password); err != nil {
+		return fmt.Errorf("failed to unlock vault: %w", err)
+	}
+	return nil
+}
+
+// storeHelperCredential saves a login reported by a credential helper's caller.
+// The password of the best match is updated if it changed; without a match,
+// record is added as a new credential.
+func storeHelperCredential(vaultService *vault.VaultService, matches []vault.URLMatch, record vault.Credential) error {
+	best := vault.BestURLMatches(matches)
+	if len(best) == 0 {
+		if err := vaultService.AddRecord(record); err != nil {
+			return fmt.Errorf("failed to add credential: %w", err)
+		}
+		return nil
+	}
+
+	service := best[0].Service
+	cred, err := vaultService.GetCredential(service, false)
+	if err != nil {
+		return fmt.Errorf("failed to get credential: %w", err)
+	}
+	if bytes.Equal(cred.Password, record.Password) {
+		return nil
+	}
+	if err := vaultService.UpdateCredential(service, vault.UpdateOpts{Password: &record.Password}); err != nil {
+		return fmt.Errorf("failed to update credential: %w", err)
+	}
+	return nil
+}
+
+// eraseHelperCredentials deletes the best matches in category, the only ones a
+// credential helper's caller may remove. With a password, matches that no longer
+// hold it (updated since the caller read them) are kept.
+func eraseHelperCredentials(vaultService *vault.VaultService, matches []vault.URLMatch, category, password string) error {
+	folder := vault.NormalizeFolder(category)
+	for _, match := range vault.BestURLMatches(matches) {
+		if vault.NormalizeFolder(match.Category) != folder {
+			continue
+		}
+		if password != "" {
+			cred, err := vaultService.GetCredential(match.Service, false)
+			if err != nil {
+				return fmt.Errorf("failed to get credential: %w", err)
+			}
+			if string(cred.Password) != password {
+				continue
+			}
+		}
+		if err := vaultService.DeleteCredential(match.Service); err != nil {
+			return fmt.Errorf("failed to delete credential: %w", err)
+		}
+	}
+	return nil
+}
+
+// warnAmbiguousMatches tells the user which of several equally good matches a
+// credential helper answers with (the first one)
+func warnAmbiguousMatches(remoteURL string, best []vault.URLMatch) {
+	if len(best) < 2 {
+		return
+	}
+	services := make([]string, len(best))
+	for i, match := range best {
+		services[i] = match.Service
+	}
+	fmt.Fprintf(os.Stderr, "pass-cli: %d credentials match %s (%s), using %s\n",
+		len(best), remoteURL, strings.Join(services, ", "), best[0].Service)
+}
+
+// filterURLMatches returns the matches keep accepts, in order
+func filterURLMatches(matches []vault.URLMatch, keep func(vault.URLMatch) bool) []vault.URLMatch {
+	var kept []vault.URLMatch
+	for _, match := range matches {
+		if keep(match) {
+			kept = append(kept, match)
+		}
+	}
+	return kept
+}
@@ -25,6 +25,9 @@ By default, you'll see a usage warning if the credential has been accessed befor
 showing where and when it was last used. This helps prevent accidental deletion
 of actively-used credentials.
 
+Deleted credentials are moved to the trash and can be brought back with
+'pass-cli trash restore' until trash.retention_days (default: 30) have passed.
+
 You can delete multiple credentials at once by providing multiple service names.
 Use --force to skip all confirmation prompts (dangerous!).`,
 	Example: `  # Delete a single credential
@@ -88,6 +91,9 @@ func runDelete(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		// Credentials whose {ref:...} values point at this one
+		referrers := vaultService.Referrers(service)
+
 		// Show usage warning if credential has been accessed
 		if !deleteForce {
 			stats, _ := vaultService.GetUsageStats(service)
@@ -109,6 +115,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 			} else {
 				fmt.Printf("\n🗑️  Deleting '%s' (never used)\n", service)
 			}
+			printReferrersWarning(referrers)
 
 			// Ask for confirmation
 			fmt.Print("Confirm deletion? (y/N): ")
@@ -131,6 +138,9 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("✅ Deleted: %s\n", service)
+		if deleteForce {
+			printReferrersWarning(referrers)
+		}
 		deleted++
 	}
 
@@ -138,6 +148,9 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	if deleted > 0 {
 		fmt.Printf("Successfully deleted %d credential(s)\n", deleted)
+		if days := vaultService.TrashRetentionDays(); days > 0 {
+			fmt.Printf("Restore within %d days with: pass-cli trash restore <service>\n", days)
+		}
 	}
 	if skipped > 0 {
 		fmt.Printf("Skipped %d credential(s)\n", skipped)
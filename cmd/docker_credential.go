@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// dockerCredentialHelperName is the executable Docker runs for "credsStore": "pass-cli"
+const dockerCredentialHelperName = "docker-credential-pass-cli"
+
+// errDockerCredentialsNotFound is the message Docker recognizes as "no credentials"
+var errDockerCredentialsNotFound = errors.New("credentials not found in native keychain")
+
+var dockerCredentialCategory string
+
+var dockerCredentialCmd = &cobra.Command{
+	Use:     "docker-credential <get|store|erase|list|version>",
+	GroupID: "utilities",
+	Short:   "Docker credential helper backed by the vault",
+	Long: `Docker-credential speaks the Docker credential helper protocol, so Docker and
+Podman keep registry logins in the vault instead of ~/.docker/config.json.
+
+  get      Answer with the login of the credential whose URL matches the
+           registry server URL
+  store    Save a login after 'docker login', updating the matching credential
+           or creating one in the docker credential category
+  erase    Delete the registry's login after 'docker logout'; only credentials
+           in the docker credential category are deleted (they go to the trash)
+  list     List the server URL and username of the credentials in the docker
+           credential category
+
+Docker runs the helper as docker-credential-<name>: link that name to pass-cli
+and set "credsStore": "pass-cli" in ~/.docker/config.json. Registry URLs match
+credential URLs as in 'pass-cli git-credential': same scheme (https for URLs
+without one), host and port and a path that is empty or a parent of the server
+URL's path.
+
+New credentials are named <username>@<registry> and put in the category set by
+docker_credential.category in the config file (default: docker) or --category.
+The vault is unlocked with the keychain or identity when possible; otherwise
+the master password is asked for on the terminal.`,
+	Example: `  # Install the helper and use it for every registry
+  ln -s "$(command -v pass-cli)" ~/.local/bin/docker-credential-pass-cli
+  echo '{ "credsStore": "pass-cli" }' > ~/.docker/config.json
+
+  # Or only for one registry ("credHelpers": { "ghcr.io": "pass-cli" })
+
+  # Check what Docker would get
+  echo ghcr.io | docker-credential-pass-cli get`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase", "list", "version"},
+	RunE:      runDockerCredential,
+}
+
+func init() {
+	rootCmd.AddCommand(dockerCredentialCmd)
+	dockerCredentialCmd.Flags().StringVar(&dockerCredentialCategory, "category", "", "category for stored credentials (default: docker_credential.category, \"docker\")")
+}
+
+// IsDockerCredentialHelper reports whether the executable was started through
+// its docker-credential-pass-cli link
+func IsDockerCredentialHelper(executable string) bool {
+	name := strings.TrimSuffix(filepath.Base(executable), ".exe")
+	return name == dockerCredentialHelperName
+}
+
+// ExecuteDockerCredential runs the docker-credential command with the helper's
+// arguments, for invocations through the docker-credential-pass-cli link
+func ExecuteDockerCredential(args []string) {
+	rootCmd.SetArgs(append([]string{"docker-credential"}, args...))
+	Execute()
+}
+
+// dockerCredential is the JSON object of the store and get actions
+type dockerCredential struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func runDockerCredential(cmd *cobra.Command, args []string) error {
+	// Docker reads the error message from stdout
+	if err := dockerCredentialAction(cmd, args[0]); err != nil {
+		fmt.Println(err)
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return exitStatusError{status: 1}
+	}
+	return nil
+}
+
+func dockerCredentialAction(cmd *cobra.Command, action string) error {
+	switch action {
+	case "version":
+		fmt.Printf("%s %s\n", dockerCredentialHelperName, version)
+		return nil
+	case "get", "store", "erase", "list":
+	default:
+		return fmt.Errorf("unknown credential helper action %q (valid: get, store, erase, list, version)", action)
+	}
+
+	// list takes no input; the others read a server URL or a JSON login
+	var request dockerCredential
+	if action != "list" {
+		input, err := readDockerCredentialRequest()
+		if err != nil {
+			return err
+		}
+		if action == "store" {
+			if err := json.Unmarshal([]byte(input), &request); err != nil {
+				return fmt.Errorf("invalid credentials: %w", err)
+			}
+		} else {
+			request.ServerURL = input
+		}
+		if request.ServerURL == "" {
+			return errors.New("no credentials server URL")
+		}
+		if action == "store" && request.Username == "" {
+			return errors.New("no credentials username")
+		}
+	}
+
+	category := dockerCredentialCategory
+	if !cmd.Flags().Changed("category") {
+		cfg, _ := config.Load()
+		category = cfg.DockerCredential.Category
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockHelperVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	if action == "list" {
+		return dockerCredentialList(vaultService, category)
+	}
+
+	matches, err := vaultService.FindByRemote(request.ServerURL)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "get":
+		return dockerCredentialGet(vaultService, request.ServerURL, matches)
+	case "store":
+		matches = filterURLMatches(matches, func(m vault.URLMatch) bool {
+			return m.Username == request.Username
+		})
+		err = storeHelperCredential(vaultService, matches, vault.Credential{
+			Service:  request.Username + "@" + dockerRegistryName(request.ServerURL),
+			Username: request.Username,
+			Password: []byte(request.Secret),
+			URL:      request.ServerURL,
+			Category: category,
+		})
+	case "erase":
+		err = eraseHelperCredentials(vaultService, matches, category, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Push changes to remote
+	syncPushAfterCommand(vaultService)
+	return nil
+}
+
+// readDockerCredentialRequest reads the helper's input: a server URL or a JSON login
+func readDockerCredentialRequest() (string, error) {
+	if os.Getenv("PASS_CLI_TEST") == "1" {
+		// Use the shared scanner: the master password follows the request
+		line, err := readLine()
+		if err != nil {
+			return "", fmt.Errorf("failed to read request: %w", err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read request: %w", err)
+	}
+	return strings.TrimSpace(string(input)), nil
+}
+
+// dockerCredentialGet writes the login of the best match as JSON
+func dockerCredentialGet(vaultService *vault.VaultService, serverURL string, matches []vault.URLMatch) error {
+	best := vault.BestURLMatches(matches)
+	if len(best) == 0 {
+		return errDockerCredentialsNotFound
+	}
+	warnAmbiguousMatches(serverURL, best)
+
+	resolver := newSecretResolver(vaultService)
+	username, err := resolver.secret(best[0].Service, "username")
+	if err != nil {
+		return err
+	}
+	secret, err := resolver.secret(best[0].Service, "password")
+	if err != nil {
+		return err
+	}
+
+	response, err := json.Marshal(dockerCredential{ServerURL: serverURL, Username: username, Secret: secret})
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.Write(response); err != nil {
+		return err
+	}
+
+	resolver.recordAccess()
+	return nil
+}
+
+// dockerCredentialList writes the server URL and username of every credential
+// in category with a URL, as a JSON object
+func dockerCredentialList(vaultService *vault.VaultService, category string) error {
+	credentials, err := vaultService.ListCredentialsWithMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	folder := vault.NormalizeFolder(category)
+	logins := make(map[string]string)
+	for _, cred := range credentials {
+		if cred.URL != "" && vault.NormalizeFolder(cred.Category) == folder {
+			logins[cred.URL] = cred.Username
+		}
+	}
+	return json.NewEncoder(os.Stdout).Encode(logins)
+}
+
+// dockerRegistryName returns the registry host of a server URL, the part used
+// in new credential names ("https://index.docker.io/v1/" -> "index.docker.io")
+func dockerRegistryName(serverURL string) string {
+	raw := serverURL
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	if u, err := url.Parse(raw); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return serverURL
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+func TestIsDockerCredentialHelper(t *testing.T) {
+	tests := map[string]bool{
+		"/usr/local/bin/docker-credential-pass-cli":      true,
+		"docker-credential-pass-cli.exe":                 true,
+		"/usr/local/bin/pass-cli":                        false,
+		"/usr/local/bin/docker-credential-secretservice": false,
+	}
+	for executable, want := range tests {
+		if got := IsDockerCredentialHelper(executable); got != want {
+			t.Errorf("IsDockerCredentialHelper(%q) = %v, want %v", executable, got, want)
+		}
+	}
+}
+
+func TestDockerCredentialErrorExitStatus(t *testing.T) {
+	// Errors go to stdout for Docker, and the exit status back through Execute
+	var err error
+	output := captureStdout(t, func() error {
+		err = runDockerCredential(dockerCredentialCmd, []string{"bogus"})
+		return nil
+	})
+	var exitErr exitStatusError
+	if !errors.As(err, &exitErr) || exitErr.status != 1 {
+		t.Errorf("runDockerCredential(bogus) = %v, want exit status 1", err)
+	}
+	if !strings.Contains(output, `unknown credential helper action "bogus"`) {
+		t.Errorf("stdout = %q, want the error message", output)
+	}
+	if !dockerCredentialCmd.SilenceErrors {
+		t.Error("the error should not be printed again")
+	}
+}
+
+func TestDockerRegistryName(t *testing.T) {
+	tests := map[string]string{
+		"https://index.docker.io/v1/": "index.docker.io",
+		"ghcr.io":                     "ghcr.io",
+		"localhost:5000":              "localhost:5000",
+		"http://registry.local/v2":    "registry.local",
+	}
+	for serverURL, want := range tests {
+		if got := dockerRegistryName(serverURL); got != want {
+			t.Errorf("dockerRegistryName(%q) = %q, want %q", serverURL, got, want)
+		}
+	}
+}
+
+func TestDockerCredentialGetLookalikeRegistry(t *testing.T) {
+	vaultService := setupUnlockedVault(t,
+		vault.Credential{Service: "ghcr", Username: "octocat", Password: []byte("ghp_token"),
+			URLs: []vault.URLRule{{URL: "https://ghcr.io", Match: vault.URLMatchPrefix}}},
+	)
+
+	matches, err := vaultService.FindByRemote("ghcr.io")
+	if err != nil {
+		t.Fatalf("FindByRemote() failed: %v", err)
+	}
+	output := captureStdout(t, func() error { return dockerCredentialGet(vaultService, "ghcr.io", matches) })
+	var got dockerCredential
+	if err := json.Unmarshal([]byte(output), &got); err != nil || got.Username != "octocat" || got.Secret != "ghp_token" {
+		t.Errorf("get ghcr.io = %q (%v), want octocat's login", output, err)
+	}
+
+	for _, serverURL := range []string{"ghcr.io.attacker.net", "https://ghcr.io@attacker.net", "http://ghcr.io"} {
+		matches, err := vaultService.FindByRemote(serverURL)
+		if err != nil {
+			t.Fatalf("FindByRemote(%s) failed: %v", serverURL, err)
+		}
+		if err := dockerCredentialGet(vaultService, serverURL, matches); !errors.Is(err, errDockerCredentialsNotFound) {
+			t.Errorf("get %s: got %v, want %v", serverURL, err, errDockerCredentialsNotFound)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/arimxyer/pass-cli/internal/config"
 	"github.com/arimxyer/pass-cli/internal/health"
+	"github.com/arimxyer/pass-cli/internal/vault"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -32,6 +33,8 @@ The doctor command verifies:
   • Configuration file validity
   • Keychain integration status
   • Backup file status
+  • Expired credentials (only when the keychain can unlock the vault;
+    doctor never prompts for the master password)
 
 Exit codes:
   0 - All checks passed (healthy)
@@ -78,6 +81,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		ConfigPath:      getConfigPath(),
 		SyncConfig:      cfg.Sync, // ARI-53: Pass sync config for health check
 	}
+	opts.Expiries, opts.ExpiryChecked = loadCredentialExpiries(vaultPath)
 
 	// Run all health checks
 	ctx := context.Background()
@@ -193,6 +197,36 @@ func outputHealthReportJSON(report health.HealthReport, opts health.CheckOptions
 	return encoder.Encode(output)
 }
 
+// loadCredentialExpiries reads credential expiry dates for the expiry check.
+// Returns false when the vault cannot be unlocked from the keychain.
+func loadCredentialExpiries(vaultPath string) ([]health.CredentialExpiry, bool) {
+	if _, err := os.Stat(vaultPath); err != nil {
+		return nil, false
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return nil, false
+	}
+	if err := vaultService.UnlockWithKeychain(); err != nil {
+		return nil, false
+	}
+	defer vaultService.Lock()
+
+	metadata, err := vaultService.ListCredentialsWithMetadata()
+	if err != nil {
+		return nil, false
+	}
+
+	var expiries []health.CredentialExpiry
+	for _, meta := range metadata {
+		if !meta.ExpiresAt.IsZero() {
+			expiries = append(expiries, health.CredentialExpiry{Service: meta.Service, ExpiresAt: meta.ExpiresAt})
+		}
+	}
+	return expiries, true
+}
+
 // getConfigPath returns the config file path
 func getConfigPath() string {
 	if cfgFile != "" {
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	envManifest string
+	envFormat   string
+)
+
+var envCmd = &cobra.Command{
+	Use:     "env",
+	GroupID: "credentials",
+	Short:   "Print the project's secrets as shell export lines",
+	Long: `Env reads the project manifest (.pass-cli.yml) in the current directory or a
+parent, resolves every entry with a single unlock and prints one export line
+per variable, for the shell to evaluate. See 'pass-cli project' for the
+manifest format.
+
+The values are printed in plain text. Prefer 'pass-cli run', which passes them
+to a single command without exporting them into your shell session.
+
+Formats:
+  sh           export NAME='value'     (bash, zsh, sh; default)
+  fish         set -gx NAME 'value'
+  powershell   $env:NAME = 'value'`,
+	Example: `  # Load the project's secrets into the current shell
+  eval "$(pass-cli env)"
+
+  # fish
+  pass-cli env --format fish | source
+
+  # PowerShell
+  pass-cli env --format powershell | Invoke-Expression`,
+	Args: cobra.NoArgs,
+	RunE: runEnvExport,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+	envCmd.Flags().StringVar(&envManifest, "manifest", "", "manifest file (default: .pass-cli.yml in this directory or a parent)")
+	envCmd.Flags().StringVar(&envFormat, "format", "sh", "output format: sh, fish, powershell")
+}
+
+func runEnvExport(cmd *cobra.Command, args []string) error {
+	format, ok := envFormatters[envFormat]
+	if !ok {
+		return fmt.Errorf("invalid format %q: use sh, fish or powershell", envFormat)
+	}
+
+	manifest, err := loadManifest(envManifest)
+	if err != nil {
+		return err
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	// Resolve everything first so a missing entry prints nothing
+	resolver := newSecretResolver(vaultService)
+	lines := make([]string, 0, len(manifest.Env))
+	for _, v := range manifest.Env {
+		value, err := resolver.secret(v.Service, v.Field)
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		lines = append(lines, format(v.Name, value))
+	}
+	resolver.recordAccess()
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// envFormatters render one variable assignment per shell, single-quoting the
+// value so the shell does not expand anything in it
+var envFormatters = map[string]func(name, value string) string{
+	"sh": func(name, value string) string {
+		return "export " + name + "='" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+	},
+	"fish": func(name, value string) string {
+		value = strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value)
+		return "set -gx " + name + " '" + value + "'"
+	},
+	"powershell": func(name, value string) string {
+		return "$env:" + name + " = '" + strings.ReplaceAll(value, "'", "''") + "'"
+	},
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import "testing"
+
+func TestEnvFormatters(t *testing.T) {
+	value := `it's $HOME \n`
+	tests := map[string]string{
+		"sh":         `export TOKEN='it'\''s $HOME \n'`,
+		"fish":       `set -gx TOKEN 'it\'s $HOME \\n'`,
+		"powershell": `$env:TOKEN = 'it''s $HOME \n'`,
+	}
+	for format, want := range tests {
+		if got := envFormatters[format]("TOKEN", value); got != want {
+			t.Errorf("%s: got %s, want %s", format, got, want)
+		}
+	}
+}
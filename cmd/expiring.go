@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	expiringWithin string
+	expiringFormat string
+)
+
+var expiringCmd = &cobra.Command{
+	Use:     "expiring",
+	GroupID: "credentials",
+	Short:   "List credentials that are expired or due for rotation",
+	Long: `Expiring lists credentials whose expiry date falls within the given window,
+including those that have already expired, soonest first.
+
+A credential's expiry is the earlier of its fixed expiry date (add/update
+--expires) and its next rotation date (--rotate-every, counted from the last
+password or hidden field change). Credentials without either are never listed.`,
+	Example: `  # Credentials expiring in the next 30 days (and already expired ones)
+  pass-cli expiring
+
+  # Look further ahead
+  pass-cli expiring --within 90d
+
+  # Only credentials that have already expired
+  pass-cli expiring --within 0
+
+  # JSON output for scripting
+  pass-cli expiring --format json`,
+	Args: cobra.NoArgs,
+	RunE: runExpiring,
+}
+
+func init() {
+	rootCmd.AddCommand(expiringCmd)
+	expiringCmd.Flags().StringVar(&expiringWithin, "within", "30d", "time window to look ahead (e.g. 7d, 12w, 1y)")
+	expiringCmd.Flags().StringVar(&expiringFormat, "format", "table", "output format: table, json")
+}
+
+// expiringEntry is the JSON representation of an expiring credential
+type expiringEntry struct {
+	Service      string `json:"service"`
+	ExpiresAt    string `json:"expires_at"` // ISO 8601
+	Expired      bool   `json:"expired"`
+	DaysLeft     int    `json:"days_left"` // Negative once expired
+	RotationDays int    `json:"rotation_days,omitempty"`
+}
+
+func runExpiring(cmd *cobra.Command, args []string) error {
+	days, err := vault.ParseDays(expiringWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	if expiringFormat != "table" && expiringFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", expiringFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	metadata, err := vaultService.ListCredentialsWithMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	now := time.Now()
+	expiring := filterExpiring(metadata, now.AddDate(0, 0, days))
+
+	if expiringFormat == "json" {
+		entries := make([]expiringEntry, 0, len(expiring))
+		for _, meta := range expiring {
+			entries = append(entries, expiringEntry{
+				Service:      meta.Service,
+				ExpiresAt:    meta.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+				Expired:      meta.IsExpired(now),
+				DaysLeft:     daysUntil(meta.ExpiresAt, now),
+				RotationDays: meta.RotationDays,
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(expiring) == 0 {
+		fmt.Printf("No credentials expire within %d days.\n", days)
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Service", "Expires", "Status", "Rotation"})
+
+	var data [][]string
+	for _, meta := range expiring {
+		status := fmt.Sprintf("in %d days", daysUntil(meta.ExpiresAt, now))
+		if meta.IsExpired(now) {
+			status = "EXPIRED"
+		}
+		rotation := "-"
+		if meta.RotationDays > 0 {
+			rotation = fmt.Sprintf("every %d days", meta.RotationDays)
+		}
+		data = append(data, []string{
+			meta.Service,
+			meta.ExpiresAt.Format("2006-01-02"),
+			status,
+			rotation,
+		})
+	}
+
+	_ = table.Bulk(data)
+	_ = table.Render()
+	return nil
+}
+
+// filterExpiring keeps credentials expiring before the cutoff, soonest first
+func filterExpiring(metadata []vault.CredentialMetadata, cutoff time.Time) []vault.CredentialMetadata {
+	filtered := make([]vault.CredentialMetadata, 0)
+
+	for _, meta := range metadata {
+		if !meta.ExpiresAt.IsZero() && meta.ExpiresAt.Before(cutoff) {
+			filtered = append(filtered, meta)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ExpiresAt.Before(filtered[j].ExpiresAt)
+	})
+	return filtered
+}
+
+// daysUntil returns the number of whole days from now until t (negative when t has passed)
+func daysUntil(t, now time.Time) int {
+	return int(t.Sub(now).Hours() / 24)
+}
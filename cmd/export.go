@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/arimxyer/pass-cli/internal/archive"
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/exporter"
+	"github.com/arimxyer/pass-cli/internal/security"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+const (
+	exportFormatEncryptedJSON = "encrypted-json"
+	exportFormatJSON          = "json"
+	exportFormatCSV           = "csv"
+	exportFormatDotenv        = "dotenv"
+)
+
+var exportFormats = []string{exportFormatEncryptedJSON, exportFormatJSON, exportFormatCSV, exportFormatDotenv}
+
+var (
+	exportFormat        string
+	exportOut           string
+	exportStdout        bool
+	exportForce         bool
+	exportPlaintext     bool
+	exportServices      []string
+	exportCategory      string
+	exportTags          []string
+	exportType          string
+	exportFolder        string
+	exportRecursive     bool
+	exportNoAttachments bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:     "export [service...]",
+	GroupID: "vault",
+	Short:   "Export credentials to an encrypted archive or a plaintext file",
+	Long: `Export writes credentials to a file that can be handed to a colleague or read
+by another tool, without sharing the vault or its master password.
+
+Formats:
+  encrypted-json  a pass-cli archive encrypted with a separate export
+                  passphrase (Argon2id + AES-256-GCM). Its header says how it
+                  is encrypted, so it can be read without pass-cli as well.
+                  Import it with 'pass-cli import passcli-archive'. (default)
+  json            the archive content unencrypted: {"entries": [...]}
+  csv             one row per credential (service, username, password, url,
+                  notes, totp, category, tags, then a column per custom field),
+                  readable by 'pass-cli import csv'
+  dotenv          SERVICE_PASSWORD="..." lines for secret managers and
+                  deployment tools
+
+The plaintext formats (json, csv, dotenv) write every secret unencrypted and
+require --i-understand-plaintext. Attachments are only part of the JSON formats.
+
+Name services (as arguments or with --service) to export only those, or select
+them with --tag, --type, --category and --folder (all filters must match).
+Without either, the whole vault is exported. References are resolved to their
+current values. Attachments are included unless --no-attachments is given;
+usage history and revisions never are.
+
+The export goes to the --out file, created with 0600 permissions (existing files
+are not overwritten unless --force is given), or to stdout when it is redirected.
+Export refuses to print to a terminal unless --stdout is given. For
+encrypted-json you are prompted for an export passphrase after the vault
+password: share it with the recipient separately from the file.`,
+	Example: `  # Export everything tagged "team" for a colleague
+  pass-cli export --tag team --out team.passcli
+
+  # Export two credentials without their attachments
+  pass-cli export github aws --out handover.passcli --no-attachments
+
+  # Export a folder and its subfolders
+  pass-cli export --folder Clients/Acme --recursive --out acme.passcli
+
+  # Plaintext CSV of one category
+  pass-cli export --format csv --category Work --out work.csv --i-understand-plaintext
+
+  # dotenv for two services, piped into another tool
+  pass-cli export --format dotenv --service db,api --i-understand-plaintext | secret-tool-import`,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportFormatEncryptedJSON, "export format: "+strings.Join(exportFormats, ", "))
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "file to write (default: stdout when redirected)")
+	exportCmd.Flags().BoolVar(&exportStdout, "stdout", false, "write to stdout even when it is a terminal")
+	exportCmd.Flags().BoolVarP(&exportForce, "force", "f", false, "overwrite an existing output file")
+	exportCmd.Flags().BoolVar(&exportPlaintext, "i-understand-plaintext", false, "allow the unencrypted formats (json, csv, dotenv)")
+	exportCmd.Flags().StringSliceVar(&exportServices, "service", nil, "export only these services (comma-separated or repeatable)")
+	exportCmd.Flags().StringVar(&exportCategory, "category", "", "export only credentials whose category is exactly this")
+	exportCmd.Flags().StringSliceVar(&exportTags, "tag", nil, "export only credentials with this tag (repeatable, all must match)")
+	exportCmd.Flags().StringVar(&exportType, "type", "", "export only records of this type")
+	exportCmd.Flags().StringVar(&exportFolder, "folder", "", "export only credentials in this folder (e.g. Clients/Acme)")
+	exportCmd.Flags().BoolVar(&exportRecursive, "recursive", false, "include subfolders with --folder")
+	exportCmd.Flags().BoolVar(&exportNoAttachments, "no-attachments", false, "leave attachments out of the export")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(exportFormat)
+	switch format {
+	case exportFormatEncryptedJSON:
+	case exportFormatJSON, exportFormatCSV, exportFormatDotenv:
+		if !exportPlaintext {
+			return fmt.Errorf("the %s format writes your secrets unencrypted; add --i-understand-plaintext to confirm, or use --format %s", format, exportFormatEncryptedJSON)
+		}
+	default:
+		return fmt.Errorf("invalid format: %s (valid: %s)", exportFormat, strings.Join(exportFormats, ", "))
+	}
+
+	switch {
+	case exportOut != "" && exportStdout:
+		return fmt.Errorf("--out and --stdout cannot be used together")
+	case exportOut == "" && !exportStdout && term.IsTerminal(int(os.Stdout.Fd())):
+		return fmt.Errorf("refusing to write the export to a terminal; use --out <file>, redirect stdout, or add --stdout")
+	}
+	if exportOut != "" && !exportForce {
+		if _, err := os.Stat(exportOut); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", exportOut)
+		}
+	}
+
+	tags, err := normalizeTagFlags(exportTags)
+	if err != nil {
+		return err
+	}
+	var recordType string
+	if exportType != "" {
+		if recordType, err = vault.NormalizeRecordType(exportType); err != nil {
+			return err
+		}
+	}
+	var category string
+	if exportCategory != "" {
+		if category = vault.NormalizeFolder(exportCategory); category == "" {
+			return fmt.Errorf("category cannot be empty")
+		}
+	}
+	var folder string
+	if exportFolder != "" {
+		if folder = vault.NormalizeFolder(exportFolder); folder == "" {
+			return fmt.Errorf("folder cannot be empty")
+		}
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	metadata, err := vaultService.ListCredentialsWithMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+	if services := append(append([]string(nil), args...), exportServices...); len(services) > 0 {
+		metadata, err = selectServices(metadata, services)
+		if err != nil {
+			return err
+		}
+	}
+	if len(tags) > 0 {
+		metadata = filterByTags(metadata, tags)
+	}
+	if recordType != "" {
+		metadata = filterByType(metadata, recordType)
+	}
+	if category != "" {
+		metadata = filterByFolder(metadata, category, false)
+	}
+	if folder != "" {
+		metadata = filterByFolder(metadata, folder, exportRecursive)
+	}
+	if len(metadata) == 0 {
+		return fmt.Errorf("no credentials match the selection")
+	}
+
+	services := make([]string, 0, len(metadata))
+	for _, meta := range metadata {
+		services = append(services, meta.Service)
+	}
+
+	var passphrase []byte
+	if format == exportFormatEncryptedJSON {
+		if passphrase, err = readExportPassphrase(); err != nil {
+			return err
+		}
+		defer crypto.ClearBytes(passphrase)
+	}
+
+	// Only the JSON formats can carry file content
+	withAttachments := !exportNoAttachments && (format == exportFormatEncryptedJSON || format == exportFormatJSON)
+	credentials, err := vaultService.ExportCredentials(services, withAttachments)
+	if err != nil {
+		return fmt.Errorf("failed to export credentials: %w", err)
+	}
+	defer func() {
+		for i := range credentials {
+			crypto.ClearBytes(credentials[i].Password)
+		}
+	}()
+
+	var data []byte
+	var warnings []string
+	switch format {
+	case exportFormatEncryptedJSON:
+		data, err = archive.Seal(credentials, passphrase)
+	case exportFormatJSON:
+		data, err = archive.Plaintext(credentials)
+	case exportFormatCSV:
+		data, err = exporter.CSV(credentials)
+	case exportFormatDotenv:
+		data, warnings, err = exporter.Dotenv(credentials)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export credentials: %w", err)
+	}
+	defer crypto.ClearBytes(data)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+
+	if exportOut == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+		logExports(vaultService, credentials)
+		return nil
+	}
+
+	if err := writePrivateFile(exportOut, data, exportForce); err != nil {
+		return err
+	}
+	logExports(vaultService, credentials)
+
+	fmt.Printf("✅ Exported %d credential(s) to %s\n", len(credentials), exportOut)
+	if format == exportFormatEncryptedJSON {
+		fmt.Println("🔑 Share the export passphrase separately from the file.")
+	} else {
+		fmt.Println("⚠️  The file holds your secrets in plaintext: delete it when you are done.")
+	}
+	return nil
+}
+
+// logExports records an audit event for every exported credential, once the
+// export has been written
+func logExports(vaultService *vault.VaultService, credentials []vault.Credential) {
+	for _, credential := range credentials {
+		vaultService.LogAudit(security.EventCredentialExport, security.OutcomeSuccess, credential.Service)
+	}
+}
+
+// selectServices keeps the named credentials, failing on names not in the vault
+func selectServices(metadata []vault.CredentialMetadata, services []string) ([]vault.CredentialMetadata, error) {
+	byName := make(map[string]vault.CredentialMetadata, len(metadata))
+	for _, meta := range metadata {
+		byName[meta.Service] = meta
+	}
+
+	selected := make([]vault.CredentialMetadata, 0, len(services))
+	seen := make(map[string]bool, len(services))
+	for _, service := range services {
+		service = strings.TrimSpace(service)
+		meta, exists := byName[service]
+		if !exists {
+			return nil, fmt.Errorf("credential %q not found", service)
+		}
+		if !seen[service] {
+			seen[service] = true
+			selected = append(selected, meta)
+		}
+	}
+	return selected, nil
+}
+
+// readExportPassphrase prompts for a new export passphrase twice
+func readExportPassphrase() ([]byte, error) {
+	fmt.Fprint(os.Stderr, "Export passphrase: ")
+	passphrase, err := readPassword()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	fmt.Fprintln(os.Stderr)
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("export passphrase cannot be empty")
+	}
+
+	fmt.Fprint(os.Stderr, "Confirm export passphrase: ")
+	confirmPassphrase, err := readPassword()
+	if err != nil {
+		crypto.ClearBytes(passphrase)
+		return nil, fmt.Errorf("failed to read confirmation passphrase: %w", err)
+	}
+	fmt.Fprintln(os.Stderr)
+	defer crypto.ClearBytes(confirmPassphrase)
+
+	if string(passphrase) != string(confirmPassphrase) {
+		crypto.ClearBytes(passphrase)
+		return nil, fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
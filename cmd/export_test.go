@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWritePrivateFile(t *testing.T) {
+	dir := t.TempDir()
+	checkFile := func(path, want string) {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != want {
+			t.Fatalf("%s = %q, %v; want %q", path, data, err, want)
+		}
+		if runtime.GOOS == "windows" {
+			return
+		}
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, want 0600", path, info.Mode().Perm())
+		}
+	}
+
+	path := filepath.Join(dir, "export.csv")
+	if err := writePrivateFile(path, []byte("first"), false); err != nil {
+		t.Fatalf("writePrivateFile() failed: %v", err)
+	}
+	checkFile(path, "first")
+
+	// Without overwrite an existing file is kept
+	err := writePrivateFile(path, []byte("second"), false)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("writePrivateFile() on existing file: got %v, want already exists", err)
+	}
+	checkFile(path, "first")
+
+	// Overwriting a world-readable file replaces it with a private one
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePrivateFile(path, []byte("second"), true); err != nil {
+		t.Fatalf("writePrivateFile(overwrite) failed: %v", err)
+	}
+	checkFile(path, "second")
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	// A symlink planted at the path is not written through
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "link.csv")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePrivateFile(link, []byte("secret"), false); err == nil {
+		t.Error("writePrivateFile() wrote through a symlink")
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("symlink target was created: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	findURL    string
+	findAll    bool
+	findFormat string
+)
+
+var findCmd = &cobra.Command{
+	Use:     "find --url <url>",
+	GroupID: "credentials",
+	Short:   "Find credentials by URL",
+	Long: `Find looks up the credentials whose URLs match a page URL.
+
+Each credential's --url is matched by base domain: https://example.com matches
+https://login.example.com/path. URLs added with --match-url can use a stricter rule:
+  domain:  same base domain (the default)
+  host:    same host name, and port if the rule has one
+  prefix:  same scheme, host and port, at or below the rule URL's path
+  regex:   the page URL matches a regular expression
+
+Prefix matches rank highest (longest prefix first), then regex, then exact host,
+then base domain. By default only the best-ranked credentials are shown; use
+--all to list every match, best first.`,
+	Example: `  # Credentials for a login page
+  pass-cli find --url https://login.example.com/path
+
+  # Every matching credential, as JSON
+  pass-cli find --url example.com --all --format json
+
+  # Service names only, for scripts
+  pass-cli find --url https://github.com/acme/repo --format simple`,
+	Args: cobra.NoArgs,
+	RunE: runFind,
+}
+
+func init() {
+	rootCmd.AddCommand(findCmd)
+	findCmd.Flags().StringVar(&findURL, "url", "", "page URL to match (scheme optional)")
+	findCmd.Flags().BoolVar(&findAll, "all", false, "list every match, not just the best")
+	findCmd.Flags().StringVar(&findFormat, "format", "table", "output format: table, json, simple")
+	_ = findCmd.MarkFlagRequired("url")
+}
+
+// findEntry is the JSON representation of a URL match
+type findEntry struct {
+	Service  string `json:"service"`
+	Username string `json:"username,omitempty"`
+	Category string `json:"category,omitempty"`
+	URL      string `json:"url"`   // The credential URL that matched
+	Match    string `json:"match"` // Match rule of that URL
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	if findFormat != "table" && findFormat != "json" && findFormat != "simple" {
+		return fmt.Errorf("invalid format: %s (valid: table, json, simple)", findFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	matches, err := vaultService.FindByURL(findURL)
+	if err != nil {
+		return fmt.Errorf("failed to find credentials: %w", err)
+	}
+	if !findAll {
+		matches = vault.BestURLMatches(matches)
+	}
+
+	switch findFormat {
+	case "json":
+		entries := make([]findEntry, 0, len(matches))
+		for _, match := range matches {
+			entries = append(entries, findEntry{
+				Service:  match.Service,
+				Username: match.Username,
+				Category: match.Category,
+				URL:      match.Rule.URL,
+				Match:    match.Rule.MatchRule(),
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "simple":
+		for _, match := range matches {
+			fmt.Println(match.Service)
+		}
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No credentials match %s\n", findURL)
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Service", "Username", "Matched URL", "Rule"})
+
+	var data [][]string
+	for _, match := range matches {
+		data = append(data, []string{
+			match.Service,
+			match.Username,
+			match.Rule.URL,
+			match.Rule.MatchRule(),
+		})
+	}
+
+	_ = table.Bulk(data)
+	_ = table.Render()
+	return nil
+}
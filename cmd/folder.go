@@ -0,0 +1,22 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// folderCmd represents the folder command
+var folderCmd = &cobra.Command{
+	Use:     "folder",
+	GroupID: "credentials",
+	Short:   "List, move and rename credential folders",
+	Long: `Folder manages the folder tree formed by credential categories.
+
+A category may be a slash-separated path such as Clients/Acme/Prod, which places
+the credential in a nested folder. Plain categories like Cloud are top-level
+folders. Folders exist as long as they (or their subfolders) hold credentials.
+
+Set a credential's folder with 'pass-cli add --category' or
+'pass-cli update --category', and filter with 'pass-cli list --folder'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(folderCmd)
+}
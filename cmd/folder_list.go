@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var folderListFormat string
+
+var folderListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show the folder tree",
+	Long: `List shows every folder as a tree. The count after each folder includes the
+credentials in its subfolders.`,
+	Example: `  # Show the folder tree
+  pass-cli folder list
+
+  # JSON output for scripting
+  pass-cli folder list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runFolderList,
+}
+
+func init() {
+	folderCmd.AddCommand(folderListCmd)
+	folderListCmd.Flags().StringVar(&folderListFormat, "format", "tree", "output format: tree, json")
+}
+
+// folderEntry is the JSON representation of a folder
+type folderEntry struct {
+	Path        string `json:"path"`
+	Credentials int    `json:"credentials"` // Directly in this folder
+	Total       int    `json:"total"`       // Including subfolders
+}
+
+func runFolderList(cmd *cobra.Command, args []string) error {
+	if folderListFormat != "tree" && folderListFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be tree or json)", folderListFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	metadata, err := vaultService.ListCredentialsWithMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	entries, unfiled := buildFolderEntries(metadata)
+
+	if folderListFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No folders. Set one with: pass-cli update <service> --category <folder>")
+		return nil
+	}
+
+	for _, entry := range entries {
+		depth := strings.Count(entry.Path, vault.FolderSeparator)
+		fmt.Printf("%s📁 %s (%d)\n", strings.Repeat("  ", depth), vault.FolderName(entry.Path), entry.Total)
+	}
+	if unfiled > 0 {
+		fmt.Printf("\n%d credential(s) without a folder\n", unfiled)
+	}
+	return nil
+}
+
+// buildFolderEntries counts credentials per folder, returning folders in tree order
+// and the number of credentials without a folder
+func buildFolderEntries(metadata []vault.CredentialMetadata) ([]folderEntry, int) {
+	direct := make(map[string]int)
+	folders := make([]string, 0, len(metadata))
+	unfiled := 0
+	for _, meta := range metadata {
+		folder := vault.NormalizeFolder(meta.Category)
+		if folder == "" {
+			unfiled++
+			continue
+		}
+		direct[folder]++
+		folders = append(folders, folder)
+	}
+
+	paths := vault.FolderPaths(folders)
+	entries := make([]folderEntry, 0, len(paths))
+	for _, path := range paths {
+		total := 0
+		for folder, count := range direct {
+			if vault.InFolder(folder, path, true) {
+				total += count
+			}
+		}
+		entries = append(entries, folderEntry{
+			Path:        path,
+			Credentials: direct[path],
+			Total:       total,
+		})
+	}
+	return entries, unfiled
+}
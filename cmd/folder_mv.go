@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var folderMvCmd = &cobra.Command{
+	Use:     "mv <folder> <new-path>",
+	Aliases: []string{"move"},
+	Short:   "Move a folder with its credentials and subfolders",
+	Long: `Mv moves a folder, including its subfolders, to a new path. If the new path
+already exists the two folders are merged.
+
+Every moved credential records its previous folder in its history, so a single
+credential can be moved back with 'pass-cli history restore'.`,
+	Example: `  # Move a client under an archive folder
+  pass-cli folder mv Clients/Acme Archive/Acme
+
+  # Turn a top-level category into a subfolder
+  pass-cli folder mv Databases Infrastructure/Databases`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFolderMv,
+}
+
+func init() {
+	folderCmd.AddCommand(folderMvCmd)
+}
+
+func runFolderMv(cmd *cobra.Command, args []string) error {
+	return moveFolder(args[0], args[1])
+}
+
+// moveFolder unlocks the vault, moves a folder and reports the result (shared by mv and rename)
+func moveFolder(from, to string) error {
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	moved, err := vaultService.MoveFolder(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to move folder: %w", err)
+	}
+
+	fmt.Printf("✅ Folder moved\n")
+	fmt.Printf("📁 %s → %s\n", vault.NormalizeFolder(from), vault.NormalizeFolder(to))
+	fmt.Printf("📝 Credentials: %d\n", moved)
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var folderRenameCmd = &cobra.Command{
+	Use:   "rename <folder> <new-name>",
+	Short: "Rename a folder in place",
+	Long: `Rename changes the last segment of a folder path, keeping it in the same
+parent folder. Subfolders and credentials move along.`,
+	Example: `  # Clients/Acme becomes Clients/Acme Corp
+  pass-cli folder rename Clients/Acme "Acme Corp"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFolderRename,
+}
+
+func init() {
+	folderCmd.AddCommand(folderRenameCmd)
+}
+
+func runFolderRename(cmd *cobra.Command, args []string) error {
+	folder := vault.NormalizeFolder(args[0])
+	if folder == "" {
+		return fmt.Errorf("folder cannot be empty")
+	}
+	name := strings.TrimSpace(args[1])
+	if name == "" {
+		return fmt.Errorf("new name cannot be empty")
+	}
+	if strings.Contains(name, vault.FolderSeparator) {
+		return fmt.Errorf("new name cannot contain %q (use 'pass-cli folder mv' to move folders)", vault.FolderSeparator)
+	}
+
+	target := name
+	if parent := vault.FolderParent(folder); parent != "" {
+		target = parent + vault.FolderSeparator + name
+	}
+	return moveFolder(folder, target)
+}
@@ -20,6 +20,7 @@ var (
 	getTOTP        bool   // Output TOTP code instead of password
 	getTOTPQR      bool   // Display TOTP QR code in terminal
 	getTOTPQRFile  string // Export TOTP QR code to file
+	getRaw         bool   // Show {ref:...} values unresolved
 )
 
 var getCmd = &cobra.Command{
@@ -29,15 +30,19 @@ var getCmd = &cobra.Command{
 	Long: `Get retrieves a credential from your vault and copies the password to clipboard.
 
 By default, the password is copied to the clipboard and credential details
-are displayed. Use flags to customize the output:
+are displayed. Records without a password (cards, SSH keys, notes, ...) copy
+their main secret instead, and --quiet prints it: the card number, the private
+key, the note text, and so on. Use flags to customize the output:
 
   --quiet      Output only the requested value (for scripts)
-  --field      Extract a specific field (username, password, category, url, notes, service)
+  --field      Extract a specific field (username, password, category, url, notes, service, type, tags,
+               or a custom field name such as a card's number or an SSH key's private-key)
   --no-clipboard  Skip copying to clipboard
   --masked     Display password as asterisks (default shows full password)
   --totp       Output TOTP code instead of password (requires TOTP to be configured)
   --totp-qr    Display TOTP QR code in terminal (for adding to another device)
   --totp-qr-file  Export TOTP QR code to a PNG file
+  --raw        Show reference values such as {ref:corp.username} instead of resolving them
 
 Automatic usage tracking records where credentials are accessed based on
 your current working directory.`,
@@ -50,6 +55,12 @@ your current working directory.`,
   # Get specific field for scripts
   pass-cli get github --field username --quiet
 
+  # Get a custom field (hidden fields are copied to clipboard like passwords)
+  pass-cli get aws --field secret-key
+
+  # Print an SSH private key for scripts
+  pass-cli get deploy-key --quiet > id_deploy
+
   # Get without clipboard
   pass-cli get github --no-clipboard
 
@@ -66,7 +77,10 @@ your current working directory.`,
   pass-cli get github --totp-qr
 
   # Export TOTP QR code to a PNG file
-  pass-cli get github --totp-qr-file totp-github.png`,
+  pass-cli get github --totp-qr-file totp-github.png
+
+  # Show which credential a referenced username comes from
+  pass-cli get corp-admin --field username --raw`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGet,
 }
@@ -74,12 +88,13 @@ your current working directory.`,
 func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.Flags().BoolVarP(&getQuiet, "quiet", "q", false, "output only the requested value (script-friendly)")
-	getCmd.Flags().StringVarP(&getField, "field", "f", "password", "field to extract (username, password, category, url, notes, service)")
+	getCmd.Flags().StringVarP(&getField, "field", "f", "password", "field to extract (username, password, category, url, notes, service, type, tags, or a custom field name; defaults to the record type's main field)")
 	getCmd.Flags().BoolVar(&getNoClipboard, "no-clipboard", false, "do not copy to clipboard")
 	getCmd.Flags().BoolVar(&getMasked, "masked", false, "display password as asterisks")
 	getCmd.Flags().BoolVar(&getTOTP, "totp", false, "output TOTP code instead of password")
 	getCmd.Flags().BoolVar(&getTOTPQR, "totp-qr", false, "display TOTP QR code in terminal")
 	getCmd.Flags().StringVar(&getTOTPQRFile, "totp-qr-file", "", "export TOTP QR code to PNG file")
+	getCmd.Flags().BoolVar(&getRaw, "raw", false, "show {ref:service.field} references unresolved")
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
@@ -111,11 +126,22 @@ func runGet(cmd *cobra.Command, args []string) error {
 	defer vaultService.Lock()
 
 	// Get credential (no automatic tracking)
-	cred, err := vaultService.GetCredential(service, false)
+	var cred *vault.Credential
+	if getRaw {
+		cred, err = vaultService.GetCredentialRaw(service)
+	} else {
+		cred, err = vaultService.GetCredential(service, false)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get credential: %w", err)
 	}
 
+	// Without --field, use the record type's main field (password for logins)
+	schema, _ := vault.GetRecordSchema(cred.RecordType())
+	if !cmd.Flags().Changed("field") {
+		getField = schema.DefaultField
+	}
+
 	// TOTP QR code display mode
 	if getTOTPQR {
 		return outputTOTPQRMode(cred, service)
@@ -131,41 +157,26 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return outputTOTPMode(cred, vaultService, service)
 	}
 
+	// Custom field mode - display a single custom field requested by name
+	if !getQuiet && cmd.Flags().Changed("field") {
+		if field, found := cred.GetCustomField(getField); found {
+			return outputCustomFieldMode(field, vaultService, service)
+		}
+	}
+
 	// Quiet mode - output only requested field
 	if getQuiet {
 		return outputQuietMode(cred, vaultService, service)
 	}
 
 	// Normal mode - display credential details
-	return outputNormalMode(cred, vaultService, service)
+	return outputNormalMode(cred, schema, vaultService, service)
 }
 
 func outputQuietMode(cred *vault.Credential, vaultService *vault.VaultService, service string) error {
-	field := strings.ToLower(getField)
-	var value string
-	var fieldName string
-
-	switch field {
-	case "username", "user", "u":
-		value = cred.Username
-		fieldName = "username"
-	case "password", "pass", "p":
-		value = string(cred.Password) // T020d: Convert []byte to string
-		fieldName = "password"
-	case "category", "cat", "c":
-		value = cred.Category
-		fieldName = "category"
-	case "url":
-		value = cred.URL
-		fieldName = "url"
-	case "notes", "note", "n":
-		value = cred.Notes
-		fieldName = "notes"
-	case "service", "s":
-		value = cred.Service
-		fieldName = "service"
-	default:
-		return fmt.Errorf("invalid field: %s (valid: username, password, category, url, notes, service)", getField)
+	value, fieldName, err := credentialField(cred, getField)
+	if err != nil {
+		return err
 	}
 
 	// Track field access
@@ -301,34 +312,100 @@ func exportTOTPQRFile(cred *vault.Credential, service string, filename string) e
 	return nil
 }
 
-func outputNormalMode(cred *vault.Credential, vaultService *vault.VaultService, service string) error {
+// outputCustomFieldMode displays a single custom field.
+// Hidden fields follow password behavior: optionally masked, copied to clipboard and cleared after 5s.
+func outputCustomFieldMode(field vault.CustomField, vaultService *vault.VaultService, service string) error {
+	fmt.Printf("🔹 %s: %s\n", field.Name, displayCustomFieldValue(field, getMasked))
+
+	if err := vaultService.RecordFieldAccess(service, field.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to track field access: %v\n", err)
+	}
+
+	if !field.Hidden || getNoClipboard {
+		return nil
+	}
+
+	return copyCustomFieldToClipboard(field)
+}
+
+// copyCustomFieldToClipboard copies a hidden field's value and clears it after 5s
+func copyCustomFieldToClipboard(field vault.CustomField) error {
+	if err := clipboard.WriteAll(field.Value); err != nil {
+		fmt.Fprintf(os.Stderr, "\n⚠️  Warning: failed to copy to clipboard: %v\n", err)
+		return nil
+	}
+
+	fmt.Printf("\n✅ %s copied to clipboard!\n", field.Name)
+
+	// Schedule clipboard clear in background (5 seconds)
+	go func() {
+		time.Sleep(5 * time.Second)
+		// Only clear if the clipboard still contains our value
+		if current, err := clipboard.ReadAll(); err == nil && current == field.Value {
+			_ = clipboard.WriteAll("")
+			if IsVerbose() {
+				fmt.Fprintln(os.Stderr, "🧹 Clipboard cleared")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func outputNormalMode(cred *vault.Credential, schema vault.RecordSchema, vaultService *vault.VaultService, service string) error {
 	// Display credential details
 	fmt.Printf("📝 Service: %s\n", cred.Service)
 
+	if cred.RecordType() != vault.RecordTypeLogin {
+		fmt.Printf("📇 Type: %s\n", cred.RecordType())
+	}
+
+	usernameLabel := schema.UsernameLabel
+	if usernameLabel == "" {
+		usernameLabel = "Username"
+	}
 	if cred.Username != "" {
-		fmt.Printf("👤 Username: %s\n", cred.Username)
+		fmt.Printf("👤 %s: %s\n", usernameLabel, cred.Username)
 	}
 
-	// Display password (masked or full)
-	if getMasked {
-		fmt.Printf("🔑 Password: %s\n", strings.Repeat("*", len(cred.Password)))
-	} else {
-		// T020d: Convert []byte to string for display
-		fmt.Printf("🔑 Password: %s\n", string(cred.Password))
+	// Display password (masked or full); types without a password skip it
+	passwordLabel := schema.PasswordLabel
+	if passwordLabel == "" {
+		passwordLabel = "Password"
+	}
+	if len(cred.Password) > 0 {
+		if getMasked {
+			fmt.Printf("🔑 %s: %s\n", passwordLabel, strings.Repeat("*", len(cred.Password)))
+		} else {
+			// T020d: Convert []byte to string for display
+			fmt.Printf("🔑 %s: %s\n", passwordLabel, string(cred.Password))
+		}
 	}
 
 	if cred.Category != "" {
 		fmt.Printf("🏷️ Category: %s\n", cred.Category)
 	}
 
+	if len(cred.Tags) > 0 {
+		fmt.Printf("🔖 Tags: %s\n", strings.Join(cred.Tags, ", "))
+	}
+
 	if cred.URL != "" {
 		fmt.Printf("🔗 URL: %s\n", cred.URL)
 	}
+	for _, rule := range cred.URLs {
+		fmt.Printf("🔗 Match URL: %s\n", rule)
+	}
 
 	if cred.Notes != "" {
 		fmt.Printf("📋 Notes: %s\n", cred.Notes)
 	}
 
+	// Display custom fields (hidden values stay masked; use --field <name> to reveal)
+	for _, field := range cred.CustomFields {
+		fmt.Printf("🔹 %s: %s\n", field.Name, displayCustomFieldValue(field, true))
+	}
+
 	// Display TOTP status if configured
 	if cred.HasTOTP() {
 		issuer := cred.TOTPIssuer
@@ -338,6 +415,21 @@ func outputNormalMode(cred *vault.Credential, vaultService *vault.VaultService,
 		fmt.Printf("🔐 TOTP: %s (use --totp to get code)\n", issuer)
 	}
 
+	if expiry, ok := cred.ExpiryDate(); ok {
+		fmt.Printf("⏳ Expires: %s\n", formatExpiry(expiry))
+	}
+	if cred.RotationDays > 0 {
+		fmt.Printf("🔄 Rotate every: %d days\n", cred.RotationDays)
+	}
+
+	if len(cred.Attachments) > 0 {
+		names := make([]string, 0, len(cred.Attachments))
+		for _, attachment := range cred.Attachments {
+			names = append(names, attachment.Name)
+		}
+		fmt.Printf("📎 Attachments: %s (use 'pass-cli attach get')\n", strings.Join(names, ", "))
+	}
+
 	// Display timestamps
 	fmt.Printf("📅 Created: %s\n", cred.CreatedAt.Format("2006-01-02 15:04:05"))
 	if !cred.UpdatedAt.Equal(cred.CreatedAt) {
@@ -350,6 +442,15 @@ func outputNormalMode(cred *vault.Credential, vaultService *vault.VaultService,
 			fmt.Fprintf(os.Stderr, "Warning: failed to track username access: %v\n", err)
 		}
 	}
+
+	// Records without a password copy their main hidden field instead (card number, private key)
+	if len(cred.Password) == 0 {
+		if field, found := cred.GetCustomField(schema.DefaultField); found && field.Hidden && !getNoClipboard {
+			return copyCustomFieldToClipboard(field)
+		}
+		return nil
+	}
+
 	if err := vaultService.RecordFieldAccess(service, "password"); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to track password access: %v\n", err)
 	}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var gitCredentialCategory string
+
+var gitCredentialCmd = &cobra.Command{
+	Use:     "git-credential <get|store|erase>",
+	GroupID: "utilities",
+	Short:   "Git credential helper backed by the vault",
+	Long: `Git-credential speaks git's credential helper protocol, so git reads HTTPS
+passwords and tokens from the vault instead of prompting for them.
+
+  get     Answer with the username and password of the credential whose URL
+          matches the request's protocol, host and path
+  store   Save a username and password git has used successfully, updating the
+          matching credential or creating one in the git credential category
+  erase   Delete a rejected credential; only credentials in the git credential
+          category are deleted (they go to the trash)
+
+A credential URL matches when it names the same protocol, host and port and a
+path that is empty or a parent of the repository path; a URL without a scheme
+only matches https. The longest matching path wins. Other hosts of the same
+domain do not match. Git only sends the repository path
+with credential.useHttpPath enabled.
+
+New credentials are named <username>@<host>[/<path>] and put in the category
+set by git_credential.category in the config file (default: git) or --category.
+
+Git owns stdin and stdout while the helper runs, so the vault is unlocked with
+the keychain or identity when possible; otherwise the master password is asked
+for on the terminal.`,
+	Example: `  # Use pass-cli for every HTTPS remote
+  git config --global credential.helper "pass-cli git-credential"
+
+  # Only for GitHub, with per-repository credentials
+  git config --global credential.https://github.com.helper "pass-cli git-credential"
+  git config --global credential.https://github.com.useHttpPath true
+
+  # Store a token ahead of time (what git does after a successful push)
+  printf 'protocol=https\nhost=github.com\nusername=octocat\npassword=ghp_xxx\n' | pass-cli git-credential store`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase"},
+	RunE:      runGitCredential,
+}
+
+func init() {
+	rootCmd.AddCommand(gitCredentialCmd)
+	gitCredentialCmd.Flags().StringVar(&gitCredentialCategory, "category", "", "category for stored credentials (default: git_credential.category, \"git\")")
+}
+
+// gitCredentialRequest is the set of attributes git sends to a helper
+type gitCredentialRequest struct {
+	protocol string
+	host     string // Host name, with the port if not the default
+	path     string
+	username string
+	password string
+}
+
+// url returns the remote the request is for, as a URL
+func (r gitCredentialRequest) url() string {
+	u := r.protocol + "://" + r.host
+	if r.path != "" {
+		u += "/" + strings.TrimPrefix(r.path, "/")
+	}
+	return u
+}
+
+func runGitCredential(cmd *cobra.Command, args []string) error {
+	action := args[0]
+	if action != "get" && action != "store" && action != "erase" {
+		// The protocol asks helpers to ignore actions they don't know
+		return nil
+	}
+
+	request, err := readGitCredentialRequest()
+	if err != nil {
+		return err
+	}
+	// Only network remotes can be matched against credential URLs
+	if request.protocol == "" || request.host == "" {
+		return nil
+	}
+	if action == "store" && (request.username == "" || request.password == "") {
+		return nil
+	}
+
+	category := gitCredentialCategory
+	if !cmd.Flags().Changed("category") {
+		cfg, _ := config.Load()
+		category = cfg.GitCredential.Category
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockHelperVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	matches, err := vaultService.FindByRemote(request.url())
+	if err != nil {
+		return err
+	}
+	if request.username != "" {
+		matches = filterURLMatches(matches, func(m vault.URLMatch) bool {
+			return m.Username == request.username
+		})
+	}
+
+	switch action {
+	case "get":
+		return gitCredentialGet(vaultService, request, matches)
+	case "store":
+		service := request.username + "@" + request.host
+		if request.path != "" {
+			service += "/" + strings.TrimSuffix(strings.Trim(request.path, "/"), ".git")
+		}
+		err = storeHelperCredential(vaultService, matches, vault.Credential{
+			Service:  service,
+			Username: request.username,
+			Password: []byte(request.password),
+			URL:      request.url(),
+			Category: category,
+		})
+	case "erase":
+		err = eraseHelperCredentials(vaultService, matches, category, request.password)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Push changes to remote
+	syncPushAfterCommand(vaultService)
+	return nil
+}
+
+// readGitCredentialRequest reads the key=value lines git writes to the helper,
+// up to a blank line or the end of input
+func readGitCredentialRequest() (gitCredentialRequest, error) {
+	var lines []string
+	if os.Getenv("PASS_CLI_TEST") == "1" {
+		// Use the shared scanner: the master password follows the request
+		for {
+			line, err := readLine()
+			if err != nil || line == "" {
+				break
+			}
+			lines = append(lines, line)
+		}
+	} else {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() && scanner.Text() != "" {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return gitCredentialRequest{}, fmt.Errorf("failed to read request: %w", err)
+		}
+	}
+
+	var request gitCredentialRequest
+	for _, line := range lines {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return gitCredentialRequest{}, fmt.Errorf("invalid request line %q: expected key=value", line)
+		}
+		// Unknown and multi-valued (key[]) attributes are not needed
+		switch key {
+		case "protocol":
+			request.protocol = value
+		case "host":
+			request.host = value
+		case "path":
+			request.path = value
+		case "username":
+			request.username = value
+		case "password":
+			request.password = value
+		}
+	}
+	return request, nil
+}
+
+// gitCredentialGet writes the username and password of the best match
+func gitCredentialGet(vaultService *vault.VaultService, request gitCredentialRequest, matches []vault.URLMatch) error {
+	best := vault.BestURLMatches(matches)
+	if len(best) == 0 {
+		// No answer: git moves on to the next helper or prompts
+		return nil
+	}
+	warnAmbiguousMatches(request.url(), best)
+
+	resolver := newSecretResolver(vaultService)
+	username, err := resolver.secret(best[0].Service, "username")
+	if err != nil {
+		return err
+	}
+	password, err := resolver.secret(best[0].Service, "password")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return nil
+	}
+	if strings.ContainsAny(username+password, "\n\x00") {
+		return fmt.Errorf("%s: git credentials cannot contain newlines or NUL characters", best[0].Service)
+	}
+
+	var response bytes.Buffer
+	if username != "" {
+		fmt.Fprintf(&response, "username=%s\n", username)
+	}
+	fmt.Fprintf(&response, "password=%s\n", password)
+	defer crypto.ClearBytes(response.Bytes())
+	if _, err := os.Stdout.Write(response.Bytes()); err != nil {
+		return err
+	}
+
+	resolver.recordAccess()
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+func TestGitCredentialGetLookalikeHost(t *testing.T) {
+	vaultService := setupUnlockedVault(t,
+		vault.Credential{Service: "github", Username: "octocat", Password: []byte("ghp_token"),
+			URLs: []vault.URLRule{{URL: "https://github.com", Match: vault.URLMatchPrefix}}},
+	)
+
+	tests := []struct {
+		name    string
+		request gitCredentialRequest
+		want    string
+	}{
+		{"same host", gitCredentialRequest{protocol: "https", host: "github.com", path: "acme/api.git"}, "username=octocat\npassword=ghp_token\n"},
+		{"lookalike host", gitCredentialRequest{protocol: "https", host: "github.com.evil.net", path: "acme/api.git"}, ""},
+		{"longer host", gitCredentialRequest{protocol: "https", host: "github.company.io"}, ""},
+		{"other scheme", gitCredentialRequest{protocol: "http", host: "github.com"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := vaultService.FindByRemote(tt.request.url())
+			if err != nil {
+				t.Fatalf("FindByRemote() failed: %v", err)
+			}
+			got := captureStdout(t, func() error { return gitCredentialGet(vaultService, tt.request, matches) })
+			if got != tt.want {
+				t.Errorf("git-credential get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
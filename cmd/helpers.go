@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"github.com/arimxyer/pass-cli/internal/config"
 	"github.com/arimxyer/pass-cli/internal/recovery"
+	"github.com/arimxyer/pass-cli/internal/storage"
 	"github.com/arimxyer/pass-cli/internal/vault"
 	"os"
 	"path/filepath"
@@ -16,6 +19,7 @@ import (
 	"github.com/howeyc/gopass"
 	"github.com/olekukonko/tablewriter"
 	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
 )
 
 // Package-level scanner for test mode stdin reading
@@ -268,6 +272,208 @@ func formatUsageTable(records []vault.UsageRecord) string {
 	return builder.String()
 }
 
+// parseCustomFieldFlags converts repeated --field/--hidden-field name=value flags into custom fields
+func parseCustomFieldFlags(visible, hidden []string) ([]vault.CustomField, error) {
+	fields := make([]vault.CustomField, 0, len(visible)+len(hidden))
+	for _, assignment := range visible {
+		field, err := vault.ParseCustomField(assignment, false)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	for _, assignment := range hidden {
+		field, err := vault.ParseCustomField(assignment, true)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// readCustomFieldFiles reads repeated --field-file name=path flags into hidden custom fields.
+// A leading ~ in the path is expanded to the home directory.
+func readCustomFieldFiles(assignments []string) ([]vault.CustomField, error) {
+	fields := make([]vault.CustomField, 0, len(assignments))
+	for _, assignment := range assignments {
+		field, err := vault.ParseCustomField(assignment, true)
+		if err != nil {
+			return nil, err
+		}
+		path := strings.TrimSpace(field.Value)
+		if strings.HasPrefix(path, "~") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+			}
+			path = filepath.Join(home, path[1:])
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- user-specified file for field content
+		if err != nil {
+			return nil, fmt.Errorf("failed to read field %s: %w", field.Name, err)
+		}
+		field.Value = string(data)
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// promptRequiredSchemaFields prompts for required type-specific fields missing from fields.
+// Hidden fields are read without echo; multi-line fields must be given with --field-file.
+func promptRequiredSchemaFields(schema vault.RecordSchema, fields []vault.CustomField) ([]vault.CustomField, error) {
+	for _, schemaField := range schema.Fields {
+		if !schemaField.Required || containsCustomFieldName(fields, schemaField.Name) {
+			continue
+		}
+		if schemaField.Multiline {
+			return nil, fmt.Errorf("%s records require %s: use --field-file %s=PATH", schema.Type, schemaField.Name, schemaField.Name)
+		}
+
+		fmt.Printf("%s: ", schemaField.Name)
+		var value string
+		if schemaField.Hidden {
+			input, err := readPassword()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", schemaField.Name, err)
+			}
+			fmt.Println() // newline after hidden input
+			value = string(input)
+		} else {
+			input, err := readLineInput()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", schemaField.Name, err)
+			}
+			value = input
+		}
+		if strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("%s cannot be empty", schemaField.Name)
+		}
+
+		fields = append(fields, vault.CustomField{Name: schemaField.Name, Value: value, Hidden: schemaField.Hidden})
+	}
+	return fields, nil
+}
+
+// containsCustomFieldName reports whether fields has an entry with the given name (case-insensitive)
+func containsCustomFieldName(fields []vault.CustomField, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTagFlags validates --tag values and returns them lowercased and deduplicated
+func normalizeTagFlags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag, err := vault.NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// parseURLRuleFlags parses --match-url values ("host:login.example.com", "regex:...")
+func parseURLRuleFlags(specs []string) ([]vault.URLRule, error) {
+	rules := make([]vault.URLRule, 0, len(specs))
+	for _, spec := range specs {
+		rule, err := vault.ParseURLRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// printReferrersWarning lists the credentials with {ref:...} values pointing at a
+// credential that is being deleted or renamed; their references stop resolving
+func printReferrersWarning(referrers []string) {
+	if len(referrers) == 0 {
+		return
+	}
+	fmt.Printf("⚠️  Referenced by: %s\n", strings.Join(referrers, ", "))
+	fmt.Printf("   Their {ref:...} values will no longer resolve until updated\n")
+}
+
+// parseExpiryFlags parses --expires and --rotate-every; empty values return nil
+func parseExpiryFlags(expires, rotateEvery string) (*time.Time, *int, error) {
+	var expiresAt *time.Time
+	if expires != "" {
+		date, err := vault.ParseExpiry(expires, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		expiresAt = &date
+	}
+
+	var rotationDays *int
+	if rotateEvery != "" {
+		days, err := vault.ParseDays(rotateEvery)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --rotate-every: %w", err)
+		}
+		rotationDays = &days
+	}
+	return expiresAt, rotationDays, nil
+}
+
+// formatExpiry formats an expiry date with how far away it is, e.g. "2026-03-01 (in 12 days)"
+func formatExpiry(expiresAt time.Time) string {
+	date := expiresAt.Format("2006-01-02")
+	remaining := time.Until(expiresAt)
+	if remaining <= 0 {
+		return fmt.Sprintf("%s (expired %s ago)", date, formatAge(-remaining))
+	}
+	return fmt.Sprintf("%s (in %s)", date, formatAge(remaining))
+}
+
+// displayCustomFieldValue returns the field value, masked with asterisks when hidden and mask is set
+func displayCustomFieldValue(field vault.CustomField, mask bool) string {
+	if field.Hidden && mask {
+		return strings.Repeat("*", len(field.Value))
+	}
+	return field.Value
+}
+
+// credentialField returns the value of a credential field by name or alias
+// (username, password, category, url, notes, service, type, tags or a custom
+// field), with the canonical name used to track field access
+func credentialField(cred *vault.Credential, field string) (value, name string, err error) {
+	switch strings.ToLower(field) {
+	case "username", "user", "u":
+		return cred.Username, "username", nil
+	case "password", "pass", "p":
+		return string(cred.Password), "password", nil // T020d: Convert []byte to string
+	case "category", "cat", "c":
+		return cred.Category, "category", nil
+	case "url":
+		return cred.URL, "url", nil
+	case "notes", "note", "n":
+		return cred.Notes, "notes", nil
+	case "service", "s":
+		return cred.Service, "service", nil
+	case "type":
+		return cred.RecordType(), "type", nil
+	case "tags", "tag":
+		return strings.Join(cred.Tags, ","), "tags", nil
+	}
+	customField, found := cred.GetCustomField(field)
+	if !found {
+		return "", "", fmt.Errorf("invalid field: %s (valid: username, password, category, url, notes, service, type, tags, or a custom field name)", field)
+	}
+	return customField.Value, customField.Name, nil
+}
+
 // initVaultAndStorage initializes vault service and returns both vault and storage services
 // This pattern is common across backup commands to avoid code duplication
 func initVaultAndStorage(vaultPath string) (*vault.VaultService, error) {
@@ -323,7 +529,7 @@ func formatAge(d time.Duration) string {
 		return fmt.Sprintf("%d weeks", weeks)
 	}
 	months := days / 30
-	if months == 1 {
+	if months <= 1 { // 28-59 days
 		return "1 month"
 	}
 	return fmt.Sprintf("%d months", months)
@@ -344,8 +550,109 @@ func formatSize(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// unlockVault attempts to unlock the vault with keychain or prompts for password
+// writePrivateFile writes data to a file only the user can read. Without
+// overwrite the file must not exist yet: it is created exclusively, so neither an
+// existing file nor a symlink planted at path is written through. With overwrite
+// the data goes to a 0600 temporary file in the same directory, which then
+// replaces path, so the secrets are never in a file with broader permissions.
+func writePrivateFile(path string, data []byte, overwrite bool) (err error) {
+	var f *os.File
+	if overwrite {
+		f, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	} else {
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600) // #nosec G304 -- output path given by the user
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if overwrite {
+		if err = os.Rename(f.Name(), path); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// updateConfigFile applies changes to the config file as a generic YAML map, so
+// settings the change does not touch are preserved. The file is created if missing.
+func updateConfigFile(update func(configMap map[string]interface{}) error) error {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// #nosec G304 -- configPath is from config.GetConfigPath(), not user input
+	content, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var configMap map[string]interface{}
+	if len(content) > 0 {
+		if err := yaml.Unmarshal(content, &configMap); err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	if configMap == nil {
+		configMap = make(map[string]interface{})
+	}
+
+	if err := update(configMap); err != nil {
+		return err
+	}
+
+	newContent, err := yaml.Marshal(configMap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return os.WriteFile(configPath, newContent, 0600)
+}
+
+// profileConfigSection returns the vaults.<name> map of a config map, creating it if needed
+func profileConfigSection(configMap map[string]interface{}, name string) map[string]interface{} {
+	vaults, ok := configMap["vaults"].(map[string]interface{})
+	if !ok {
+		vaults = make(map[string]interface{})
+		configMap["vaults"] = vaults
+	}
+	section, ok := vaults[name].(map[string]interface{})
+	if !ok {
+		section = make(map[string]interface{})
+		vaults[name] = section
+	}
+	return section
+}
+
+// unlockVault attempts to unlock the vault with keychain or identity, or prompts for password
 func unlockVault(vaultService *vault.VaultService) error {
+	return unlockVaultUsing(vaultService, true)
+}
+
+// unlockVaultWithPassword unlocks with the master password (keychain or prompt),
+// for operations that re-wrap the vault key and cannot run on an identity unlock
+func unlockVaultWithPassword(vaultService *vault.VaultService) error {
+	return unlockVaultUsing(vaultService, false)
+}
+
+func unlockVaultUsing(vaultService *vault.VaultService, allowIdentity bool) error {
 	// Try to unlock with keychain (if enabled and available)
 	// This attempts keyring.Get() which doesn't require GUI authorization on macOS
 	if err := vaultService.UnlockWithKeychain(); err == nil {
@@ -355,6 +662,11 @@ func unlockVault(vaultService *vault.VaultService) error {
 		return nil
 	}
 
+	// Try the local identity file (vault shared with this user as a recipient)
+	if allowIdentity && unlockWithIdentity(vaultService) == nil {
+		return nil
+	}
+
 	// Prompt for master password if keychain fails or is unavailable
 	fmt.Fprint(os.Stderr, "Master password: ")
 	password, err := readPassword()
@@ -370,6 +682,22 @@ func unlockVault(vaultService *vault.VaultService) error {
 	return nil
 }
 
+// unlockWithIdentity unlocks with the identity file when it holds a recipient
+// slot of the vault. A missing identity or slot is not worth a warning.
+func unlockWithIdentity(vaultService *vault.VaultService) error {
+	err := vaultService.UnlockWithIdentity()
+	switch {
+	case err == nil:
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "🔓 Unlocked vault using identity (%s)\n", vaultService.UnlockedIdentity())
+		}
+	case errors.Is(err, vault.ErrNoIdentity), errors.Is(err, storage.ErrNotRecipient), errors.Is(err, storage.ErrNoDataKey):
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: identity unlock failed: %v\n", err)
+	}
+	return err
+}
+
 // syncPullBeforeUnlock performs a smart sync pull before vault unlock.
 // This ensures we have the latest version from remote before reading.
 func syncPullBeforeUnlock(vaultService *vault.VaultService) {
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// setupUnlockedVault returns an unlocked vault holding records, the cmd
+// counterpart of the vault package's fixture of the same name
+func setupUnlockedVault(t *testing.T, records ...vault.Credential) *vault.VaultService {
+	t.Helper()
+	vaultService, err := vault.New(filepath.Join(t.TempDir(), "vault.enc"))
+	if err != nil {
+		t.Fatalf("vault.New() failed: %v", err)
+	}
+	if err := vaultService.Initialize([]byte("TestPassword123!"), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vaultService.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	t.Cleanup(vaultService.Lock)
+	for _, record := range records {
+		if err := vaultService.AddRecord(record); err != nil {
+			t.Fatalf("AddRecord(%s) failed: %v", record.Service, err)
+		}
+	}
+	return vaultService
+}
+
+// captureStdout returns what run writes to os.Stdout
+func captureStdout(t *testing.T, run func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := run()
+	os.Stdout = stdout
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("helper failed: %v", runErr)
+	}
+	return string(output)
+}
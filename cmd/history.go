@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	historyFormat string
+	historyReveal bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:     "history <service>",
+	GroupID: "credentials",
+	Short:   "Show the revision history of a credential",
+	Long: `History lists previous versions of a credential, newest first.
+
+Each time a credential is updated, the values being replaced are saved as a
+numbered revision inside the encrypted vault. Only the fields that changed are
+recorded. The number of revisions kept per credential is controlled by
+history.max_revisions in the config file (default: 10, 0 disables history).
+
+Previous passwords and hidden values are masked unless --reveal is given.
+Use 'pass-cli history restore' to roll a credential back to a revision.`,
+	Example: `  # Show revision history
+  pass-cli history github
+
+  # Show previous passwords in plaintext
+  pass-cli history github --reveal
+
+  # JSON output for scripting
+  pass-cli history github --format json
+
+  # Restore revision 3
+  pass-cli history restore github --revision 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVar(&historyFormat, "format", "table", "output format: table, json")
+	historyCmd.Flags().BoolVar(&historyReveal, "reveal", false, "show previous passwords and hidden field values")
+}
+
+// historyEntry is the JSON representation of a revision
+type historyEntry struct {
+	Revision     int                 `json:"revision"`
+	Replaced     string              `json:"replaced"` // ISO 8601
+	Changed      []string            `json:"changed"`
+	Username     *string             `json:"username,omitempty"`
+	Password     *string             `json:"password,omitempty"`
+	Category     *string             `json:"category,omitempty"`
+	URL          *string             `json:"url,omitempty"`
+	Notes        *string             `json:"notes,omitempty"`
+	CustomFields []vault.CustomField `json:"custom_fields,omitempty"`
+	Tags         *[]string           `json:"tags,omitempty"`
+	URLs         *[]vault.URLRule    `json:"urls,omitempty"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if historyFormat != "table" && historyFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", historyFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	history, err := vaultService.GetHistory(service)
+	if err != nil {
+		return fmt.Errorf("failed to get history: %w", err)
+	}
+	defer func() {
+		for _, rev := range history {
+			for i := range rev.Password {
+				rev.Password[i] = 0
+			}
+		}
+	}()
+
+	if historyFormat == "json" {
+		return outputHistoryJSON(history)
+	}
+
+	if len(history) == 0 {
+		fmt.Printf("No revision history for %s\n", service)
+		return nil
+	}
+
+	return outputHistoryTable(history)
+}
+
+// maskHistoryValue masks a previous secret value unless --reveal was given
+func maskHistoryValue(value string) string {
+	if historyReveal {
+		return value
+	}
+	return strings.Repeat("*", len(value))
+}
+
+// formatRevisionChanges renders the previous values of a revision as "field: value" lines
+func formatRevisionChanges(rev vault.Revision) string {
+	var lines []string
+	if rev.Username != nil {
+		lines = append(lines, "username: "+*rev.Username)
+	}
+	if rev.Password != nil {
+		lines = append(lines, "password: "+maskHistoryValue(string(rev.Password)))
+	}
+	if rev.Category != nil {
+		lines = append(lines, "category: "+*rev.Category)
+	}
+	if rev.URL != nil {
+		lines = append(lines, "url: "+*rev.URL)
+	}
+	if rev.Notes != nil {
+		lines = append(lines, "notes: "+*rev.Notes)
+	}
+	if rev.CustomFields != nil {
+		if len(*rev.CustomFields) == 0 {
+			lines = append(lines, "fields: (none)")
+		}
+		for _, field := range *rev.CustomFields {
+			value := field.Value
+			if field.Hidden {
+				value = maskHistoryValue(value)
+			}
+			lines = append(lines, fmt.Sprintf("field %s: %s", field.Name, value))
+		}
+	}
+	if rev.Tags != nil {
+		tags := strings.Join(*rev.Tags, ", ")
+		if tags == "" {
+			tags = "(none)"
+		}
+		lines = append(lines, "tags: "+tags)
+	}
+	if rev.URLs != nil {
+		if len(*rev.URLs) == 0 {
+			lines = append(lines, "match urls: (none)")
+		}
+		for _, rule := range *rev.URLs {
+			lines = append(lines, "match url: "+rule.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// outputHistoryTable prints revisions newest first
+func outputHistoryTable(history []vault.Revision) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Revision", "Replaced", "Previous Values"})
+
+	var data [][]string
+	for i := len(history) - 1; i >= 0; i-- {
+		rev := history[i]
+		data = append(data, []string{
+			fmt.Sprintf("%d", rev.Number),
+			formatRelativeTime(rev.Timestamp),
+			formatRevisionChanges(rev),
+		})
+	}
+
+	_ = table.Bulk(data)
+	_ = table.Render()
+
+	if !historyReveal {
+		fmt.Println("\nSecrets are masked. Use --reveal to show them.")
+	}
+	return nil
+}
+
+// outputHistoryJSON prints revisions newest first as JSON
+func outputHistoryJSON(history []vault.Revision) error {
+	entries := make([]historyEntry, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		rev := history[i]
+		entry := historyEntry{
+			Revision: rev.Number,
+			Replaced: rev.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+			Changed:  rev.ChangedFields(),
+			Username: rev.Username,
+			Category: rev.Category,
+			URL:      rev.URL,
+			Notes:    rev.Notes,
+			Tags:     rev.Tags,
+			URLs:     rev.URLs,
+		}
+		if rev.Password != nil {
+			password := maskHistoryValue(string(rev.Password))
+			entry.Password = &password
+		}
+		if rev.CustomFields != nil {
+			for _, field := range *rev.CustomFields {
+				if field.Hidden {
+					field.Value = maskHistoryValue(field.Value)
+				}
+				entry.CustomFields = append(entry.CustomFields, field)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	historyRestoreRevision int
+	historyRestoreForce    bool
+)
+
+var historyRestoreCmd = &cobra.Command{
+	Use:   "restore <service>",
+	Short: "Restore a credential to a previous revision",
+	Long: `Restore rolls a credential back to the values captured in a revision.
+
+Only the fields recorded in that revision are changed. The values being replaced
+are themselves saved as a new revision, so a restore can be undone by restoring
+again. Use 'pass-cli history <service>' to find revision numbers.`,
+	Example: `  # Restore revision 3 (asks for confirmation)
+  pass-cli history restore github --revision 3
+
+  # Restore without confirmation
+  pass-cli history restore github --revision 3 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistoryRestore,
+}
+
+func init() {
+	historyCmd.AddCommand(historyRestoreCmd)
+	historyRestoreCmd.Flags().IntVar(&historyRestoreRevision, "revision", 0, "revision number to restore (required)")
+	historyRestoreCmd.Flags().BoolVar(&historyRestoreForce, "force", false, "skip confirmation prompt")
+	_ = historyRestoreCmd.MarkFlagRequired("revision")
+}
+
+func runHistoryRestore(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	history, err := vaultService.GetHistory(service)
+	if err != nil {
+		return fmt.Errorf("failed to get history: %w", err)
+	}
+
+	var target *vault.Revision
+	for i := range history {
+		if history[i].Number == historyRestoreRevision {
+			target = &history[i]
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("%w: %s has no revision %d", vault.ErrRevisionNotFound, service, historyRestoreRevision)
+	}
+
+	if !historyRestoreForce {
+		fmt.Printf("Restore %s to revision %d (%s)?\n", service, target.Number, formatRelativeTime(target.Timestamp))
+		fmt.Printf("Fields to restore: %s\n", strings.Join(target.ChangedFields(), ", "))
+		confirmed, err := promptYesNo("Continue?", false)
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Restore cancelled.")
+			return nil
+		}
+	}
+
+	if err := vaultService.RestoreRevision(service, target.Number); err != nil {
+		return fmt.Errorf("failed to restore revision: %w", err)
+	}
+
+	fmt.Printf("✅ Credential restored to revision %d\n", target.Number)
+	fmt.Printf("📝 Service: %s\n", service)
+	fmt.Printf("🕒 Restored fields: %s\n", strings.Join(target.ChangedFields(), ", "))
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
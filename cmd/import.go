@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/importer"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	importDryRun     bool
+	importOnConflict string
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:     "import",
+	GroupID: "vault",
+	Short:   "Import credentials from another password manager",
+	Long: `Import reads another password manager's export file and adds its entries to
+the vault in a single save: either everything importable is written, or nothing is.
+
+Entries whose name already exists in the vault are handled by --on-conflict:
+  skip       keep the existing credential (default)
+  overwrite  replace its fields; history, usage and attachments are kept and the
+             old values can be restored with 'pass-cli history restore'
+  rename     import under a new name such as "github (2)"
+
+Entries that repeat a name within the same export are always renamed. Logins
+already in the vault under any name (same username, password and website) are
+reported as duplicates and not imported twice.
+Use --dry-run to see what would happen without changing the vault.
+
+Export files contain your secrets in plaintext: delete them after importing.`,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.PersistentFlags().BoolVar(&importDryRun, "dry-run", false, "show what would be imported without saving")
+	importCmd.PersistentFlags().StringVar(&importOnConflict, "on-conflict", vault.ImportSkip,
+		"what to do when a credential already exists ("+strings.Join(vault.ImportStrategies(), ", ")+")")
+}
+
+// runImport imports parsed credentials into the vault and prints a summary.
+// source names the export format in messages (e.g. "Bitwarden").
+func runImport(parsed *importer.Result, source string) error {
+	switch importOnConflict {
+	case vault.ImportSkip, vault.ImportOverwrite, vault.ImportRename:
+	default:
+		return fmt.Errorf("invalid --on-conflict %q (valid: %s)", importOnConflict, strings.Join(vault.ImportStrategies(), ", "))
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	result, err := vaultService.ImportCredentials(parsed.Credentials, importOnConflict, importDryRun)
+	if err != nil {
+		return fmt.Errorf("failed to import credentials: %w", err)
+	}
+
+	for _, warning := range parsed.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+	if len(parsed.Warnings) > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+	printImportResult(result, source)
+
+	if !result.DryRun && result.Imported() > 0 {
+		syncPushAfterCommand(vaultService)
+	}
+	return nil
+}
+
+// printImportResult prints the import summary, listing renamed, skipped, duplicate and failed entries
+func printImportResult(result *vault.ImportResult, source string) {
+	if result.DryRun {
+		fmt.Printf("🔍 Dry run: %d credential(s) would be imported from %s (nothing was saved)\n", result.Imported(), source)
+	} else {
+		fmt.Printf("✅ Imported %d credential(s) from %s\n", result.Imported(), source)
+	}
+
+	fmt.Printf("   Added:       %d\n", len(result.Added))
+	if len(result.Overwritten) > 0 {
+		fmt.Printf("   Overwritten: %d\n", len(result.Overwritten))
+		for _, service := range result.Overwritten {
+			fmt.Printf("     %s\n", service)
+		}
+	}
+	if len(result.Renamed) > 0 {
+		fmt.Printf("   Renamed:     %d\n", len(result.Renamed))
+		for _, renamed := range result.Renamed {
+			fmt.Printf("     %s -> %s\n", renamed.From, renamed.To)
+		}
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("   Skipped:     %d (already in the vault)\n", len(result.Skipped))
+		for _, service := range result.Skipped {
+			fmt.Printf("     %s\n", service)
+		}
+	}
+	if len(result.Duplicates) > 0 {
+		fmt.Printf("   Duplicates:  %d (same login already in the vault)\n", len(result.Duplicates))
+		for _, duplicate := range result.Duplicates {
+			fmt.Printf("     %s = %s\n", duplicate.Service, duplicate.Existing)
+		}
+	}
+	if len(result.Failed) > 0 {
+		fmt.Printf("   Failed:      %d\n", len(result.Failed))
+		for _, failure := range result.Failed {
+			fmt.Printf("     %s: %v\n", failure.Service, failure.Err)
+		}
+	}
+}
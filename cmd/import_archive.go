@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/archive"
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/importer"
+)
+
+var importArchiveCmd = &cobra.Command{
+	Use:   "passcli-archive <file>",
+	Short: "Import an archive written by 'pass-cli export'",
+	Long: `Import the credentials of an encrypted archive created with
+'pass-cli export --format encrypted-json'. You are prompted for the export
+passphrase chosen when the archive was written.
+
+Credentials keep their type, folder, tags, fields, URLs, TOTP configuration,
+expiry settings and attachments.`,
+	Example: `  # Preview the import
+  pass-cli import passcli-archive team.passcli --dry-run
+
+  # Import, renaming credentials that already exist
+  pass-cli import passcli-archive team.passcli --on-conflict rename`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportArchive,
+}
+
+func init() {
+	importCmd.AddCommand(importArchiveCmd)
+}
+
+func runImportArchive(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0]) // #nosec G304 -- user-specified archive file
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	if !archive.IsArchive(data) {
+		return archive.ErrNotArchive
+	}
+
+	fmt.Fprint(os.Stderr, "Archive passphrase: ")
+	passphrase, err := readPassword()
+	if err != nil {
+		return fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	fmt.Fprintln(os.Stderr)
+	defer crypto.ClearBytes(passphrase)
+
+	credentials, err := archive.Open(data, passphrase)
+	if err != nil {
+		return err
+	}
+	return runImport(&importer.Result{Credentials: credentials}, "pass-cli archive")
+}
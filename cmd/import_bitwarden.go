@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/importer"
+)
+
+var importBitwardenCmd = &cobra.Command{
+	Use:   "bitwarden <file.json>",
+	Short: "Import an unencrypted Bitwarden JSON export",
+	Long: `Import credentials from a Bitwarden JSON export ('bw export --format json', or
+File > Export vault > .json in the apps). Encrypted exports are not supported.
+
+Logins keep their username, password, URIs and TOTP secret. URI match detection
+is carried over as URL rules (base domain, host, starts with, exact and regular
+expression; "never" is skipped). Secure notes, cards, identities and SSH keys
+become the matching record types. Folders (or collections, for organization
+exports) become categories, custom fields are kept (hidden fields stay hidden)
+and favorites are tagged "favorite".
+
+Logins without a password are imported as notes. Password history, linked
+fields and attachments are not part of the export and are not imported.`,
+	Example: `  # Preview the import
+  pass-cli import bitwarden bitwarden_export.json --dry-run
+
+  # Import, renaming entries whose name is already taken
+  pass-cli import bitwarden bitwarden_export.json --on-conflict rename`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportBitwarden,
+}
+
+func init() {
+	importCmd.AddCommand(importBitwardenCmd)
+}
+
+func runImportBitwarden(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0]) // #nosec G304 -- user-specified export file
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+
+	parsed, err := importer.ParseBitwarden(data)
+	if err != nil {
+		return err
+	}
+	return runImport(parsed, "Bitwarden")
+}
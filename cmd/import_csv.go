@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/importer"
+)
+
+var (
+	csvPreset  string
+	csvMapping string
+)
+
+var importCSVCmd = &cobra.Command{
+	Use:   "csv <file.csv>",
+	Short: "Import a CSV export from a browser or password manager",
+	Long: `Import credentials from a CSV file with a header row, such as the password
+exports of Chrome (and Edge, Brave), Firefox, Safari (Apple Passwords) and LastPass.
+
+The format is detected from the header row; use --preset to choose it yourself:
+  chrome    name, url, username, password, note
+  firefox   url, username, password (entries are named after the website)
+  lastpass  name, url, username, password, totp, extra, grouping, fav
+  safari    Title, URL, Username, Password, Notes, OTPAuth
+  generic   columns are recognized by common names (name/title, user/login/email,
+            website, folder, ...); other columns become custom fields
+
+--map assigns columns to fields by header name, overriding the preset:
+  --map service=Account,notes=Comments
+Fields: service, username, password, url, notes, totp, category, tags, favorite.
+
+Rows without a password are imported as notes. Logins that are already in the
+vault under any name are reported as duplicates and not imported again.`,
+	Example: `  # Preview a Chrome export
+  pass-cli import csv "Chrome Passwords.csv" --dry-run
+
+  # Import a spreadsheet with custom column names
+  pass-cli import csv accounts.csv --preset generic --map service=Account,url=Site`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportCSV,
+}
+
+func init() {
+	importCmd.AddCommand(importCSVCmd)
+	importCSVCmd.Flags().StringVar(&csvPreset, "preset", "", "CSV format ("+strings.Join(importer.CSVPresets(), ", ")+"); detected when omitted")
+	importCSVCmd.Flags().StringVar(&csvMapping, "map", "", "column mapping as field=column pairs, e.g. service=name,url=url")
+}
+
+func runImportCSV(cmd *cobra.Command, args []string) error {
+	mapping, err := importer.ParseCSVMapping(csvMapping)
+	if err != nil {
+		return fmt.Errorf("invalid --map: %w", err)
+	}
+
+	data, err := os.ReadFile(args[0]) // #nosec G304 -- user-specified export file
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+
+	preset := strings.ToLower(csvPreset)
+	if preset == "" {
+		if preset, err = importer.DetectCSVPreset(data); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "🔎 Detected CSV format: %s\n", preset)
+	}
+
+	parsed, err := importer.ParseCSV(data, preset, mapping)
+	if err != nil {
+		return err
+	}
+	return runImport(parsed, "CSV")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/importer"
+)
+
+var (
+	keepassKeyFile    string
+	keepassNoPassword bool
+)
+
+var importKeePassCmd = &cobra.Command{
+	Use:   "keepass <file.kdbx>",
+	Short: "Import a KeePass (KDBX 4) database",
+	Long: `Import credentials directly from a KeePass database in the KDBX 4 format
+(KeePass 2.35+ and KeePassXC 2.7+). You are prompted for the database password;
+databases protected by a key file need --key-file as well, and --no-password if
+they use the key file alone. Older KDBX 3.1 databases must be saved again as
+KDBX 4 first (KeePassXC: Database Security > Encryption Settings).
+
+Both Argon2 and AES-KDF key derivation are supported, with AES-256, ChaCha20 or
+Twofish encryption. Deriving the key uses the database's own cost settings, so
+this may take a few seconds.
+
+Groups become categories ("Work/Servers"), entries keep their username, password,
+URL, notes, tags, expiry date and attachments, and additional strings become
+custom fields (protected strings stay hidden). The otp attribute written by
+KeePassXC becomes the TOTP secret and KP2A_URL strings become additional URLs.
+Entries without a password are imported as notes. The recycle bin and entry
+history are not imported.`,
+	Example: `  # Preview the import
+  pass-cli import keepass Passwords.kdbx --dry-run
+
+  # Database protected by a password and a key file
+  pass-cli import keepass Passwords.kdbx --key-file Passwords.keyx
+
+  # Database protected by a key file only
+  pass-cli import keepass Passwords.kdbx --key-file Passwords.keyx --no-password`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportKeePass,
+}
+
+func init() {
+	importCmd.AddCommand(importKeePassCmd)
+	importKeePassCmd.Flags().StringVar(&keepassKeyFile, "key-file", "", "key file of the database")
+	importKeePassCmd.Flags().BoolVar(&keepassNoPassword, "no-password", false, "the database has no password (key file only)")
+}
+
+func runImportKeePass(cmd *cobra.Command, args []string) error {
+	if keepassNoPassword && keepassKeyFile == "" {
+		return fmt.Errorf("--no-password requires --key-file")
+	}
+
+	data, err := os.ReadFile(args[0]) // #nosec G304 -- user-specified database file
+	if err != nil {
+		return fmt.Errorf("failed to read database: %w", err)
+	}
+
+	var keyFile []byte
+	if keepassKeyFile != "" {
+		keyFile, err = os.ReadFile(keepassKeyFile) // #nosec G304 -- user-specified key file
+		if err != nil {
+			return fmt.Errorf("failed to read key file: %w", err)
+		}
+		defer crypto.ClearBytes(keyFile)
+	}
+
+	var password []byte
+	if !keepassNoPassword {
+		fmt.Fprint(os.Stderr, "KeePass database password: ")
+		password, err = readPassword()
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Fprintln(os.Stderr)
+		defer crypto.ClearBytes(password)
+	}
+
+	parsed, err := importer.ParseKeePass(data, password, keyFile)
+	if err != nil {
+		return err
+	}
+	return runImport(parsed, "KeePass")
+}
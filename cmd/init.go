@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/arimxyer/pass-cli/internal/config"
 	"github.com/arimxyer/pass-cli/internal/crypto"
@@ -395,25 +394,30 @@ func runConnectFlow(vaultPath string) error {
 
 	fmt.Println("✓ Vault downloaded")
 
-	// Verify password works
-	fmt.Print("\nEnter master password: ")
-	password, err := readPassword()
-	if err != nil {
-		return fmt.Errorf("failed to read password: %w", err)
-	}
-	fmt.Println()
-	defer crypto.ClearBytes(password)
-
 	vaultSvc, err := vault.New(vaultPath)
 	if err != nil {
 		return fmt.Errorf("failed to open vault: %w", err)
 	}
 
-	if err := vaultSvc.Unlock(password); err != nil {
-		return fmt.Errorf("invalid password or corrupted vault: %w", err)
-	}
+	// Team members shared the vault as recipients open it with their identity
+	if err := vaultSvc.UnlockWithIdentity(); err == nil {
+		fmt.Printf("✓ Vault unlocked with your identity (%s)\n", vaultSvc.UnlockedIdentity())
+	} else {
+		// Verify password works
+		fmt.Print("\nEnter master password: ")
+		password, err := readPassword()
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Println()
+		defer crypto.ClearBytes(password)
+
+		if err := vaultSvc.Unlock(password); err != nil {
+			return fmt.Errorf("invalid password or corrupted vault: %w", err)
+		}
 
-	fmt.Println("✓ Vault unlocked successfully")
+		fmt.Println("✓ Vault unlocked successfully")
+	}
 
 	// Save sync config
 	if err := saveSyncConfig(remote); err != nil {
@@ -437,44 +441,27 @@ func getVaultDir(vaultPath string) string {
 	return filepath.Dir(vaultPath)
 }
 
-// saveSyncConfig saves sync configuration to config file using proper YAML marshaling
+// saveSyncConfig saves sync configuration to config file using proper YAML marshaling.
+// With a vault profile selected, the profile's own sync section is updated.
 func saveSyncConfig(remote string) error {
-	configPath, err := config.GetConfigPath()
-	if err != nil {
-		return err
-	}
-
-	// Read existing config or create new
-	// #nosec G304 -- configPath is from config.GetConfigPath(), not user input
-	content, err := os.ReadFile(configPath)
-	if err != nil && !os.IsNotExist(err) {
-		return err
+	profileName := ""
+	if cfg, result := config.Load(); result.Valid {
+		profileName = cfg.ActiveVault
 	}
 
-	// Parse existing config as generic map to preserve all fields
-	var configMap map[string]interface{}
-	if len(content) > 0 {
-		if err := yaml.Unmarshal(content, &configMap); err != nil {
-			return fmt.Errorf("failed to parse config: %w", err)
+	return updateConfigFile(func(configMap map[string]interface{}) error {
+		section := configMap
+		if profileName != "" {
+			section = profileConfigSection(configMap, profileName)
 		}
-	}
-	if configMap == nil {
-		configMap = make(map[string]interface{})
-	}
 
-	// Update sync section (overwrites if exists, creates if not)
-	configMap["sync"] = map[string]interface{}{
-		"enabled": true,
-		"remote":  remote,
-	}
-
-	// Marshal back to YAML
-	newContent, err := yaml.Marshal(configMap)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
-	return os.WriteFile(configPath, newContent, 0600)
+		// Update sync section (overwrites if exists, creates if not)
+		section["sync"] = map[string]interface{}{
+			"enabled": true,
+			"remote":  remote,
+		}
+		return nil
+	})
 }
 
 // offerSyncSetup prompts user to set up cloud sync after vault creation
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	injectInput  string
+	injectOutput string
+	injectCheck  bool
+)
+
+var injectCmd = &cobra.Command{
+	Use:     "inject -i <template> [-o <file>]",
+	GroupID: "credentials",
+	Short:   "Render a template with values from the vault",
+	Long: `Inject renders a Go template (text/template) with vault values, such as an
+application.yml or .npmrc that must not be committed with its secrets.
+
+Template functions:
+  {{ secret "service" }}           the record type's main field (the password for logins)
+  {{ secret "service" "field" }}   username, password, url, notes, category or a custom field
+  {{ totp "service" }}             the current TOTP code
+
+All values are read with a single unlock. A missing credential or field stops
+the rendering with an error and nothing is written. The output is written to
+--output with 0600 permissions, or to stdout without it.
+
+--check unlocks the vault and validates every secret and totp call in the
+template, including those in branches that would not run, without rendering or
+writing anything. Calls whose arguments are not plain strings can only be
+checked by rendering and are reported as skipped.`,
+	Example: `  # Render a config file
+  pass-cli inject -i application.yml.tpl -o application.yml
+
+  # Print to stdout
+  pass-cli inject -i .npmrc.tpl
+
+  # Validate the references in CI without writing anything
+  pass-cli inject -i application.yml.tpl --check
+
+  # Template example (.npmrc.tpl)
+  //registry.npmjs.org/:_authToken={{ secret "npm" "password" }}`,
+	Args: cobra.NoArgs,
+	RunE: runInject,
+}
+
+func init() {
+	rootCmd.AddCommand(injectCmd)
+	injectCmd.Flags().StringVarP(&injectInput, "input", "i", "", "template file (required)")
+	injectCmd.Flags().StringVarP(&injectOutput, "output", "o", "", "output file, or - for stdout (default: stdout)")
+	injectCmd.Flags().BoolVar(&injectCheck, "check", false, "validate the template's references without writing anything")
+	_ = injectCmd.MarkFlagRequired("input")
+}
+
+func runInject(cmd *cobra.Command, args []string) error {
+	source, err := os.ReadFile(injectInput) // #nosec G304 -- user-specified template file
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	// Parse before unlocking so syntax errors don't cost a password prompt
+	resolver := newSecretResolver(nil)
+	tmpl, err := template.New(filepath.Base(injectInput)).
+		Option("missingkey=error").
+		Funcs(templateFuncs(resolver)).
+		Parse(string(source))
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+	resolver.vaultService = vaultService
+
+	if injectCheck {
+		return checkTemplate(tmpl, resolver)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, nil); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	output := rendered.Bytes()
+	defer crypto.ClearBytes(output)
+
+	resolver.recordAccess()
+
+	if injectOutput == "" || injectOutput == "-" {
+		_, err := os.Stdout.Write(output)
+		return err
+	}
+	if err := writePrivateFile(injectOutput, output, true); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Rendered %s to %s (%d value(s) from the vault)\n", injectInput, injectOutput, len(resolver.accessed))
+	return nil
+}
+
+// checkTemplate validates every secret and totp call with literal arguments
+func checkTemplate(tmpl *template.Template, resolver *secretResolver) error {
+	references, skipped, invalid := templateReferences(tmpl)
+
+	failed := len(invalid)
+	for _, call := range invalid {
+		fmt.Fprintf(os.Stderr, "❌ %s: wrong number of arguments\n", call)
+	}
+	for _, ref := range references {
+		var err error
+		if ref.totp {
+			_, err = resolver.totp(ref.service)
+		} else {
+			_, err = resolver.secret(ref.service, ref.field)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", ref, err)
+		}
+	}
+	for _, call := range skipped {
+		fmt.Fprintf(os.Stderr, "⚠️  not checked (arguments are not plain strings): %s\n", call)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reference(s) in %s cannot be resolved", failed, len(references)+len(invalid), injectInput)
+	}
+	fmt.Printf("✅ %d reference(s) in %s resolved\n", len(references), injectInput)
+	return nil
+}
+
+// templateFuncs returns the template functions backed by resolver
+func templateFuncs(r *secretResolver) template.FuncMap {
+	return template.FuncMap{
+		"secret": func(service string, field ...string) (string, error) {
+			switch len(field) {
+			case 0:
+				return r.secret(service, "")
+			case 1:
+				return r.secret(service, field[0])
+			}
+			return "", fmt.Errorf("secret takes a service and at most one field")
+		},
+		"totp": r.totp,
+	}
+}
+
+// templateReferences finds the secret and totp calls in a parsed template,
+// including its defined templates and branches that may not run. Calls with
+// literal string arguments are returned as references; calls with other
+// arguments (skipped) and with the wrong number of arguments (invalid) as text.
+func templateReferences(tmpl *template.Template) (references []secretReference, skipped, invalid []string) {
+	seen := make(map[secretReference]bool)
+
+	// visitCall records a secret or totp call. Later commands of a pipeline
+	// receive the previous result as their last argument.
+	visitCall := func(n *parse.CommandNode, piped bool) {
+		ident, ok := n.Args[0].(*parse.IdentifierNode)
+		if !ok || (ident.Ident != "secret" && ident.Ident != "totp") {
+			return
+		}
+		var literals []string
+		for _, arg := range n.Args[1:] {
+			if str, ok := arg.(*parse.StringNode); ok {
+				literals = append(literals, str.Text)
+			}
+		}
+		ref := secretReference{totp: ident.Ident == "totp"}
+		switch {
+		case piped || len(literals) != len(n.Args)-1:
+			skipped = append(skipped, n.String())
+			return
+		case len(literals) == 0, ref.totp && len(literals) > 1, len(literals) > 2:
+			invalid = append(invalid, n.String())
+			return
+		}
+		ref.service = literals[0]
+		if len(literals) == 2 {
+			ref.field = literals[1]
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			references = append(references, ref)
+		}
+	}
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for i, command := range n.Cmds {
+				for _, arg := range command.Args {
+					walk(arg)
+				}
+				visitCall(command, i > 0)
+			}
+		}
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			walk(t.Root)
+		}
+	}
+	return references, skipped, invalid
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateReferences(t *testing.T) {
+	resolver := newSecretResolver(nil)
+	source := `token={{ secret "npm" "password" }}
+{{ if .Missing }}{{ secret "db" }}{{ else }}{{ totp "github" }}{{ end }}
+{{ define "extra" }}{{ secret "db" "host" | printf "%s" }}{{ end }}
+{{ range $i, $s := .List }}{{ secret $s }}{{ end }}
+{{ "npm" | secret }}
+{{ secret "npm" "password" }}{{ totp "a" "b" }}{{ secret }}`
+
+	tmpl, err := template.New("test").Funcs(templateFuncs(resolver)).Parse(source)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	references, skipped, invalid := templateReferences(tmpl)
+
+	// Sort-independent comparison: defined templates are walked in map order
+	want := map[secretReference]bool{
+		{service: "npm", field: "password"}: true,
+		{service: "db"}:                     true,
+		{service: "github", totp: true}:     true,
+		{service: "db", field: "host"}:      true,
+	}
+	got := make(map[secretReference]bool)
+	for _, ref := range references {
+		got[ref] = true
+	}
+	if len(references) != len(want) || !reflect.DeepEqual(got, want) {
+		t.Errorf("references = %v, want %v", references, want)
+	}
+	if !reflect.DeepEqual(skipped, []string{"secret $s", "secret"}) {
+		t.Errorf("skipped = %q", skipped)
+	}
+	if !reflect.DeepEqual(invalid, []string{`totp "a" "b"`, "secret"}) {
+		t.Errorf("invalid = %q", invalid)
+	}
+}
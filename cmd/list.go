@@ -22,6 +22,9 @@ var (
 	listByProject bool   // T029: --by-project flag
 	listLocation  string // T042: --location flag (for User Story 3)
 	listRecursive bool   // T043: --recursive flag (for User Story 3)
+	listTags      []string
+	listType      string
+	listFolder    string
 )
 
 var listCmd = &cobra.Command{
@@ -43,7 +46,17 @@ The --by-project flag groups credentials by git repository context,
 showing which credentials are used in which projects.
 
 The --location flag filters credentials accessed from a specific directory.
-Use --recursive to include subdirectories.`,
+Use --recursive to include subdirectories.
+
+The --folder flag shows only credentials in a folder (category path such as
+Clients/Acme). Use --recursive to include its subfolders.
+
+The --tag flag shows only credentials carrying the given tag. When several
+tags are given, a credential must carry all of them.
+
+The --type flag shows only records of one type (login, note, api-token,
+ssh-key, card, identity, database). Credentials created before record types
+existed are logins.`,
 	Example: `  # List all credentials as table
   pass-cli list
 
@@ -69,7 +82,16 @@ Use --recursive to include subdirectories.`,
   pass-cli list --location /path/to/project
 
   # Filter by location (recursive) and group by project
-  pass-cli list --location /path/to/project --recursive --by-project`,
+  pass-cli list --location /path/to/project --recursive --by-project
+
+  # Show credentials tagged both work and prod
+  pass-cli list --tag work --tag prod
+
+  # Show only payment cards
+  pass-cli list --type card
+
+  # Show everything under the Clients folder
+  pass-cli list --folder Clients --recursive`,
 	RunE: runList,
 }
 
@@ -78,9 +100,12 @@ func init() {
 	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "output format: table, json, simple")
 	listCmd.Flags().BoolVar(&listUnused, "unused", false, "show only unused or rarely used credentials")
 	listCmd.Flags().IntVar(&listDays, "days", 30, "days threshold for --unused flag")
-	listCmd.Flags().BoolVar(&listByProject, "by-project", false, "group credentials by git repository")   // T029
-	listCmd.Flags().StringVar(&listLocation, "location", "", "filter credentials by directory path")      // T042
-	listCmd.Flags().BoolVar(&listRecursive, "recursive", false, "include subdirectories with --location") // T043
+	listCmd.Flags().BoolVar(&listByProject, "by-project", false, "group credentials by git repository")                             // T029
+	listCmd.Flags().StringVar(&listLocation, "location", "", "filter credentials by directory path")                                // T042
+	listCmd.Flags().BoolVar(&listRecursive, "recursive", false, "include subdirectories with --location, subfolders with --folder") // T043
+	listCmd.Flags().StringSliceVar(&listTags, "tag", nil, "show only credentials with this tag (repeatable, all must match)")
+	listCmd.Flags().StringVar(&listType, "type", "", "show only records of this type")
+	listCmd.Flags().StringVar(&listFolder, "folder", "", "show only credentials in this folder (e.g. Clients/Acme)")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -106,6 +131,17 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 	defer vaultService.Lock()
 
+	tags, err := normalizeTagFlags(listTags)
+	if err != nil {
+		return err
+	}
+	var recordType string
+	if listType != "" {
+		if recordType, err = vault.NormalizeRecordType(listType); err != nil {
+			return err
+		}
+	}
+
 	// Get credential metadata
 	metadata, err := vaultService.ListCredentialsWithMetadata()
 	if err != nil {
@@ -117,6 +153,25 @@ func runList(cmd *cobra.Command, args []string) error {
 		metadata = filterUnused(metadata, listDays)
 	}
 
+	// Filter by tags if requested
+	if len(tags) > 0 {
+		metadata = filterByTags(metadata, tags)
+	}
+
+	// Filter by record type if requested
+	if recordType != "" {
+		metadata = filterByType(metadata, recordType)
+	}
+
+	// Filter by folder if requested
+	if listFolder != "" {
+		folder := vault.NormalizeFolder(listFolder)
+		if folder == "" {
+			return fmt.Errorf("folder cannot be empty")
+		}
+		metadata = filterByFolder(metadata, folder, listRecursive)
+	}
+
 	// T044-T048: Filter by location if requested (User Story 3)
 	if listLocation != "" {
 		filtered, err := filterCredentialsByLocation(metadata, listLocation, listRecursive)
@@ -172,6 +227,52 @@ func filterUnused(metadata []vault.CredentialMetadata, days int) []vault.Credent
 	return filtered
 }
 
+// filterByTags keeps credentials that carry every one of the given tags
+func filterByTags(metadata []vault.CredentialMetadata, tags []string) []vault.CredentialMetadata {
+	filtered := make([]vault.CredentialMetadata, 0)
+
+	for _, meta := range metadata {
+		matched := true
+		for _, tag := range tags {
+			if !meta.HasTag(tag) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			filtered = append(filtered, meta)
+		}
+	}
+
+	return filtered
+}
+
+// filterByType keeps credentials of the given record type
+func filterByType(metadata []vault.CredentialMetadata, recordType string) []vault.CredentialMetadata {
+	filtered := make([]vault.CredentialMetadata, 0)
+
+	for _, meta := range metadata {
+		if meta.Type == recordType {
+			filtered = append(filtered, meta)
+		}
+	}
+
+	return filtered
+}
+
+// filterByFolder keeps credentials in the given folder, and its subfolders when recursive
+func filterByFolder(metadata []vault.CredentialMetadata, folder string, recursive bool) []vault.CredentialMetadata {
+	filtered := make([]vault.CredentialMetadata, 0)
+
+	for _, meta := range metadata {
+		if vault.InFolder(vault.NormalizeFolder(meta.Category), folder, recursive) {
+			filtered = append(filtered, meta)
+		}
+	}
+
+	return filtered
+}
+
 // T044: filterCredentialsByLocation filters credentials by access location
 // T045: Resolves relative paths to absolute
 // T046: Exact match by default
@@ -258,8 +359,25 @@ func outputTable(metadata []vault.CredentialMetadata) error {
 
 	table := tablewriter.NewWriter(os.Stdout)
 
+	// Only show the Type and Tags columns when some credential needs them
+	showType, showTags := false, false
+	for _, meta := range metadata {
+		if meta.Type != vault.RecordTypeLogin {
+			showType = true
+		}
+		if len(meta.Tags) > 0 {
+			showTags = true
+		}
+	}
+
 	// Prepare header
 	header := []string{"Service", "Username", "Usage", "Last Used", "Created"}
+	if showType {
+		header = append([]string{"Service", "Type"}, header[1:]...)
+	}
+	if showTags {
+		header = append(header, "Tags")
+	}
 
 	// Prepare data rows
 	var data [][]string
@@ -287,13 +405,15 @@ func outputTable(metadata []vault.CredentialMetadata) error {
 			username = username[:27] + "..."
 		}
 
-		data = append(data, []string{
-			meta.Service,
-			username,
-			usageStr,
-			lastUsedStr,
-			createdStr,
-		})
+		row := []string{meta.Service}
+		if showType {
+			row = append(row, meta.Type)
+		}
+		row = append(row, username, usageStr, lastUsedStr, createdStr)
+		if showTags {
+			row = append(row, strings.Join(meta.Tags, ", "))
+		}
+		data = append(data, row)
 	}
 
 	// Set table configuration
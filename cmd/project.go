@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+	"github.com/arimxyer/pass-cli/internal/project"
+)
+
+// projectCmd represents the project command
+var projectCmd = &cobra.Command{
+	Use:     "project",
+	GroupID: "utilities",
+	Short:   "Work with a repository's .pass-cli.yml manifest",
+	Long: `Project works with .pass-cli.yml, a manifest committed to a repository that
+maps environment variable names to credentials:
+
+  vault: work                          # optional, a name from the vault registry
+  env:
+    DB_PASSWORD: postgres-prod:password
+    STRIPE_KEY: stripe                 # the record type's main field
+
+The manifest holds references only, never values. It is looked up in the
+current directory and its parents up to the repository root. 'pass-cli run'
+uses it when no --env is given and 'pass-cli env' prints it as export lines.`,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+}
+
+// loadManifest reads the manifest at path, or the one found from the working
+// directory when path is empty, and selects its vault unless --vault was given
+func loadManifest(path string) (*project.Manifest, error) {
+	var manifest *project.Manifest
+	var err error
+	if path != "" {
+		manifest, err = project.Load(path)
+	} else {
+		var wd string
+		if wd, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		manifest, err = project.Find(wd)
+	}
+	if errors.Is(err, project.ErrNoManifest) {
+		return nil, fmt.Errorf("%w in this directory or its parents (up to the repository root)", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Vault != "" && config.SelectedVault() == "" {
+		config.SelectVault(manifest.Vault)
+	}
+	return manifest, nil
+}
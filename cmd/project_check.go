@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var projectCheckManifest string
+
+var projectCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report manifest entries that cannot be resolved",
+	Long: `Check unlocks the vault and resolves every entry of the project's
+.pass-cli.yml without printing any values. Each entry is reported as found or
+missing, and the command fails if any entry cannot be resolved, so it can guard
+a setup script or CI job. Checking does not count as using the credentials.`,
+	Example: `  # Check the manifest of the current repository
+  pass-cli project check
+
+  # Check a manifest elsewhere
+  pass-cli project check --manifest ../api/.pass-cli.yml`,
+	Args: cobra.NoArgs,
+	RunE: runProjectCheck,
+}
+
+func init() {
+	projectCmd.AddCommand(projectCheckCmd)
+	projectCheckCmd.Flags().StringVar(&projectCheckManifest, "manifest", "", "manifest file (default: .pass-cli.yml in this directory or a parent)")
+}
+
+func runProjectCheck(cmd *cobra.Command, args []string) error {
+	manifest, err := loadManifest(projectCheckManifest)
+	if err != nil {
+		return err
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	fmt.Printf("📄 %s\n\n", manifest.Path)
+	resolver := newSecretResolver(vaultService)
+	missing := 0
+	for _, v := range manifest.Env {
+		if _, err := resolver.secret(v.Service, v.Field); err != nil {
+			missing++
+			fmt.Printf("❌ %s → %s: %v\n", v.Name, v.Reference(), err)
+			continue
+		}
+		fmt.Printf("✅ %s → %s\n", v.Name, v.Reference())
+	}
+	fmt.Println()
+
+	if missing > 0 {
+		return fmt.Errorf("%d of %d manifest entries cannot be resolved", missing, len(manifest.Env))
+	}
+	fmt.Printf("✅ All %d manifest entries resolved\n", len(manifest.Env))
+	return nil
+}
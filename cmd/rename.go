@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var renameCmd = &cobra.Command{
+	Use:     "rename <old-service> <new-service>",
+	GroupID: "credentials",
+	Aliases: []string{"mv"},
+	Short:   "Rename a credential",
+	Long: `Rename changes a credential's service name.
+
+Everything else stays with the credential: usage statistics, creation date,
+modification count, TOTP, history and attachments. Unlike deleting and re-adding,
+nothing is lost.
+
+To move a credential to another folder, use 'pass-cli update --category'.`,
+	Example: `  # Rename a credential
+  pass-cli rename github github-personal
+
+  # Using the alias
+  pass-cli mv aws-prod aws-production`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRename,
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	oldService := strings.TrimSpace(args[0])
+	newService := strings.TrimSpace(args[1])
+	if newService == "" {
+		return fmt.Errorf("new service name cannot be empty")
+	}
+	if newService == oldService {
+		return fmt.Errorf("new service name is the same as the current one")
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	referrers := vaultService.Referrers(oldService)
+
+	if err := vaultService.RenameCredential(oldService, newService); err != nil {
+		return fmt.Errorf("failed to rename credential: %w", err)
+	}
+
+	fmt.Printf("✅ Credential renamed\n")
+	fmt.Printf("📝 %s → %s\n", oldService, newService)
+	printReferrersWarning(referrers)
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
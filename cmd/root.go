@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,8 +15,9 @@ import (
 )
 
 var (
-	cfgFile string
-	verbose bool
+	cfgFile      string
+	verbose      bool
+	vaultProfile string // --vault: vault registry profile to use
 
 	// Version information (set via ldflags during build)
 	version = "dev"
@@ -56,9 +58,23 @@ For more information, visit: https://github.com/arimxyer/pass-cli`,
 	}
 )
 
+// exitStatusError makes pass-cli exit with status once the command has
+// reported the failure itself
+type exitStatusError struct {
+	status int
+}
+
+func (e exitStatusError) Error() string {
+	return fmt.Sprintf("exit status %d", e.status)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr exitStatusError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.status)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -68,29 +84,10 @@ func init() {
 	// NOTE: Config loading moved to PersistentPreRunE to ensure --config flag is parsed first
 	// See issue #65: https://github.com/arimxyer/pass-cli/issues/65
 
-	// T037: Custom flag error handler for migration guidance
-	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
-		// Check if error is about --vault flag
-		if strings.Contains(err.Error(), "vault") && strings.Contains(err.Error(), "flag") {
-			return fmt.Errorf(`the --vault flag has been removed
-
-Instead, configure your vault location in the config file:
-  1. Edit %s/.pass-cli/config.yml
-  2. Add: vault_path: /your/custom/path/vault.enc
-  3. Run your command without the --vault flag
-
-For more details, see the migration guide:
-  https://github.com/arimxyer/pass-cli/blob/main/docs/MIGRATION.md
-
-Original error: %w`, os.Getenv("HOME"), err)
-		}
-		// Return original error for other flag issues
-		return err
-	})
-
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.pass-cli/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&vaultProfile, "vault", "", "vault to use, by name from the vault registry (see 'pass-cli vault list')")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
@@ -110,7 +107,9 @@ func GetVaultPath() string {
 	// Check if viper has vault_path set (from --config flag or default config)
 	// This is necessary because config.Load() uses os.UserConfigDir() and doesn't respect --config flag
 	var vaultPath string
-	if viper.IsSet("vault_path") {
+	if _, profile, ok := activeVaultProfile(); ok {
+		vaultPath = profile.Path
+	} else if viper.IsSet("vault_path") {
 		vaultPath = viper.GetString("vault_path")
 	} else {
 		// Load config and check validation only if viper doesn't have it
@@ -141,6 +140,12 @@ func GetVaultPath() string {
 		return filepath.Join(home, ".pass-cli", "vault.enc")
 	}
 
+	return expandVaultPath(vaultPath)
+}
+
+// expandVaultPath expands environment variables and ~ in a configured vault path,
+// resolving relative paths against the home directory
+func expandVaultPath(vaultPath string) string {
 	// Expand environment variables
 	vaultPath = os.ExpandEnv(vaultPath)
 
@@ -164,7 +169,7 @@ func GetVaultPath() string {
 	return vaultPath
 }
 
-// GetVaultPathWithSource returns the vault path and its source ("config" or "default")
+// GetVaultPathWithSource returns the vault path and its source ("vault <name>", "config" or "default")
 // Exits with error if config validation fails (FR-012)
 func GetVaultPathWithSource() (path string, source string) {
 	// Check if viper has vault_path set (from --config flag or default config)
@@ -172,7 +177,10 @@ func GetVaultPathWithSource() (path string, source string) {
 	var vaultPath string
 	var pathSource string
 
-	if viper.IsSet("vault_path") {
+	if name, profile, ok := activeVaultProfile(); ok {
+		vaultPath = profile.Path
+		pathSource = "vault " + name
+	} else if viper.IsSet("vault_path") {
 		vaultPath = viper.GetString("vault_path")
 		if vaultPath != "" {
 			pathSource = "config"
@@ -231,6 +239,30 @@ func GetVaultPathWithSource() (path string, source string) {
 	return vaultPath, pathSource
 }
 
+// activeVaultProfile returns the vault registry profile selected with --vault or
+// default_vault, if any. Exits with an error when --vault cannot be resolved.
+func activeVaultProfile() (string, config.VaultProfile, bool) {
+	cfg, result := config.Load()
+	if !result.Valid {
+		if config.SelectedVault() == "" {
+			return "", config.VaultProfile{}, false
+		}
+		if len(result.Errors) == 1 && result.Errors[0].Field == "--vault" {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", result.Errors[0].Message)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Configuration validation failed:\n")
+		for _, err := range result.Errors {
+			fmt.Fprintf(os.Stderr, "  - %s: %s\n", err.Field, err.Message)
+		}
+		fmt.Fprintf(os.Stderr, "\nPlease fix your configuration file and try again.\n")
+		os.Exit(1)
+	}
+
+	profile, ok := cfg.ActiveProfile()
+	return cfg.ActiveVault, profile, ok
+}
+
 // IsVerbose returns whether verbose mode is enabled
 func IsVerbose() bool {
 	return verbose || viper.GetBool("verbose")
@@ -298,6 +330,7 @@ func checkFirstRun(cmd *cobra.Command, args []string) error {
 	// so that --config flag is available. This fixes issue #65 where
 	// custom config files were not being loaded properly.
 	initConfig()
+	config.SelectVault(vaultProfile)
 
 	// Skip first-run check in test mode
 	if os.Getenv("PASS_CLI_TEST") == "1" {
@@ -306,7 +339,9 @@ func checkFirstRun(cmd *cobra.Command, args []string) error {
 
 	// Get custom vault path from config (now properly loaded)
 	var customVaultPath string
-	if viper.IsSet("vault_path") {
+	if _, profile, ok := activeVaultProfile(); ok {
+		customVaultPath = profile.Path
+	} else if viper.IsSet("vault_path") {
 		customVaultPath = viper.GetString("vault_path")
 	}
 
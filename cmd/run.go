@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/mask"
+	"github.com/arimxyer/pass-cli/internal/project"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	runEnv      []string
+	runMask     bool
+	runManifest string
+)
+
+// forwardedSignals are passed on to the child instead of stopping pass-cli. An
+// interrupt is only caught: the terminal already sends Ctrl+C to the child too.
+var forwardedSignals = []os.Signal{syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+var runCmd = &cobra.Command{
+	Use:     "run [--env NAME=service[:field]]... -- <command> [args...]",
+	GroupID: "credentials",
+	Short:   "Run a command with secrets in its environment",
+	Long: `Run unlocks the vault once, reads every --env mapping and starts the command
+with the values added to its environment. The vault is locked again before the
+command starts.
+
+Each mapping is NAME=service:field, where field is username, password, url,
+notes, category or a custom field name. Without :field the record type's main
+field is used (the password for logins). When a service name contains ':',
+give the field explicitly: NAME=host:5432:password.
+
+Without --env, the mappings come from the project manifest (.pass-cli.yml) in
+the current directory or a parent; see 'pass-cli project'.
+
+The command's exit code becomes pass-cli's exit code, and terminate, hang-up
+and quit signals are passed on to it. Ctrl+C reaches the command from the
+terminal; pass-cli waits for it to exit. With --mask, the values are
+replaced by ******** in the command's output (values shorter than 4 characters
+are not masked); output that may be the start of a value is held back until it
+is complete.`,
+	Example: `  # Two secrets for one command
+  pass-cli run --env DB_PASS=postgres-prod:password --env API_KEY=stripe:password -- ./deploy.sh
+
+  # Username and password of the same credential
+  pass-cli run -e PGUSER=postgres-prod:username -e PGPASSWORD=postgres-prod -- psql -h db.internal
+
+  # Use the mappings in the repository's .pass-cli.yml
+  pass-cli run -- npm start
+
+  # Hide the secrets in the command's output (e.g. in CI logs)
+  pass-cli run --mask --env TOKEN=github:token -- make release`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRun,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().StringArrayVarP(&runEnv, "env", "e", nil, "environment variable as NAME=service[:field] (repeatable)")
+	runCmd.Flags().BoolVar(&runMask, "mask", false, "replace the secret values in the command's output with ********")
+	runCmd.Flags().StringVar(&runManifest, "manifest", "", "manifest file to use without --env (default: .pass-cli.yml in this directory or a parent)")
+	// Flags after the command name belong to the command
+	runCmd.Flags().SetInterspersed(false)
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	var mappings []project.EnvVar
+	if len(runEnv) == 0 {
+		manifest, err := loadManifest(runManifest)
+		if err != nil {
+			return fmt.Errorf("%w\nUse --env NAME=service[:field] or add a %s", err, project.ManifestName)
+		}
+		mappings = manifest.Env
+	}
+	for _, spec := range runEnv {
+		mapping, err := parseEnvMapping(spec)
+		if err != nil {
+			return err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	resolver := newSecretResolver(vaultService)
+	env, secrets, err := resolveEnv(resolver, mappings)
+	if err == nil {
+		resolver.recordAccess()
+	}
+	// The command may run for a long time: don't keep the vault unlocked meanwhile
+	vaultService.Lock()
+	if err != nil {
+		return err
+	}
+
+	child := exec.Command(args[0], args[1:]...) // #nosec G204 -- running the user's command is the purpose of run
+	child.Env = append(os.Environ(), env...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	var masked []*mask.Writer
+	if runMask {
+		stdout, stderr := mask.NewWriter(os.Stdout, secrets), mask.NewWriter(os.Stderr, secrets)
+		child.Stdout, child.Stderr = stdout, stderr
+		masked = append(masked, stdout, stderr)
+	}
+
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, append(forwardedSignals, os.Interrupt)...)
+	go func() {
+		for sig := range signals {
+			if sig != os.Interrupt {
+				_ = child.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err = child.Wait()
+	signal.Stop(signals)
+	close(signals)
+	for _, w := range masked {
+		_ = w.Flush()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitCode(exitErr))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// parseEnvMapping parses an --env NAME=service[:field] flag
+func parseEnvMapping(spec string) (project.EnvVar, error) {
+	name, reference, found := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return project.EnvVar{}, fmt.Errorf("invalid --env %q: expected NAME=service[:field]", spec)
+	}
+	if strings.ContainsAny(name, " \t\x00") {
+		return project.EnvVar{}, fmt.Errorf("invalid --env %q: variable name cannot contain whitespace", spec)
+	}
+	service, field, err := project.ParseReference(reference)
+	if err != nil {
+		return project.EnvVar{}, fmt.Errorf("invalid --env %q: expected NAME=service[:field]", spec)
+	}
+	return project.EnvVar{Name: name, Service: service, Field: field}, nil
+}
+
+// resolveEnv reads the mapped values, returning NAME=value environment entries
+// and the values themselves (for masking)
+func resolveEnv(resolver *secretResolver, mappings []project.EnvVar) ([]string, []string, error) {
+	env := make([]string, 0, len(mappings))
+	secrets := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		value, err := resolver.secret(mapping.Service, mapping.Field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", mapping.Name, err)
+		}
+		env = append(env, mapping.Name+"="+value)
+		secrets = append(secrets, value)
+	}
+	return env, secrets, nil
+}
+
+// exitCode returns the exit code to pass on for a command that failed, using
+// the shell convention 128+n for a command killed by signal n
+func exitCode(exitErr *exec.ExitError) int {
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return exitErr.ExitCode()
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// secretReference is one value read from the vault by run, env or inject
+type secretReference struct {
+	service string
+	field   string // Empty for the record type's main field
+	totp    bool
+}
+
+// String renders the reference as it would appear in a template
+func (r secretReference) String() string {
+	switch {
+	case r.totp:
+		return fmt.Sprintf("totp %q", r.service)
+	case r.field == "":
+		return fmt.Sprintf("secret %q", r.service)
+	}
+	return fmt.Sprintf("secret %q %q", r.service, r.field)
+}
+
+// secretResolver reads credential fields for run, env and inject. Credentials
+// are read once per service; the fields read are tracked as accesses only when
+// recordAccess is called, once the command has succeeded.
+type secretResolver struct {
+	vaultService *vault.VaultService
+	credentials  map[string]*vault.Credential
+	accessed     map[secretReference]bool
+}
+
+// newSecretResolver returns a resolver for an unlocked vault
+func newSecretResolver(vaultService *vault.VaultService) *secretResolver {
+	return &secretResolver{
+		vaultService: vaultService,
+		credentials:  make(map[string]*vault.Credential),
+		accessed:     make(map[secretReference]bool),
+	}
+}
+
+// credential returns a service's credential, reading it on first use
+func (r *secretResolver) credential(service string) (*vault.Credential, error) {
+	if cred, ok := r.credentials[service]; ok {
+		return cred, nil
+	}
+	cred, err := r.vaultService.GetCredential(service, false)
+	if err != nil {
+		return nil, err
+	}
+	r.credentials[service] = cred
+	return cred, nil
+}
+
+// secret returns a field of a credential; an empty field is the record type's main field
+func (r *secretResolver) secret(service, field string) (string, error) {
+	cred, err := r.credential(service)
+	if err != nil {
+		return "", err
+	}
+	if field == "" {
+		schema, _ := vault.GetRecordSchema(cred.RecordType())
+		field = schema.DefaultField
+	}
+	value, fieldName, err := credentialField(cred, field)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", service, err)
+	}
+	r.accessed[secretReference{service: service, field: fieldName}] = true
+	return value, nil
+}
+
+// totp returns the credential's current TOTP code
+func (r *secretResolver) totp(service string) (string, error) {
+	cred, err := r.credential(service)
+	if err != nil {
+		return "", err
+	}
+	if !cred.HasTOTP() {
+		return "", fmt.Errorf("no TOTP configured for credential: %s", service)
+	}
+	code, _, err := cred.GetTOTPCode()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", service, err)
+	}
+	r.accessed[secretReference{service: service, totp: true}] = true
+	return code, nil
+}
+
+// recordAccess tracks every field read so far as an access
+func (r *secretResolver) recordAccess() {
+	for ref := range r.accessed {
+		field := ref.field
+		if ref.totp {
+			field = "totp"
+		}
+		if err := r.vaultService.RecordFieldAccess(ref.service, field); err != nil {
+			// Log warning but don't fail the operation
+			fmt.Fprintf(os.Stderr, "Warning: failed to track field access: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/arimxyer/pass-cli/internal/security"
+	"github.com/arimxyer/pass-cli/internal/sshagent"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	sshAgentSocket   string
+	sshAgentKeys     []string
+	sshAgentConfirm  bool
+	sshAgentLifetime string
+)
+
+var sshAgentCmd = &cobra.Command{
+	Use:     "ssh-agent",
+	GroupID: "security",
+	Short:   "Serve the vault's SSH keys to ssh over the ssh-agent protocol",
+	Long: `SSH-agent loads the vault's ssh-key records and serves them over the ssh-agent
+protocol on a Unix socket, so ssh, git and scp use them without key files in
+~/.ssh. The vault is locked again once the keys are loaded; from then on they
+are only held in the agent's memory.
+
+Keys stay in the agent until it stops, unless a lifetime applies: the record's
+agent-lifetime field or --lifetime for records without one. Keys whose record
+sets agent-confirm to yes, or all keys with --confirm, are only used after the
+user allows each signature, through the program in SSH_ASKPASS if it is set or
+on the agent's terminal otherwise. Requests are refused when neither is available.
+
+Every signature request is written to the audit log (ssh_sign), with the
+outcome failure if it was refused or failed. 'ssh-add -l' lists the keys and
+'ssh-add -d' or 'ssh-add -D' unloads them from the agent, not from the vault;
+keys cannot be added with ssh-add.
+
+The socket defaults to $XDG_RUNTIME_DIR/pass-cli/agent.sock, or
+~/.pass-cli/agent.sock without XDG_RUNTIME_DIR. The agent runs until it is
+interrupted.`,
+	Example: `  # Start the agent with every SSH key in the vault
+  pass-cli ssh-agent
+
+  # In another shell, point ssh at it
+  export SSH_AUTH_SOCK="$XDG_RUNTIME_DIR/pass-cli/agent.sock"
+  ssh-add -l
+
+  # Serve two keys, confirming each use, for the next 8 hours
+  pass-cli ssh-agent --key github-ssh --key prod-deploy --confirm --lifetime 8h`,
+	Args: cobra.NoArgs,
+	RunE: runSSHAgent,
+}
+
+func init() {
+	rootCmd.AddCommand(sshAgentCmd)
+	sshAgentCmd.Flags().StringVar(&sshAgentSocket, "socket", "", "socket path (default: $XDG_RUNTIME_DIR/pass-cli/agent.sock)")
+	sshAgentCmd.Flags().StringSliceVar(&sshAgentKeys, "key", nil, "ssh-key record to serve (repeatable; default: all)")
+	sshAgentCmd.Flags().BoolVar(&sshAgentConfirm, "confirm", false, "ask before every signature, for all keys")
+	sshAgentCmd.Flags().StringVar(&sshAgentLifetime, "lifetime", "", "how long keys without an agent-lifetime stay loaded (e.g. 30m, 8h)")
+}
+
+func runSSHAgent(cmd *cobra.Command, args []string) error {
+	var defaultLifetime time.Duration
+	if sshAgentLifetime != "" {
+		var err error
+		if defaultLifetime, err = parseAgentLifetime(sshAgentLifetime); err != nil {
+			return err
+		}
+	}
+
+	socketPath := sshAgentSocket
+	if socketPath == "" {
+		var err error
+		if socketPath, err = defaultAgentSocket(); err != nil {
+			return err
+		}
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+
+	sshAgent := sshagent.New(confirmAgentRequest, func(req sshagent.Request, err error) {
+		if err != nil {
+			vaultService.LogAudit(security.EventSSHSign, security.OutcomeFailure, req.Service)
+			fmt.Fprintf(os.Stderr, "🚫 %s: %v\n", req.Service, err)
+			return
+		}
+		vaultService.LogAudit(security.EventSSHSign, security.OutcomeSuccess, req.Service)
+		fmt.Fprintf(os.Stderr, "✍️  %s: signed\n", req.Service)
+	})
+
+	// The keys only live in the agent from here on
+	keys, err := loadAgentKeys(vaultService, sshAgent, defaultLifetime)
+	vaultService.Lock()
+	if err != nil {
+		return err
+	}
+
+	listener, err := listenAgentSocket(socketPath)
+	if err != nil {
+		_ = sshAgent.RemoveAll()
+		return err
+	}
+
+	fmt.Printf("✅ SSH agent running with %d key(s)\n", len(keys))
+	for _, key := range keys {
+		fmt.Printf("🔑 %s\n", key)
+	}
+	fmt.Printf("\n%s\n", envFormatters["sh"]("SSH_AUTH_SOCK", socketPath))
+	fmt.Println("Press Ctrl+C to stop the agent")
+
+	// agent.ServeAgent logs failed requests, which the audit callback already reports
+	log.SetOutput(io.Discard)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		_ = listener.Close()
+	}()
+
+	serveErr := sshAgent.Serve(listener)
+	_ = sshAgent.RemoveAll()
+	_ = os.Remove(socketPath)
+	if serveErr != nil {
+		return fmt.Errorf("agent stopped: %w", serveErr)
+	}
+
+	fmt.Println("\n🔒 SSH agent stopped")
+	return nil
+}
+
+// defaultAgentSocket returns the socket path used without --socket
+func defaultAgentSocket() (string, error) {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "pass-cli", "agent.sock"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the home directory, use --socket: %w", err)
+	}
+	return filepath.Join(home, ".pass-cli", "agent.sock"), nil
+}
+
+// loadAgentKeys adds the ssh-key records named by --key, or all of them, to the
+// agent. It returns a description of each loaded key.
+func loadAgentKeys(vaultService *vault.VaultService, sshAgent *sshagent.Agent, defaultLifetime time.Duration) ([]string, error) {
+	services := sshAgentKeys
+	if len(services) == 0 {
+		credentials, err := vaultService.ListCredentialsWithMetadata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list credentials: %w", err)
+		}
+		for _, cred := range credentials {
+			if cred.Type == vault.RecordTypeSSHKey {
+				services = append(services, cred.Service)
+			}
+		}
+		if len(services) == 0 {
+			return nil, fmt.Errorf("no ssh-key records in the vault\nImport a key with 'pass-cli ssh-key import <service> <file>'")
+		}
+		sort.Strings(services)
+	}
+
+	var loaded []string
+	for _, service := range services {
+		key, err := agentKey(vaultService, service, defaultLifetime)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := sshAgent.AddKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		description := fmt.Sprintf("%s (%s %s", service, publicKey.Type(), ssh.FingerprintSHA256(publicKey))
+		if key.Confirm {
+			description += ", confirm"
+		}
+		if key.Lifetime > 0 {
+			description += ", lifetime " + key.Lifetime.String()
+		}
+		loaded = append(loaded, description+")")
+	}
+	return loaded, nil
+}
+
+// agentKey reads an ssh-key record and decrypts its private key with the
+// record's passphrase
+func agentKey(vaultService *vault.VaultService, service string, defaultLifetime time.Duration) (sshagent.Key, error) {
+	cred, err := vaultService.GetCredential(service, false)
+	if err != nil {
+		return sshagent.Key{}, fmt.Errorf("%s: %w", service, err)
+	}
+	if cred.RecordType() != vault.RecordTypeSSHKey {
+		return sshagent.Key{}, fmt.Errorf("%s is a %s record, not an ssh-key", service, cred.RecordType())
+	}
+
+	field, _ := cred.GetCustomField("private-key")
+	privateKey, _, err := sshagent.ParsePrivateKey([]byte(field.Value), cred.Password)
+	if err != nil {
+		return sshagent.Key{}, fmt.Errorf("%s: %w", service, err)
+	}
+
+	key := sshagent.Key{
+		Service:    service,
+		PrivateKey: privateKey,
+		Confirm:    sshAgentConfirm,
+		Lifetime:   defaultLifetime,
+	}
+	if field, ok := cred.GetCustomField("comment"); ok {
+		key.Comment = field.Value
+	}
+	if field, ok := cred.GetCustomField("agent-confirm"); ok {
+		switch strings.ToLower(strings.TrimSpace(field.Value)) {
+		case "yes", "true", "1":
+			key.Confirm = true
+		case "", "no", "false", "0":
+		default:
+			return sshagent.Key{}, fmt.Errorf("%s: invalid agent-confirm %q: use yes or no", service, field.Value)
+		}
+	}
+	if field, ok := cred.GetCustomField("agent-lifetime"); ok && strings.TrimSpace(field.Value) != "" {
+		if key.Lifetime, err = parseAgentLifetime(field.Value); err != nil {
+			return sshagent.Key{}, fmt.Errorf("%s: %w", service, err)
+		}
+	}
+	return key, nil
+}
+
+// listenAgentSocket listens on a Unix socket only the user can use, replacing a
+// stale socket left by an agent that did not stop cleanly
+func listenAgentSocket(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if _, err := os.Stat(socketPath); err == nil {
+		if conn, err := net.Dial("unix", socketPath); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("an agent is already listening on %s", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// confirmAgentRequest asks the user to allow a signature, with SSH_ASKPASS if
+// set or on the terminal. Without either, the request is refused.
+func confirmAgentRequest(req sshagent.Request) bool {
+	prompt := fmt.Sprintf("Allow use of SSH key %s (%s)?", req.Service, req.Fingerprint)
+
+	if askpass := os.Getenv("SSH_ASKPASS"); askpass != "" {
+		// As with ssh-agent -c: the program exits 0 if the user allowed the request
+		askpassCmd := exec.Command(askpass, prompt) // #nosec G204 -- SSH_ASKPASS is chosen by the user
+		askpassCmd.Env = append(os.Environ(), "SSH_ASKPASS_PROMPT=confirm")
+		return askpassCmd.Run() == nil
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: no SSH_ASKPASS or terminal to confirm with\n", req.Service)
+		return false
+	}
+	defer func() { _ = tty.Close() }()
+
+	fmt.Fprintf(tty, "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
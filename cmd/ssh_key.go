@@ -0,0 +1,21 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// sshKeyCmd represents the ssh-key command
+var sshKeyCmd = &cobra.Command{
+	Use:     "ssh-key",
+	GroupID: "credentials",
+	Short:   "Store SSH keys in the vault",
+	Long: `SSH-key stores SSH private keys as ssh-key records, so they can be served by
+'pass-cli ssh-agent' instead of living in ~/.ssh.
+
+An ssh-key record holds the private key (hidden), its passphrase as the
+password, the public key, comment and fingerprint, and optionally the agent
+settings agent-confirm and agent-lifetime. Records can also be created with
+'pass-cli add --type ssh-key' and changed with 'pass-cli update --field'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(sshKeyCmd)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/sshagent"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var (
+	sshKeyImportCategory string
+	sshKeyImportComment  string
+	sshKeyImportConfirm  bool
+	sshKeyImportLifetime string
+)
+
+var sshKeyImportCmd = &cobra.Command{
+	Use:   "import <service> <private-key-file>",
+	Short: "Import an SSH private key file",
+	Long: `Import reads an SSH private key (OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM) and stores
+it as an ssh-key record, with its public key and SHA256 fingerprint.
+
+The key is stored as it is in the file. For an encrypted key, the passphrase is
+asked for, checked and kept as the record's password so 'pass-cli ssh-agent'
+can decrypt the key. The comment is taken from --comment or from the matching
+.pub file next to the key.
+
+Once imported, the key file can be deleted from ~/.ssh.`,
+	Example: `  # Import a key
+  pass-cli ssh-key import github-ssh ~/.ssh/id_ed25519
+
+  # Ask before every use and unload from the agent after an hour
+  pass-cli ssh-key import prod-deploy ./deploy_key --confirm --lifetime 1h`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSSHKeyImport,
+}
+
+func init() {
+	sshKeyCmd.AddCommand(sshKeyImportCmd)
+	sshKeyImportCmd.Flags().StringVarP(&sshKeyImportCategory, "category", "c", "", "category or folder path for the key")
+	sshKeyImportCmd.Flags().StringVar(&sshKeyImportComment, "comment", "", "key comment (default: from the .pub file)")
+	sshKeyImportCmd.Flags().BoolVar(&sshKeyImportConfirm, "confirm", false, "make ssh-agent ask before every use of the key")
+	sshKeyImportCmd.Flags().StringVar(&sshKeyImportLifetime, "lifetime", "", "how long ssh-agent keeps the key loaded (e.g. 30m, 8h)")
+}
+
+func runSSHKeyImport(cmd *cobra.Command, args []string) error {
+	service := strings.TrimSpace(args[0])
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	path := args[1]
+
+	if sshKeyImportLifetime != "" {
+		if _, err := parseAgentLifetime(sshKeyImportLifetime); err != nil {
+			return err
+		}
+	}
+
+	pemBytes, err := os.ReadFile(path) // #nosec G304 -- key file given by the user
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer crypto.ClearBytes(pemBytes)
+
+	var passphrase []byte
+	defer func() { crypto.ClearBytes(passphrase) }()
+	_, publicKey, err := sshagent.ParsePrivateKey(pemBytes, nil)
+	if errors.Is(err, sshagent.ErrPassphraseRequired) {
+		fmt.Fprint(os.Stderr, "Key passphrase: ")
+		passphrase, err = readPassword()
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		_, publicKey, err = sshagent.ParsePrivateKey(pemBytes, passphrase)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	comment := strings.TrimSpace(sshKeyImportComment)
+	if !cmd.Flags().Changed("comment") {
+		comment = publicKeyFileComment(path+".pub", publicKey)
+	}
+
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	if comment != "" {
+		authorizedKey += " " + comment
+	}
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+
+	fields := []vault.CustomField{
+		{Name: "private-key", Value: string(pemBytes)},
+		{Name: "public-key", Value: authorizedKey},
+		{Name: "fingerprint", Value: fingerprint},
+	}
+	if comment != "" {
+		fields = append(fields, vault.CustomField{Name: "comment", Value: comment})
+	}
+	if sshKeyImportConfirm {
+		fields = append(fields, vault.CustomField{Name: "agent-confirm", Value: "yes"})
+	}
+	if sshKeyImportLifetime != "" {
+		fields = append(fields, vault.CustomField{Name: "agent-lifetime", Value: sshKeyImportLifetime})
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	if err := vaultService.AddRecord(vault.Credential{
+		Service:      service,
+		Type:         vault.RecordTypeSSHKey,
+		Password:     passphrase,
+		Category:     sshKeyImportCategory,
+		CustomFields: fields,
+	}); err != nil {
+		return fmt.Errorf("failed to add SSH key: %w", err)
+	}
+
+	fmt.Printf("✅ SSH key imported\n")
+	fmt.Printf("📝 Service: %s\n", service)
+	fmt.Printf("🔑 Key: %s %s\n", publicKey.Type(), fingerprint)
+	if comment != "" {
+		fmt.Printf("💬 Comment: %s\n", comment)
+	}
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
+
+// publicKeyFileComment returns the comment of the public key file at path if
+// it holds publicKey, or "" if there is no such file
+func publicKeyFileComment(path string, publicKey ssh.PublicKey) string {
+	data, err := os.ReadFile(path) // #nosec G304 -- public key next to the key file given by the user
+	if err != nil {
+		return ""
+	}
+	filePublicKey, comment, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil || string(filePublicKey.Marshal()) != string(publicKey.Marshal()) {
+		return ""
+	}
+	return comment
+}
+
+// parseAgentLifetime parses an agent-lifetime value such as 30m or 8h
+func parseAgentLifetime(value string) (time.Duration, error) {
+	lifetime, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || lifetime <= 0 {
+		return 0, fmt.Errorf("invalid lifetime %q: expected a positive duration such as 30m or 8h", value)
+	}
+	if lifetime < time.Second {
+		return 0, fmt.Errorf("invalid lifetime %q: the agent counts lifetimes in seconds", value)
+	}
+	return lifetime, nil
+}
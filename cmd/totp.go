@@ -0,0 +1,19 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// totpCmd represents the totp command
+var totpCmd = &cobra.Command{
+	Use:     "totp",
+	GroupID: "credentials",
+	Short:   "Manage TOTP/2FA secrets",
+	Long: `TOTP manages the two-factor secrets stored with credentials.
+
+Use 'pass-cli add --totp' or 'pass-cli update --totp-uri' to set the secret of a
+single credential, 'pass-cli get --totp' to get a code, and 'pass-cli totp
+import-migration' to move accounts over from an authenticator app.`,
+}
+
+func init() {
+	rootCmd.AddCommand(totpCmd)
+}
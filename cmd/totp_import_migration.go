@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/importer"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var totpImportDryRun bool
+
+var totpImportMigrationCmd = &cobra.Command{
+	Use:   "import-migration <uri|file>...",
+	Short: "Import TOTP secrets from Google Authenticator, Aegis or 2FAS",
+	Long: `Import-migration adds the accounts of an authenticator app to the vault.
+
+It accepts:
+  - Google Authenticator transfer URIs (otpauth-migration://offline?data=...),
+    as read from the QR codes of Transfer accounts > Export accounts. Large
+    exports are split over several QR codes: pass every URI, or a file holding
+    one URI per line.
+  - Aegis JSON exports (unencrypted)
+  - 2FAS backups (.2fas, unencrypted)
+
+Each account is matched to an existing credential by issuer: a credential named
+after the issuer (or with the same TOTP issuer) receives the secret, algorithm,
+digits and period. When several credentials match, the one whose username is the
+account name is used. Accounts without an unambiguous match are added as notes
+named after the issuer, such as "GitHub" or "GitHub (alice)".
+
+Counter-based (HOTP), Steam and MD5 codes are not supported and are skipped.`,
+	Example: `  # Import a Google Authenticator QR code (quote the URI)
+  pass-cli totp import-migration 'otpauth-migration://offline?data=CjEKCkhlbGxv...'
+
+  # Preview an Aegis export
+  pass-cli totp import-migration aegis-export.json --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTOTPImportMigration,
+}
+
+func init() {
+	totpCmd.AddCommand(totpImportMigrationCmd)
+	totpImportMigrationCmd.Flags().BoolVar(&totpImportDryRun, "dry-run", false, "show what would be imported without saving")
+}
+
+func runTOTPImportMigration(cmd *cobra.Command, args []string) error {
+	parsed := &importer.Result{}
+	var uris []string
+	for _, arg := range args {
+		if importer.IsAuthenticatorMigrationURI(arg) {
+			uris = append(uris, arg)
+			continue
+		}
+		data, err := os.ReadFile(arg) // #nosec G304 -- user-specified export file
+		if err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+		fromFile, err := importer.ParseAuthenticatorExport(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+		parsed.Credentials = append(parsed.Credentials, fromFile.Credentials...)
+		parsed.Warnings = append(parsed.Warnings, fromFile.Warnings...)
+	}
+	if len(uris) > 0 {
+		fromURIs, err := importer.ParseAuthenticatorMigration(uris)
+		if err != nil {
+			return err
+		}
+		parsed.Credentials = append(parsed.Credentials, fromURIs.Credentials...)
+		parsed.Warnings = append(parsed.Warnings, fromURIs.Warnings...)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	result, err := vaultService.ImportTOTP(parsed.Credentials, totpImportDryRun)
+	if err != nil {
+		return fmt.Errorf("failed to import TOTP secrets: %w", err)
+	}
+
+	for _, warning := range parsed.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+	if len(parsed.Warnings) > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+	printTOTPImportResult(result)
+
+	if !result.DryRun && len(result.Updated)+len(result.Created) > 0 {
+		syncPushAfterCommand(vaultService)
+	}
+	return nil
+}
+
+// printTOTPImportResult prints which credentials received a TOTP secret
+func printTOTPImportResult(result *vault.TOTPImportResult) {
+	imported := len(result.Updated) + len(result.Created)
+	if result.DryRun {
+		fmt.Printf("🔍 Dry run: %d TOTP secret(s) would be imported (nothing was saved)\n", imported)
+	} else {
+		fmt.Printf("✅ Imported %d TOTP secret(s)\n", imported)
+	}
+
+	lists := []struct {
+		label    string
+		services []string
+	}{
+		{"Updated:  ", result.Updated},
+		{"Created:  ", result.Created},
+		{"Unchanged:", result.Unchanged},
+	}
+	for _, list := range lists {
+		if len(list.services) == 0 {
+			continue
+		}
+		fmt.Printf("   %s %d\n", list.label, len(list.services))
+		for _, service := range list.services {
+			fmt.Printf("     %s\n", service)
+		}
+	}
+	if len(result.Failed) > 0 {
+		fmt.Printf("   Failed:    %d\n", len(result.Failed))
+		for _, failure := range result.Failed {
+			fmt.Printf("     %s: %v\n", failure.Service, failure.Err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// trashCmd represents the trash command
+var trashCmd = &cobra.Command{
+	Use:     "trash",
+	GroupID: "credentials",
+	Short:   "List, restore or purge deleted credentials",
+	Long: `Trash manages deleted credentials.
+
+'pass-cli delete' moves credentials to the trash, which is stored encrypted
+inside the vault. They can be restored until trash.retention_days (default: 30)
+have passed, after which they are removed for good. Set trash.retention_days
+to 0 in the config file to delete credentials immediately instead.`,
+}
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var trashListFormat string
+
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List deleted credentials",
+	Example: `  # List deleted credentials, most recent first
+  pass-cli trash list
+
+  # JSON output for scripting
+  pass-cli trash list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runTrashList,
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd)
+	trashListCmd.Flags().StringVar(&trashListFormat, "format", "table", "output format: table, json")
+}
+
+// trashEntry is the JSON representation of a deleted credential
+type trashEntry struct {
+	ID        string `json:"id"`
+	Service   string `json:"service"`
+	Username  string `json:"username,omitempty"`
+	Category  string `json:"category,omitempty"`
+	DeletedAt string `json:"deleted_at"` // ISO 8601
+	PurgeAt   string `json:"purge_at"`   // ISO 8601
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	if trashListFormat != "table" && trashListFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", trashListFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	items, err := vaultService.ListTrash()
+	if err != nil {
+		return fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	if trashListFormat == "json" {
+		entries := make([]trashEntry, 0, len(items))
+		for _, item := range items {
+			entries = append(entries, trashEntry{
+				ID:        item.ID,
+				Service:   item.Service,
+				Username:  item.Username,
+				Category:  item.Category,
+				DeletedAt: item.DeletedAt.Format("2006-01-02T15:04:05Z07:00"),
+				PurgeAt:   item.PurgeAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(items) == 0 {
+		if vaultService.TrashRetentionDays() == 0 {
+			fmt.Println("Trash is empty (disabled: trash.retention_days is 0)")
+		} else {
+			fmt.Println("Trash is empty")
+		}
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"ID", "Service", "Username", "Deleted", "Purged"})
+
+	var data [][]string
+	for _, item := range items {
+		data = append(data, []string{
+			item.ID,
+			item.Service,
+			item.Username,
+			formatRelativeTime(item.DeletedAt),
+			formatExpiry(item.PurgeAt),
+		})
+	}
+
+	_ = table.Bulk(data)
+	_ = table.Render()
+
+	fmt.Printf("\nRestore with: pass-cli trash restore <service|id>\n")
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var trashPurgeForce bool
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [service|id]",
+	Short: "Permanently remove deleted credentials",
+	Long: `Purge permanently removes deleted credentials from the trash, together with
+their attachments. Without an argument the whole trash is emptied.
+
+The previous vault backup still contains purged credentials until the next
+save replaces the backup.`,
+	Example: `  # Empty the trash (asks for confirmation)
+  pass-cli trash purge
+
+  # Purge every deletion of one service
+  pass-cli trash purge old-service
+
+  # Without confirmation
+  pass-cli trash purge --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTrashPurge,
+}
+
+func init() {
+	trashCmd.AddCommand(trashPurgeCmd)
+	trashPurgeCmd.Flags().BoolVarP(&trashPurgeForce, "force", "f", false, "skip confirmation prompt")
+}
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) == 1 {
+		if target = strings.TrimSpace(args[0]); target == "" {
+			return fmt.Errorf("service name cannot be empty")
+		}
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	if !trashPurgeForce {
+		prompt := "Permanently delete everything in the trash?"
+		if target != "" {
+			prompt = fmt.Sprintf("Permanently delete %s from the trash?", target)
+		}
+		confirmed, err := promptYesNo(prompt, false)
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Purge cancelled.")
+			return nil
+		}
+	}
+
+	purged, err := vaultService.PurgeTrash(target)
+	if err != nil {
+		return fmt.Errorf("failed to purge trash: %w", err)
+	}
+
+	if purged == 0 {
+		fmt.Println("Trash is already empty")
+		return nil
+	}
+	fmt.Printf("✅ Permanently deleted %d credential(s)\n", purged)
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
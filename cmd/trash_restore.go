@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <service|id>",
+	Short: "Restore a deleted credential",
+	Long: `Restore moves a deleted credential back into the vault.
+
+If the same service name was deleted more than once, the most recent deletion
+is restored; pass the ID from 'pass-cli trash list' to pick an older one.
+Restoring fails if a credential with the same name has been added since.`,
+	Example: `  # Restore by service name
+  pass-cli trash restore github
+
+  # Restore a specific deletion by ID
+  pass-cli trash restore 3f9a1c2e`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrashRestore,
+}
+
+func init() {
+	trashCmd.AddCommand(trashRestoreCmd)
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	target := strings.TrimSpace(args[0])
+	if target == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	service, err := vaultService.RestoreFromTrash(target)
+	if err != nil {
+		return fmt.Errorf("failed to restore credential: %w", err)
+	}
+
+	fmt.Printf("✅ Credential restored from trash\n")
+	fmt.Printf("📝 Service: %s\n", service)
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
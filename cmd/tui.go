@@ -68,10 +68,13 @@ func runTUI(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(os.Stderr, "Warning: sync pull failed: %v\n", syncErr)
 	}
 
-	// Try keychain unlock first
+	// Try keychain unlock first, then the identity file
 	err = vaultService.UnlockWithKeychain()
 	if err != nil {
-		// Keychain failed, prompt for password
+		err = unlockWithIdentity(vaultService)
+	}
+	if err != nil {
+		// Keychain and identity failed, prompt for password
 		password, err := promptForMasterPassword()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: Failed to read password: %v\n", err)
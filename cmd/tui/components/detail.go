@@ -37,6 +37,7 @@ type DetailView struct {
 	appState                *models.AppState
 	passwordVisible         bool   // Toggle for password visibility (false = masked)
 	totpVisible             bool   // Toggle for TOTP code visibility (false = hidden)
+	historyVisible          bool   // Toggle for password history display (false = hidden)
 	cachedCredentialService string // Cache last refreshed credential service to avoid unnecessary vault calls
 }
 
@@ -100,6 +101,11 @@ func (dv *DetailView) formatCredential(cred *vault.CredentialMetadata) string {
 	b.WriteString(separator())
 	b.WriteString(textColor() + "\n")
 
+	// Record type (only shown for non-login records)
+	if cred.Type != "" && cred.Type != vault.RecordTypeLogin {
+		b.WriteString(fmt.Sprintf("%sType:%s       %s\n", colorWithBg("lightSlateGray"), textColor(), cred.Type))
+	}
+
 	// Main credential fields
 	b.WriteString(fmt.Sprintf("%sUsername:%s   %s\n", colorWithBg("lightSlateGray"), textColor(), cred.Username))
 
@@ -108,19 +114,34 @@ func (dv *DetailView) formatCredential(cred *vault.CredentialMetadata) string {
 		b.WriteString(fmt.Sprintf("%sCategory:%s   %s\n", colorWithBg("lightSlateGray"), textColor(), cred.Category))
 	}
 
+	// Tags (if present)
+	if len(cred.Tags) > 0 {
+		b.WriteString(fmt.Sprintf("%sTags:%s       %s\n", colorWithBg("lightSlateGray"), textColor(), tview.Escape("#"+strings.Join(cred.Tags, " #"))))
+	}
+
 	// URL (if present)
 	if cred.URL != "" {
 		b.WriteString(fmt.Sprintf("%sURL:%s        %s\n", colorWithBg("lightSlateGray"), textColor(), cred.URL))
 	}
+	for _, rule := range cred.URLs {
+		b.WriteString(fmt.Sprintf("%sMatch URL:%s  %s\n", colorWithBg("lightSlateGray"), textColor(), tview.Escape(rule.String())))
+	}
 
-	// Password field with masking
-	dv.formatPasswordField(&b, cred)
+	// Password field with masking (skipped for types without a password, e.g. notes and cards)
+	if schema, _ := vault.GetRecordSchema(cred.Type); schema.PasswordLabel != "" {
+		dv.formatPasswordField(&b, cred)
+	}
 
 	// TOTP field (if configured)
 	if cred.HasTOTP {
 		dv.formatTOTPField(&b, cred)
 	}
 
+	// Custom fields (if any)
+	if len(cred.CustomFieldNames) > 0 {
+		dv.formatCustomFields(&b, cred)
+	}
+
 	// Notes (if present)
 	if cred.Notes != "" {
 		b.WriteString(fmt.Sprintf("\n%sNotes:%s\n", colorWithBg("lightSlateGray"), textColor()))
@@ -129,6 +150,11 @@ func (dv *DetailView) formatCredential(cred *vault.CredentialMetadata) string {
 		b.WriteString(fmt.Sprintf("  %s\n", indentedNotes))
 	}
 
+	// Revision history (if any)
+	if cred.RevisionCount > 0 {
+		dv.formatHistory(&b, cred)
+	}
+
 	// Metadata section
 	b.WriteString(textColor() + "\n")
 	b.WriteString(separator())
@@ -139,6 +165,18 @@ func (dv *DetailView) formatCredential(cred *vault.CredentialMetadata) string {
 	b.WriteString(fmt.Sprintf("%sCreated:%s     %s\n", colorWithBg("lightSlateGray"), textColor(), cred.CreatedAt.Format("2006-01-02 03:04 PM")))
 	b.WriteString(fmt.Sprintf("%sModified:%s    %s\n", colorWithBg("lightSlateGray"), textColor(), cred.UpdatedAt.Format("2006-01-02 03:04 PM")))
 
+	// Expiry (fixed date or next rotation), highlighted once passed
+	if !cred.ExpiresAt.IsZero() {
+		expiry := cred.ExpiresAt.Format("2006-01-02")
+		if cred.IsExpired(time.Now()) {
+			expiry = colorWithBg("red") + expiry + " (expired)" + textColor()
+		}
+		b.WriteString(fmt.Sprintf("%sExpires:%s     %s\n", colorWithBg("lightSlateGray"), textColor(), expiry))
+	}
+	if cred.RotationDays > 0 {
+		b.WriteString(fmt.Sprintf("%sRotation:%s    every %d days\n", colorWithBg("lightSlateGray"), textColor(), cred.RotationDays))
+	}
+
 	// Display modification count
 	if cred.ModifiedCount > 0 {
 		timesText := "time"
@@ -223,6 +261,66 @@ func (dv *DetailView) formatPasswordField(b *strings.Builder, cred *vault.Creden
 	fmt.Fprintf(b, "%sPassword:%s   %s%s\n", colorWithBg("lightSlateGray"), textColor(), password, hint)
 }
 
+// formatCustomFields adds the custom fields section.
+// Hidden fields share the password visibility toggle so 'p' reveals all secrets at once.
+func (dv *DetailView) formatCustomFields(b *strings.Builder, cred *vault.CredentialMetadata) {
+	fmt.Fprintf(b, "\n%sFields:%s     %s('f' to copy)%s\n", colorWithBg("lightSlateGray"), textColor(),
+		colorWithBg("lightSlateGray"), textColor())
+
+	fullCred, err := dv.appState.GetFullCredential(cred.Service)
+	if err != nil || fullCred == nil {
+		fmt.Fprintf(b, "  %sError loading fields%s\n", colorWithBg("red"), textColor())
+		return
+	}
+
+	for _, field := range fullCred.CustomFields {
+		value := field.Value
+		if field.Hidden && !dv.passwordVisible {
+			value = "********"
+		}
+		fmt.Fprintf(b, "  %s%s:%s %s\n", colorWithBg("lightSlateGray"), tview.Escape(field.Name), textColor(), tview.Escape(value))
+	}
+}
+
+// formatHistory adds the revision history section.
+// Previous passwords are only fetched from the vault while history is toggled on.
+func (dv *DetailView) formatHistory(b *strings.Builder, cred *vault.CredentialMetadata) {
+	if !dv.historyVisible {
+		fmt.Fprintf(b, "\n%sHistory:%s    %d revisions  %s('h' to show previous passwords)%s\n",
+			colorWithBg("lightSlateGray"), textColor(), cred.RevisionCount,
+			colorWithBg("lightSlateGray"), textColor())
+		return
+	}
+
+	fmt.Fprintf(b, "\n%sHistory:%s    %d revisions  %s('h' to hide)%s\n",
+		colorWithBg("lightSlateGray"), textColor(), cred.RevisionCount,
+		colorWithBg("lightSlateGray"), textColor())
+
+	history, err := dv.appState.GetHistory(cred.Service)
+	if err != nil {
+		fmt.Fprintf(b, "  %sError loading history%s\n", colorWithBg("red"), textColor())
+		return
+	}
+
+	// Newest first, password changes only
+	shown := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		rev := history[i]
+		if rev.Password == nil {
+			continue
+		}
+		fmt.Fprintf(b, "  %s#%d %s:%s %s\n", colorWithBg("lightSlateGray"), rev.Number,
+			formatRelativeTime(rev.Timestamp), textColor(), tview.Escape(string(rev.Password)))
+		for j := range rev.Password {
+			rev.Password[j] = 0
+		}
+		shown++
+	}
+	if shown == 0 {
+		fmt.Fprintf(b, "  %sNo previous passwords%s\n", colorWithBg("lightSlateGray"), textColor())
+	}
+}
+
 // showEmptyState displays a message when no credential is selected.
 func (dv *DetailView) showEmptyState() {
 	content := fmt.Sprintf(`%s
@@ -255,6 +353,14 @@ func (dv *DetailView) ToggleTOTPVisibility() {
 	dv.Refresh()
 }
 
+// ToggleHistoryVisibility toggles the password history display state and refreshes.
+// Invalidates cache to force refresh with new history visibility state.
+func (dv *DetailView) ToggleHistoryVisibility() {
+	dv.historyVisible = !dv.historyVisible
+	dv.cachedCredentialService = "" // Invalidate cache to force refresh
+	dv.Refresh()
+}
+
 // CopyPasswordToClipboard copies the selected credential's password to clipboard.
 // Returns error if no credential selected or clipboard operation fails.
 // T020g: Added explicit memory zeroing after clipboard write
@@ -358,6 +464,39 @@ func (dv *DetailView) CopyFieldToClipboard(field string) error {
 	return nil
 }
 
+// CopyCustomFieldToClipboard copies the named custom field's value to clipboard.
+// Returns error if no credential selected, field not found, or clipboard operation fails.
+func (dv *DetailView) CopyCustomFieldToClipboard(name string) error {
+	cred := dv.appState.GetSelectedCredential()
+	if cred == nil {
+		return fmt.Errorf("no credential selected")
+	}
+
+	fullCred, err := dv.appState.GetFullCredential(cred.Service)
+	if err != nil {
+		return fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	field, found := fullCred.GetCustomField(name)
+	if !found {
+		return fmt.Errorf("%w: %s", vault.ErrCustomFieldNotFound, name)
+	}
+	if field.Value == "" {
+		return fmt.Errorf("%s is empty", field.Name)
+	}
+
+	if err := clipboard.WriteAll(field.Value); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+
+	// Track field access under the custom field's name
+	if err := dv.appState.RecordFieldAccess(cred.Service, field.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to track %s access: %v\n", field.Name, err)
+	}
+
+	return nil
+}
+
 // CopyTOTPToClipboard generates and copies the TOTP code to clipboard.
 // Returns the remaining seconds until the code expires, or error if no TOTP configured.
 func (dv *DetailView) CopyTOTPToClipboard() (int, error) {
@@ -56,6 +56,10 @@ func (t *testVaultService) AddCredential(service, username string, password []by
 	return nil
 }
 
+func (t *testVaultService) AddRecord(record vault.Credential) error {
+	return nil
+}
+
 func (t *testVaultService) UpdateCredential(service string, opts vault.UpdateOpts) error {
 	return nil
 }
@@ -68,6 +72,14 @@ func (t *testVaultService) GetCredential(service string, trackUsage bool) (*vaul
 	return nil, nil
 }
 
+func (t *testVaultService) GetCredentialRaw(service string) (*vault.Credential, error) {
+	return t.GetCredential(service, false)
+}
+
+func (t *testVaultService) Referrers(service string) []string {
+	return nil
+}
+
 func (t *testVaultService) RecordFieldAccess(service, field string) error {
 	return nil
 }
@@ -76,6 +88,22 @@ func (t *testVaultService) GetTOTPCode(service string) (string, int, error) {
 	return "123456", 25, nil
 }
 
+func (t *testVaultService) GetHistory(service string) ([]vault.Revision, error) {
+	return nil, nil
+}
+
+func (t *testVaultService) RenameCredential(oldService, newService string) error {
+	return nil
+}
+
+func (t *testVaultService) RestoreFromTrash(serviceOrID string) (string, error) {
+	return "", nil
+}
+
+func (t *testVaultService) TrashRetentionDays() int {
+	return 0
+}
+
 // Test helper functions
 
 // CreateTestCredential creates a test credential with usage records
@@ -10,6 +10,7 @@ import (
 
 	"github.com/arimxyer/pass-cli/cmd/tui/models"
 	"github.com/arimxyer/pass-cli/cmd/tui/styles"
+	"github.com/arimxyer/pass-cli/internal/crypto"
 	"github.com/arimxyer/pass-cli/internal/security"
 	"github.com/arimxyer/pass-cli/internal/vault"
 	"github.com/atotto/clipboard"
@@ -26,6 +27,76 @@ func normalizeCategory(c string) string {
 	return c
 }
 
+// customFieldsPlaceholder describes the custom field editor line format.
+const customFieldsPlaceholder = "name=value per line, !name=value for hidden"
+
+// parseCustomFieldLines parses custom field editor text: one name=value per line,
+// a leading '!' marks the field as hidden. Blank lines are ignored.
+func parseCustomFieldLines(text string) ([]vault.CustomField, error) {
+	var fields []vault.CustomField
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		hidden := strings.HasPrefix(line, "!")
+		field, err := vault.ParseCustomField(strings.TrimPrefix(line, "!"), hidden)
+		if err != nil {
+			return nil, err
+		}
+		if containsCustomField(fields, field.Name) {
+			return nil, fmt.Errorf("duplicate custom field: %s", field.Name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// formatCustomFieldLines is the inverse of parseCustomFieldLines.
+func formatCustomFieldLines(fields []vault.CustomField) string {
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		prefix := ""
+		if field.Hidden {
+			prefix = "!"
+		}
+		lines = append(lines, prefix+field.Name+"="+field.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordFieldsTemplate returns custom field editor lines for a record type's schema fields,
+// with empty values for the user to fill in.
+func recordFieldsTemplate(schema vault.RecordSchema) string {
+	fields := make([]vault.CustomField, 0, len(schema.Fields))
+	for _, schemaField := range schema.Fields {
+		fields = append(fields, vault.CustomField{Name: schemaField.Name, Hidden: schemaField.Hidden})
+	}
+	return formatCustomFieldLines(fields)
+}
+
+// dropEmptySchemaFields removes template fields the user left empty.
+// Required fields are kept so the vault can report them as missing.
+func dropEmptySchemaFields(schema vault.RecordSchema, fields []vault.CustomField) []vault.CustomField {
+	kept := fields[:0]
+	for _, field := range fields {
+		if schemaField, ok := schema.Field(field.Name); ok && !schemaField.Required && strings.TrimSpace(field.Value) == "" {
+			continue
+		}
+		kept = append(kept, field)
+	}
+	return kept
+}
+
+// containsCustomField reports whether fields has an entry with the given name (case-insensitive).
+func containsCustomField(fields []vault.CustomField, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddForm provides a modal form for adding new credentials.
 // Embeds tview.Flex (which contains Form + hints footer) and manages validation and submission.
 type AddForm struct {
@@ -36,6 +107,9 @@ type AddForm struct {
 
 	passwordVisible bool // Track password visibility state for toggle
 
+	recordType     string // Selected record type (login by default)
+	fieldsTemplate string // Custom field lines prefilled for the selected type
+
 	onSubmit        func()
 	onCancel        func()
 	onCancelConfirm func(message string, onYes func(), onNo func()) // Callback to show confirmation dialog
@@ -55,19 +129,23 @@ type EditForm struct {
 	passwordVisible  bool   // Track password visibility state for toggle
 	clearTOTP        bool   // Track if user wants to clear TOTP
 
+	originalCustomFields []vault.CustomField // Custom fields as loaded, used to compute removals
+	customFieldsFetched  bool                // Track if custom fields have been fetched (lazy loading)
+
 	onSubmit        func()
 	onCancel        func()
 	onCancelConfirm func(message string, onYes func(), onNo func()) // Callback to show confirmation dialog
 }
 
 // NewAddForm creates a new form for adding credentials.
-// Creates input fields for Service, Username, Password, Category, URL, Notes.
+// Creates input fields for Service, Username, Password, Category, URL, Notes, TOTP, Custom Fields and Type.
 func NewAddForm(appState *models.AppState) *AddForm {
 	form := tview.NewForm()
 
 	af := &AddForm{
-		form:     form,
-		appState: appState,
+		form:       form,
+		appState:   appState,
+		recordType: vault.RecordTypeLogin,
 	}
 
 	af.buildFormFields()
@@ -145,14 +223,47 @@ func (af *AddForm) buildFormFields() {
 	// TOTP field (optional) - accepts base32 secret or otpauth:// URI
 	af.form.AddInputField("TOTP Secret/URI", "", 0, nil, nil)
 
+	// Custom fields (optional) - one name=value per line, '!' prefix marks a hidden field
+	customFieldsArea := tview.NewTextArea().
+		SetLabel("Custom Fields").
+		SetSize(3, 0).
+		SetPlaceholder(customFieldsPlaceholder)
+	af.form.AddFormItem(customFieldsArea)
+
+	// Record type - selecting a type prefills Custom Fields with its fields
+	typeDropDown := tview.NewDropDown().
+		SetLabel("Type").
+		SetOptions(vault.RecordTypes(), func(text string, index int) {
+			af.onTypeSelected(text)
+		}).
+		SetCurrentOption(0)
+	af.form.AddFormItem(typeDropDown)
+
 	// Action buttons
 	af.form.AddButton("Generate Password", af.onGeneratePassword)
 	af.form.AddButton("Add", af.onAddPressed)
 	af.form.AddButton("Cancel", af.onCancelPressed)
 }
 
+// onTypeSelected switches the record type. The Custom Fields editor is replaced with the
+// new type's template unless the user has already typed into it.
+func (af *AddForm) onTypeSelected(recordType string) {
+	schema, ok := vault.GetRecordSchema(recordType)
+	if !ok {
+		return
+	}
+	af.recordType = schema.Type
+
+	area := af.form.GetFormItem(7).(*tview.TextArea)
+	template := recordFieldsTemplate(schema)
+	if text := area.GetText(); strings.TrimSpace(text) == "" || text == af.fieldsTemplate {
+		area.SetText(template, false)
+	}
+	af.fieldsTemplate = template
+}
+
 // onAddPressed handles the Add button submission.
-// Validates inputs, calls AppState.AddCredential(), invokes onSubmit callback.
+// Validates inputs, calls AppState.AddRecord(), invokes onSubmit callback.
 func (af *AddForm) onAddPressed() {
 	// Validate inputs before submission
 	if err := af.validate(); err != nil {
@@ -173,38 +284,43 @@ func (af *AddForm) onAddPressed() {
 	url := af.form.GetFormItem(4).(*tview.InputField).GetText()
 	notes := af.form.GetFormItem(5).(*tview.TextArea).GetText()
 	totpInput := af.form.GetFormItem(6).(*tview.InputField).GetText()
-
-	// Call AppState to add credential with all 6 fields
-	err := af.appState.AddCredential(service, username, password, category, url, notes)
-	if err != nil {
-		// Error already handled by AppState onError callback
-		// Form stays open for correction
-		return
+	customFields, _ := parseCustomFieldLines(af.form.GetFormItem(7).(*tview.TextArea).GetText()) // Already validated
+	schema, _ := vault.GetRecordSchema(af.recordType)
+
+	record := vault.Credential{
+		Service:      service,
+		Type:         af.recordType,
+		Username:     username,
+		Password:     []byte(password),
+		Category:     category,
+		URL:          url,
+		Notes:        notes,
+		CustomFields: dropEmptySchemaFields(schema, customFields),
 	}
 
-	// If TOTP was provided, update credential with TOTP fields
+	// If TOTP was provided, store it with the credential
 	if totpInput != "" {
 		totpConfig, err := vault.ParseTOTPURI(strings.TrimSpace(totpInput))
 		if err != nil {
-			// TOTP parsing failed - credential was added but without TOTP
+			// TOTP parsing failed - credential is added without TOTP
 			// Could show warning but don't fail the whole operation
-			// Form will close, user can edit later to fix TOTP
+			// User can edit later to fix TOTP
 		} else {
-			// Update credential with TOTP fields
-			opts := models.UpdateCredentialOpts{
-				TOTPSecret:    &totpConfig.Secret,
-				TOTPAlgorithm: &totpConfig.Algorithm,
-				TOTPDigits:    &totpConfig.Digits,
-				TOTPPeriod:    &totpConfig.Period,
-			}
-			if totpConfig.Issuer != "" {
-				opts.TOTPIssuer = &totpConfig.Issuer
-			}
-			// Ignore error - credential was added, TOTP is optional
-			_ = af.appState.UpdateCredential(service, opts)
+			record.TOTPSecret = totpConfig.Secret
+			record.TOTPAlgorithm = totpConfig.Algorithm
+			record.TOTPDigits = totpConfig.Digits
+			record.TOTPPeriod = totpConfig.Period
+			record.TOTPIssuer = totpConfig.Issuer
 		}
 	}
 
+	// Call AppState to add the record with all fields in one write
+	if err := af.appState.AddRecord(record); err != nil {
+		// Error already handled by AppState onError callback
+		// Form stays open for correction
+		return
+	}
+
 	// Success - invoke callback to close modal
 	if af.onSubmit != nil {
 		af.onSubmit()
@@ -266,12 +382,14 @@ func (af *AddForm) hasUnsavedData() bool {
 	url := af.form.GetFormItem(4).(*tview.InputField).GetText()
 	notes := af.form.GetFormItem(5).(*tview.TextArea).GetText()
 	totp := af.form.GetFormItem(6).(*tview.InputField).GetText()
+	customFields := af.form.GetFormItem(7).(*tview.TextArea).GetText()
 
 	// Consider form "dirty" if any field has non-empty value
-	// Ignore "Uncategorized" since it's the default
+	// Ignore "Uncategorized" and an untouched type template since they are defaults
 	return service != "" || username != "" || password != "" ||
 		(category != "" && category != "Uncategorized") ||
-		url != "" || notes != "" || totp != ""
+		url != "" || notes != "" || totp != "" ||
+		(strings.TrimSpace(customFields) != "" && customFields != af.fieldsTemplate)
 }
 
 // validate checks that required fields are filled.
@@ -283,21 +401,52 @@ func (af *AddForm) validate() error {
 		return fmt.Errorf("service is required")
 	}
 
-	// Username is required (minimum validation)
+	schema, _ := vault.GetRecordSchema(af.recordType)
+
+	// Username is required for logins (minimum validation)
 	username := af.form.GetFormItem(1).(*tview.InputField).GetText()
-	if username == "" {
+	if username == "" && schema.Type == vault.RecordTypeLogin {
 		return fmt.Errorf("username is required")
 	}
 
-	// Password validation (basic check)
+	// Password validation (basic check) - depends on the record type
 	password := af.form.GetFormItem(2).(*tview.InputField).GetText()
-	if password == "" {
-		return fmt.Errorf("password is required")
+	if password == "" && schema.PasswordRequired {
+		return fmt.Errorf("%s is required", strings.ToLower(schema.PasswordLabel))
+	}
+	if password != "" && schema.PasswordLabel == "" {
+		return fmt.Errorf("%s records have no password", schema.Type)
+	}
+
+	// Custom fields must be well-formed name=value lines
+	customFields, err := parseCustomFieldLines(af.form.GetFormItem(7).(*tview.TextArea).GetText())
+	if err != nil {
+		return err
+	}
+
+	// Type-specific required fields must have values
+	for _, schemaField := range schema.Fields {
+		if !schemaField.Required {
+			continue
+		}
+		if !containsFilledCustomField(customFields, schemaField.Name) {
+			return fmt.Errorf("%s is required for %s records", schemaField.Name, schema.Type)
+		}
 	}
 
 	return nil
 }
 
+// containsFilledCustomField reports whether fields has a non-empty entry with the given name.
+func containsFilledCustomField(fields []vault.CustomField, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) && strings.TrimSpace(field.Value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // T048, T049: updatePasswordLabel updates the password field label with strength indicator
 func (af *AddForm) updatePasswordLabel(field *tview.InputField, password []byte) {
 	policy := security.DefaultPasswordPolicy
@@ -402,9 +551,9 @@ func (af *AddForm) applyStyles() {
 	// Apply form-level styling
 	styles.ApplyFormStyle(af.form)
 
-	// Style individual input fields (7 fields: Service, Username, Password, Category, URL, Notes, TOTP)
+	// Style individual input fields (9 fields: Service, Username, Password, Category, URL, Notes, TOTP, Custom Fields, Type)
 	// Use BackgroundLight for input fields - lighter than form Background for contrast
-	for i := 0; i < 7; i++ {
+	for i := 0; i < 9; i++ {
 		item := af.form.GetFormItem(i)
 		switch field := item.(type) {
 		case *tview.InputField:
@@ -473,9 +622,20 @@ func (af *AddForm) GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey
 
 // NewEditForm creates a new form for editing an existing credential.
 // Pre-populates all fields with current credential values.
+// Fields holding {ref:...} references show the reference, not the resolved value,
+// so saving keeps the reference intact.
 func NewEditForm(appState *models.AppState, credential *vault.CredentialMetadata) *EditForm {
 	form := tview.NewForm()
 
+	if raw, err := appState.GetRawCredential(credential.Service); err == nil && raw != nil {
+		crypto.ClearBytes(raw.Password)
+		stored := *credential
+		stored.Username = raw.Username
+		stored.URL = raw.URL
+		stored.Notes = raw.Notes
+		credential = &stored
+	}
+
 	ef := &EditForm{
 		form:       form,
 		appState:   appState,
@@ -495,11 +655,9 @@ func (ef *EditForm) buildFormFieldsWithValues() {
 	categories := ef.getCategories()
 
 	// Pre-populate fields with existing credential data
-	// Service field is read-only (cannot be changed in edit mode)
+	// Changing the service name renames the credential on save (history and usage are kept)
 	// Use 0 width to make fields fill available space (prevents black rectangles)
 	ef.form.AddInputField("Service (UID)", ef.credential.Service, 0, nil, nil)
-	serviceField := ef.form.GetFormItem(0).(*tview.InputField)
-	serviceField.SetDisabled(true) // Make read-only to prevent confusion
 
 	ef.form.AddInputField("Username", ef.credential.Username, 0, nil, nil)
 
@@ -568,6 +726,19 @@ func (ef *EditForm) buildFormFieldsWithValues() {
 	}
 	ef.form.AddInputField(totpLabel, "", 0, nil, nil)
 
+	// Custom fields - values (including hidden ones) are only loaded once the editor is focused
+	customFieldsArea := tview.NewTextArea().
+		SetLabel("Custom Fields").
+		SetSize(3, 0).
+		SetPlaceholder(customFieldsPlaceholder)
+	if len(ef.credential.CustomFieldNames) > 0 {
+		customFieldsArea.SetPlaceholder(strings.Join(ef.credential.CustomFieldNames, ", ") + " (focus to edit)")
+	}
+	customFieldsArea.SetFocusFunc(func() {
+		ef.fetchCustomFieldsIfNeeded(customFieldsArea)
+	})
+	ef.form.AddFormItem(customFieldsArea)
+
 	// Clear TOTP checkbox - only meaningful if credential has TOTP
 	if ef.credential.HasTOTP {
 		ef.form.AddCheckbox("Clear TOTP", false, func(checked bool) {
@@ -590,8 +761,8 @@ func (ef *EditForm) fetchPasswordIfNeeded(passwordField *tview.InputField) {
 		return
 	}
 
-	// Fetch credential without tracking (track=false)
-	cred, err := ef.appState.GetFullCredentialWithTracking(ef.credential.Service, false)
+	// Fetch credential without tracking, references unresolved
+	cred, err := ef.appState.GetRawCredential(ef.credential.Service)
 	if err != nil {
 		// Surface error via AppState error handler without blocking UI
 		// Leave password field empty on error
@@ -609,6 +780,27 @@ func (ef *EditForm) fetchPasswordIfNeeded(passwordField *tview.InputField) {
 	ef.passwordFetched = true
 }
 
+// fetchCustomFieldsIfNeeded lazily loads custom field values when the editor is focused.
+// Mirrors fetchPasswordIfNeeded so hidden values are not pulled into the form until needed.
+func (ef *EditForm) fetchCustomFieldsIfNeeded(area *tview.TextArea) {
+	if ef.customFieldsFetched {
+		return
+	}
+	ef.customFieldsFetched = true // Mark as attempted to avoid retry loops
+
+	if len(ef.credential.CustomFieldNames) == 0 {
+		return
+	}
+
+	cred, err := ef.appState.GetRawCredential(ef.credential.Service)
+	if err != nil || cred == nil {
+		return
+	}
+
+	ef.originalCustomFields = cred.CustomFields
+	area.SetText(formatCustomFieldLines(cred.CustomFields), false)
+}
+
 // onSavePressed handles the Save button submission.
 // Shows confirmation if data changed, validates, and saves credential.
 func (ef *EditForm) onSavePressed() {
@@ -638,11 +830,21 @@ func (ef *EditForm) performSave() {
 		return
 	}
 
-	// Extract field values
-	// Note: Use original credential.Service as identifier, not form field
-	// This prevents ErrCredentialNotFound if user tries to edit service name
-	// For service renaming, a dedicated rename flow should be implemented
+	// Rename first so the update below targets the new name
 	service := ef.credential.Service
+	newService := strings.TrimSpace(ef.form.GetFormItem(0).(*tview.InputField).GetText())
+	if newService != service {
+		if err := ef.appState.RenameCredential(service, newService); err != nil {
+			// Error already handled by AppState onError callback
+			// Form stays open for correction
+			return
+		}
+		// Keep the form pointed at the renamed credential in case the update fails
+		ef.credential.Service = newService
+		service = newService
+	}
+
+	// Extract field values
 	username := ef.form.GetFormItem(1).(*tview.InputField).GetText()
 	password := ef.form.GetFormItem(2).(*tview.InputField).GetText()
 
@@ -698,6 +900,18 @@ func (ef *EditForm) performSave() {
 		}
 	}
 
+	// Custom fields: only touched once the editor has been loaded, so an unfocused
+	// (empty) editor never wipes existing fields
+	if ef.customFieldsFetched {
+		customFields, _ := parseCustomFieldLines(ef.form.GetFormItem(7).(*tview.TextArea).GetText()) // Already validated
+		opts.SetCustomFields = customFields
+		for _, original := range ef.originalCustomFields {
+			if !containsCustomField(customFields, original.Name) {
+				opts.RemoveCustomFields = append(opts.RemoveCustomFields, original.Name)
+			}
+		}
+	}
+
 	// Call AppState to update credential with options struct
 	err := ef.appState.UpdateCredential(service, opts)
 	if err != nil {
@@ -760,46 +974,63 @@ func (ef *EditForm) onGeneratePassword() {
 
 // hasUnsavedChanges checks if any form fields have been modified from original values.
 func (ef *EditForm) hasUnsavedChanges() bool {
+	service := ef.form.GetFormItem(0).(*tview.InputField).GetText()
 	username := ef.form.GetFormItem(1).(*tview.InputField).GetText()
 	password := ef.form.GetFormItem(2).(*tview.InputField).GetText()
 	category := ef.form.GetFormItem(3).(*tview.InputField).GetText()
 	url := ef.form.GetFormItem(4).(*tview.InputField).GetText()
 	notes := ef.form.GetFormItem(5).(*tview.TextArea).GetText()
 	totpInput := ef.form.GetFormItem(6).(*tview.InputField).GetText()
+	customFields := ef.form.GetFormItem(7).(*tview.TextArea).GetText()
 
 	// Normalize current category for comparison
 	normalizedCategory := normalizeCategory(category)
 
 	// Compare with original values
-	return username != ef.credential.Username ||
+	return strings.TrimSpace(service) != ef.credential.Service ||
+		username != ef.credential.Username ||
 		password != ef.originalPassword ||
 		normalizedCategory != ef.credential.Category ||
 		url != ef.credential.URL ||
 		notes != ef.credential.Notes ||
 		totpInput != "" || // Any TOTP input means changes
-		ef.clearTOTP // Clear TOTP checkbox is checked
+		ef.clearTOTP || // Clear TOTP checkbox is checked
+		(ef.customFieldsFetched && customFields != formatCustomFieldLines(ef.originalCustomFields))
 }
 
 // validate checks that required fields are filled.
 // Returns error describing first validation failure, or nil if valid.
 func (ef *EditForm) validate() error {
 	// Service is required (cannot be empty)
-	service := ef.form.GetFormItem(0).(*tview.InputField).GetText()
+	service := strings.TrimSpace(ef.form.GetFormItem(0).(*tview.InputField).GetText())
 	if service == "" {
 		return fmt.Errorf("service is required")
 	}
 
-	// Username is required (minimum validation)
+	// Username is required for logins (minimum validation)
 	username := ef.form.GetFormItem(1).(*tview.InputField).GetText()
-	if username == "" {
+	if username == "" && ef.recordType() == vault.RecordTypeLogin {
 		return fmt.Errorf("username is required")
 	}
 
 	// Password not required in edit form (can keep existing)
 
+	// Custom fields must be well-formed name=value lines
+	if _, err := parseCustomFieldLines(ef.form.GetFormItem(7).(*tview.TextArea).GetText()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// recordType returns the edited credential's record type, treating an empty type as login.
+func (ef *EditForm) recordType() string {
+	if ef.credential.Type == "" {
+		return vault.RecordTypeLogin
+	}
+	return ef.credential.Type
+}
+
 // getCategories retrieves available categories from AppState.
 // Returns default "Uncategorized" if no categories exist.
 func (ef *EditForm) getCategories() []string {
@@ -831,9 +1062,13 @@ func (ef *EditForm) wrapInFrame() {
 		AddItem(ef.form, 0, 1, true). // Form takes all available space
 		AddItem(hints, 2, 0, false)   // Hints fixed at 2 rows (enough for wrapped text)
 
-	// Apply border and title to the flex container
+	// Apply border and title to the flex container (non-login records show their type)
+	title := " Edit Credential "
+	if ef.recordType() != vault.RecordTypeLogin {
+		title = fmt.Sprintf(" Edit Credential (%s) ", ef.recordType())
+	}
 	flex.SetBorder(true).
-		SetTitle(" Edit Credential ").
+		SetTitle(title).
 		SetTitleAlign(tview.AlignLeft).
 		SetBorderColor(theme.BorderColor)
 
@@ -905,12 +1140,12 @@ func (ef *EditForm) applyStyles() {
 	styles.ApplyFormStyle(ef.form)
 
 	// Style individual input fields
-	// Form has 7 fields (Service, Username, Password, Category, URL, Notes, TOTP)
-	// Plus optional Clear TOTP checkbox (8th item) if credential has TOTP
+	// Form has 8 fields (Service, Username, Password, Category, URL, Notes, TOTP, Custom Fields)
+	// Plus optional Clear TOTP checkbox (9th item) if credential has TOTP
 	// Use BackgroundLight for input fields - lighter than form Background for contrast
-	numFields := 7
+	numFields := 8
 	if ef.credential.HasTOTP {
-		numFields = 8 // Include Clear TOTP checkbox
+		numFields = 9 // Include Clear TOTP checkbox
 	}
 	for i := 0; i < numFields; i++ {
 		item := ef.form.GetFormItem(i)
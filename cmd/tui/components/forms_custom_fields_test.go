@@ -0,0 +1,117 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/arimxyer/pass-cli/cmd/tui/models"
+	"github.com/arimxyer/pass-cli/internal/vault"
+	"github.com/rivo/tview"
+)
+
+func TestParseCustomFieldLines(t *testing.T) {
+	text := "account-id=1234\n\n!secret-key=abc=def\n  \nregion="
+
+	fields, err := parseCustomFieldLines(text)
+	if err != nil {
+		t.Fatalf("parseCustomFieldLines() failed: %v", err)
+	}
+
+	want := []vault.CustomField{
+		{Name: "account-id", Value: "1234"},
+		{Name: "secret-key", Value: "abc=def", Hidden: true},
+		{Name: "region", Value: ""},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, fields[i], want[i])
+		}
+	}
+
+	// Round trip through the editor format
+	roundTrip, err := parseCustomFieldLines(formatCustomFieldLines(fields))
+	if err != nil {
+		t.Fatalf("round trip failed: %v", err)
+	}
+	if len(roundTrip) != len(fields) || roundTrip[1] != fields[1] {
+		t.Errorf("round trip mismatch: %+v vs %+v", roundTrip, fields)
+	}
+}
+
+func TestParseCustomFieldLines_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing equals": "account-id",
+		"reserved name":  "password=oops",
+		"duplicate name": "region=a\nRegion=b",
+	}
+
+	for name, text := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseCustomFieldLines(text); err == nil {
+				t.Errorf("parseCustomFieldLines(%q) expected error", text)
+			}
+		})
+	}
+}
+
+func TestAddFormTypeTemplate(t *testing.T) {
+	appState := models.NewAppState(newMockVaultServiceForForms())
+	form := NewAddForm(appState)
+
+	area := form.GetFormItem(7).(*tview.TextArea)
+	typeDropDown := form.GetFormItem(8).(*tview.DropDown)
+
+	// Selecting a type prefills its fields, hidden ones with the '!' prefix
+	typeDropDown.SetCurrentOption(indexOf(vault.RecordTypes(), vault.RecordTypeCard))
+	if got := area.GetText(); got != "cardholder=\n!number=\nexpiry=\n!cvv=\n!pin=" {
+		t.Errorf("card template = %q", got)
+	}
+	if form.hasUnsavedData() {
+		t.Error("untouched template should not count as unsaved data")
+	}
+
+	// An untouched template is replaced, edited text is kept
+	typeDropDown.SetCurrentOption(indexOf(vault.RecordTypes(), vault.RecordTypeDatabase))
+	if got := area.GetText(); got != "engine=\nhost=\nport=\ndatabase=" {
+		t.Errorf("database template = %q", got)
+	}
+	area.SetText("host=db.internal", false)
+	typeDropDown.SetCurrentOption(indexOf(vault.RecordTypes(), vault.RecordTypeIdentity))
+	if got := area.GetText(); got != "host=db.internal" {
+		t.Errorf("edited text was replaced: %q", got)
+	}
+}
+
+func TestAddFormValidateRecordType(t *testing.T) {
+	appState := models.NewAppState(newMockVaultServiceForForms())
+	form := NewAddForm(appState)
+	typeDropDown := form.GetFormItem(8).(*tview.DropDown)
+
+	form.GetFormItem(0).(*tview.InputField).SetText("visa")
+	typeDropDown.SetCurrentOption(indexOf(vault.RecordTypes(), vault.RecordTypeCard))
+
+	// Cards need no username or password, but do need a number
+	if err := form.validate(); err == nil {
+		t.Error("expected error for missing card number")
+	}
+	form.GetFormItem(7).(*tview.TextArea).SetText("!number=4111111111111111\ncvv=", false)
+	if err := form.validate(); err != nil {
+		t.Errorf("validate() failed: %v", err)
+	}
+	form.GetFormItem(2).(*tview.InputField).SetText("secret")
+	if err := form.validate(); err == nil {
+		t.Error("expected error for password on a card")
+	}
+}
+
+// indexOf returns the position of value in values, or -1.
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -40,6 +40,16 @@ func (m *mockVaultServiceForForms) AddCredential(service, username string, passw
 	return nil
 }
 
+func (m *mockVaultServiceForForms) AddRecord(record vault.Credential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.credentials = append(m.credentials, vault.CredentialMetadata{
+		Service: record.Service, Type: record.RecordType(), Username: record.Username, Category: record.Category,
+		URL: record.URL, Notes: record.Notes, Tags: record.Tags, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	})
+	return nil
+}
+
 func (m *mockVaultServiceForForms) UpdateCredential(service string, opts vault.UpdateOpts) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -87,6 +97,14 @@ func (m *mockVaultServiceForForms) GetCredential(service string, trackUsage bool
 	return nil, errors.New("not found")
 }
 
+func (m *mockVaultServiceForForms) GetCredentialRaw(service string) (*vault.Credential, error) {
+	return m.GetCredential(service, false)
+}
+
+func (m *mockVaultServiceForForms) Referrers(service string) []string {
+	return nil
+}
+
 func (m *mockVaultServiceForForms) RecordFieldAccess(service, field string) error {
 	return nil
 }
@@ -95,6 +113,22 @@ func (m *mockVaultServiceForForms) GetTOTPCode(service string) (string, int, err
 	return "", 0, errors.New("TOTP not configured")
 }
 
+func (m *mockVaultServiceForForms) GetHistory(service string) ([]vault.Revision, error) {
+	return nil, nil
+}
+
+func (m *mockVaultServiceForForms) RenameCredential(oldService, newService string) error {
+	return errors.New("not found")
+}
+
+func (m *mockVaultServiceForForms) RestoreFromTrash(serviceOrID string) (string, error) {
+	return "", errors.New("not found")
+}
+
+func (m *mockVaultServiceForForms) TrashRetentionDays() int {
+	return 0
+}
+
 // TestAddFormPasswordVisibilityToggle verifies the toggle changes label
 // T004: Unit test for AddForm password visibility toggle functionality
 // NOTE: tview InputField doesn't expose GetMaskCharacter(), so we test via label changes
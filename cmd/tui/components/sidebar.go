@@ -13,12 +13,13 @@ import (
 // NodeReference identifies the type and value of a tree node.
 // Used to distinguish categories from credentials without relying on tree position.
 type NodeReference struct {
-	Kind  string // "category" or "credential"
-	Value string // Category name or service name
+	Kind  string // "category", "tags", "tag" or "credential"
+	Value string // Category name, tag name or service name
 }
 
 // Sidebar wraps tview.TreeView to display credential categories.
 // Provides category navigation with "All Credentials" root and category children.
+// Categories that are folder paths ("Clients/Acme/Prod") are shown as nested folders.
 type Sidebar struct {
 	*tview.TreeView
 
@@ -71,7 +72,8 @@ func NewSidebar(appState *models.AppState) *Sidebar {
 }
 
 // Refresh rebuilds the category tree from current AppState.
-// Clears existing children and builds category-grouped tree with credential nodes.
+// Clears existing children and builds a folder tree: each folder lists its
+// subfolders first, then its credentials.
 func (s *Sidebar) Refresh() {
 	theme := styles.GetCurrentTheme()
 
@@ -81,45 +83,55 @@ func (s *Sidebar) Refresh() {
 	// Clear existing children
 	s.rootNode.ClearChildren()
 
-	// Pre-group credentials by category for O(N+C) performance instead of O(C×N)
+	// Pre-group credentials by folder for O(N+F) performance instead of O(F×N)
 	groups := make(map[string][]vault.CredentialMetadata)
 	for _, cred := range credentials {
-		category := cred.Category
+		category := vault.NormalizeFolder(cred.Category)
 		if category == "" {
 			category = "Uncategorized"
 		}
 		groups[category] = append(groups[category], cred)
 	}
 
-	// Build category list from groups (avoids snapshot mismatch with credentials)
+	// Build folder list from groups (avoids snapshot mismatch with credentials),
+	// adding parent folders that hold no credentials directly
 	categories := make([]string, 0, len(groups))
 	for category := range groups {
 		categories = append(categories, category)
 	}
-	sort.Strings(categories) // Sort categories alphabetically
-
-	// Build category nodes with credential children
-	for _, category := range categories {
-		// Create category node with theme background
-		categoryStyle := tcell.StyleDefault.
-			Foreground(theme.TextPrimary).
-			Background(theme.Background)
-		categoryNode := tview.NewTreeNode(category).
+	folders := vault.FolderPaths(categories) // Parents sort before their subfolders
+
+	// Build folder nodes, attaching each to its parent (or the root)
+	categoryStyle := tcell.StyleDefault.
+		Foreground(theme.TextPrimary).
+		Background(theme.Background)
+	folderNodes := make(map[string]*tview.TreeNode, len(folders))
+	for _, folder := range folders {
+		categoryNode := tview.NewTreeNode(vault.FolderName(folder)).
 			SetSelectable(true).
 			SetTextStyle(categoryStyle).
-			SetReference(NodeReference{Kind: "category", Value: category}).
+			SetReference(NodeReference{Kind: "category", Value: folder}).
 			SetExpanded(false) // Collapsed by default
+		folderNodes[folder] = categoryNode
+
+		if parent := vault.FolderParent(folder); parent != "" {
+			folderNodes[parent].AddChild(categoryNode)
+		} else {
+			s.rootNode.AddChild(categoryNode)
+		}
+	}
 
-		// Sort credentials within category for deterministic ordering
-		credList := groups[category]
+	// Add credential nodes below the subfolders of each folder
+	credStyle := tcell.StyleDefault.
+		Foreground(theme.TextSecondary).
+		Background(theme.Background)
+	for _, folder := range folders {
+		// Sort credentials within folder for deterministic ordering
+		credList := groups[folder]
 		sort.Slice(credList, func(i, j int) bool {
 			return credList[i].Service < credList[j].Service
 		})
 
-		// Add credential nodes from sorted list
-		credStyle := tcell.StyleDefault.
-			Foreground(theme.TextSecondary).
-			Background(theme.Background)
 		for _, cred := range credList {
 			// Create credential node with theme background
 			credNode := tview.NewTreeNode(cred.Service).
@@ -127,19 +139,79 @@ func (s *Sidebar) Refresh() {
 				SetTextStyle(credStyle).
 				SetReference(NodeReference{Kind: "credential", Value: cred.Service})
 
-			categoryNode.AddChild(credNode)
+			folderNodes[folder].AddChild(credNode)
 		}
-
-		// Add category node to root
-		s.rootNode.AddChild(categoryNode)
 	}
 
+	// Tags branch: a credential appears under every tag it carries
+	s.addTagsBranch(credentials)
+
 	// Ensure root is expanded
 	s.rootNode.SetExpanded(true)
 }
 
+// addTagsBranch appends a "Tags" node with one child per tag, each listing its credentials.
+// Omitted entirely when no credential is tagged.
+func (s *Sidebar) addTagsBranch(credentials []vault.CredentialMetadata) {
+	theme := styles.GetCurrentTheme()
+
+	tagGroups := make(map[string][]vault.CredentialMetadata)
+	for _, cred := range credentials {
+		for _, tag := range cred.Tags {
+			tagGroups[tag] = append(tagGroups[tag], cred)
+		}
+	}
+	if len(tagGroups) == 0 {
+		return
+	}
+
+	tags := make([]string, 0, len(tagGroups))
+	for tag := range tagGroups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	branchStyle := tcell.StyleDefault.
+		Foreground(theme.BorderColor).
+		Background(theme.Background)
+	tagsNode := tview.NewTreeNode("Tags").
+		SetSelectable(true).
+		SetTextStyle(branchStyle).
+		SetReference(NodeReference{Kind: "tags"}).
+		SetExpanded(false)
+
+	tagStyle := tcell.StyleDefault.
+		Foreground(theme.TextPrimary).
+		Background(theme.Background)
+	credStyle := tcell.StyleDefault.
+		Foreground(theme.TextSecondary).
+		Background(theme.Background)
+	for _, tag := range tags {
+		tagNode := tview.NewTreeNode("#" + tag).
+			SetSelectable(true).
+			SetTextStyle(tagStyle).
+			SetReference(NodeReference{Kind: "tag", Value: tag}).
+			SetExpanded(false)
+
+		credList := tagGroups[tag]
+		sort.Slice(credList, func(i, j int) bool {
+			return credList[i].Service < credList[j].Service
+		})
+		for _, cred := range credList {
+			tagNode.AddChild(tview.NewTreeNode(cred.Service).
+				SetSelectable(true).
+				SetTextStyle(credStyle).
+				SetReference(NodeReference{Kind: "credential", Value: cred.Service}))
+		}
+
+		tagsNode.AddChild(tagNode)
+	}
+
+	s.rootNode.AddChild(tagsNode)
+}
+
 // onSelect handles node selection by updating AppState.
-// Root node shows all, category nodes filter by category, credential nodes select specific credential.
+// Root and "Tags" nodes show all, category and tag nodes filter, credential nodes select specific credential.
 func (s *Sidebar) onSelect(node *tview.TreeNode) {
 	if node == s.rootNode {
 		// Root selected - show all credentials and clear detail view
@@ -165,6 +237,10 @@ func (s *Sidebar) onSelect(node *tview.TreeNode) {
 			// Use SetSelection for atomic update with single notification
 			s.appState.SetSelection(nodeRef.Value, nil)
 
+		case "tag":
+			// Tag node - filter by tag and clear credential selection
+			s.appState.SetTagSelection(nodeRef.Value)
+
 		case "credential":
 			// Credential node - lookup credential by service and select it
 			if credMeta, found := s.appState.FindCredentialByService(nodeRef.Value); found {
@@ -39,6 +39,16 @@ func (m *MockVaultService) AddCredential(service, username string, password []by
 	return nil
 }
 
+func (m *MockVaultService) AddRecord(record vault.Credential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.credentials = append(m.credentials, vault.CredentialMetadata{
+		Service: record.Service, Type: record.RecordType(), Username: record.Username, Category: record.Category,
+		URL: record.URL, Notes: record.Notes, Tags: record.Tags, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	})
+	return nil
+}
+
 func (m *MockVaultService) UpdateCredential(service string, opts vault.UpdateOpts) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -86,6 +96,14 @@ func (m *MockVaultService) GetCredential(service string, trackUsage bool) (*vaul
 	return nil, errors.New("not found")
 }
 
+func (m *MockVaultService) GetCredentialRaw(service string) (*vault.Credential, error) {
+	return m.GetCredential(service, false)
+}
+
+func (m *MockVaultService) Referrers(service string) []string {
+	return nil
+}
+
 func (m *MockVaultService) RecordFieldAccess(service, field string) error {
 	return nil
 }
@@ -94,6 +112,30 @@ func (m *MockVaultService) GetTOTPCode(service string) (string, int, error) {
 	return "", 0, errors.New("TOTP not configured")
 }
 
+func (m *MockVaultService) GetHistory(service string) ([]vault.Revision, error) {
+	return nil, nil
+}
+
+func (m *MockVaultService) RenameCredential(oldService, newService string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, cred := range m.credentials {
+		if cred.Service == oldService {
+			m.credentials[i].Service = newService
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (m *MockVaultService) RestoreFromTrash(serviceOrID string) (string, error) {
+	return "", errors.New("not found")
+}
+
+func (m *MockVaultService) TrashRetentionDays() int {
+	return 0
+}
+
 func (m *MockVaultService) SetCredentials(creds []vault.CredentialMetadata) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -570,3 +612,98 @@ func TestSidebarRefresh_MixedCategoriesAndUncategorized(t *testing.T) {
 		}
 	}
 }
+
+// TestSidebarRefresh_TagsBranch verifies the Tags branch lists each tag with its credentials.
+func TestSidebarRefresh_TagsBranch(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := models.NewAppState(mockVault)
+
+	mockCreds := []vault.CredentialMetadata{
+		{Service: "AWS", Category: "Cloud", Tags: []string{"prod", "work"}, CreatedAt: time.Now()},
+		{Service: "GitHub", Category: "Dev", Tags: []string{"work"}, CreatedAt: time.Now()},
+		{Service: "Email", Category: "Personal", CreatedAt: time.Now()},
+	}
+	mockVault.SetCredentials(mockCreds)
+	_ = state.LoadCredentials()
+
+	sidebar := NewSidebar(state)
+
+	// Three categories plus the Tags branch last
+	children := sidebar.rootNode.GetChildren()
+	require.Len(t, children, 4)
+	tagsNode := children[3]
+	require.Equal(t, "Tags", tagsNode.GetText())
+
+	tagNodes := tagsNode.GetChildren()
+	require.Len(t, tagNodes, 2)
+	require.Equal(t, "#prod", tagNodes[0].GetText())
+	require.Equal(t, "#work", tagNodes[1].GetText())
+	require.Len(t, tagNodes[1].GetChildren(), 2, "work tag should list AWS and GitHub")
+
+	// Selecting a tag filters by tag and clears the category
+	sidebar.onSelect(children[0])
+	sidebar.onSelect(tagNodes[1])
+	require.Equal(t, "work", state.GetSelectedTag())
+	require.Equal(t, "", state.GetSelectedCategory())
+
+	// Selecting a category clears the tag filter
+	sidebar.onSelect(children[0])
+	require.Equal(t, "", state.GetSelectedTag())
+}
+
+// TestSidebarRefresh_NoTagsBranchWithoutTags verifies the Tags branch is omitted for untagged vaults.
+func TestSidebarRefresh_NoTagsBranchWithoutTags(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := models.NewAppState(mockVault)
+
+	mockVault.SetCredentials([]vault.CredentialMetadata{
+		{Service: "AWS", Category: "Cloud", CreatedAt: time.Now()},
+	})
+	_ = state.LoadCredentials()
+
+	sidebar := NewSidebar(state)
+
+	for _, child := range sidebar.rootNode.GetChildren() {
+		require.NotEqual(t, "Tags", child.GetText())
+	}
+}
+
+// TestSidebarRefresh_NestedFolders verifies folder paths become nested nodes.
+func TestSidebarRefresh_NestedFolders(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := models.NewAppState(mockVault)
+
+	mockVault.SetCredentials([]vault.CredentialMetadata{
+		{Service: "acme-db", Category: "Clients/Acme/Prod", CreatedAt: time.Now()},
+		{Service: "acme-portal", Category: "Clients/Acme", CreatedAt: time.Now()},
+		{Service: "globex", Category: "Clients/Globex", CreatedAt: time.Now()},
+		{Service: "AWS", Category: "Cloud", CreatedAt: time.Now()},
+	})
+	_ = state.LoadCredentials()
+
+	sidebar := NewSidebar(state)
+
+	// Top level: Clients (no credentials of its own) and Cloud
+	children := sidebar.rootNode.GetChildren()
+	require.Len(t, children, 2)
+	clients := children[0]
+	require.Equal(t, "Clients", clients.GetText())
+	require.Equal(t, NodeReference{Kind: "category", Value: "Clients"}, clients.GetReference())
+
+	// Clients holds two subfolders
+	subfolders := clients.GetChildren()
+	require.Len(t, subfolders, 2)
+	require.Equal(t, "Acme", subfolders[0].GetText())
+	require.Equal(t, "Globex", subfolders[1].GetText())
+
+	// Acme lists its subfolder before its own credential
+	acme := subfolders[0].GetChildren()
+	require.Len(t, acme, 2)
+	require.Equal(t, NodeReference{Kind: "category", Value: "Clients/Acme/Prod"}, acme[0].GetReference())
+	require.Equal(t, "Prod", acme[0].GetText())
+	require.Equal(t, NodeReference{Kind: "credential", Value: "acme-portal"}, acme[1].GetReference())
+
+	// Selecting a folder filters by its full path
+	sidebar.onSelect(subfolders[0])
+	require.Equal(t, "Clients/Acme", state.GetSelectedCategory())
+}
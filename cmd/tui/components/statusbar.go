@@ -57,7 +57,7 @@ func NewStatusBar(app *tview.Application, appState *models.AppState, cfg *config
 	}
 
 	// Set initial shortcuts display (direct SetText, no queue - app not running yet)
-	shortcuts := sb.getShortcutsForContext(FocusSidebar)
+	shortcuts := sb.vaultBadge() + sb.getShortcutsForContext(FocusSidebar) + sb.expiredBadge(FocusSidebar)
 	sb.SetText(shortcuts)
 
 	return sb
@@ -66,7 +66,7 @@ func NewStatusBar(app *tview.Application, appState *models.AppState, cfg *config
 // UpdateForContext updates the displayed shortcuts based on the current focus context.
 func (sb *StatusBar) UpdateForContext(focus FocusContext) {
 	sb.currentFocus = focus
-	shortcuts := sb.getShortcutsForContext(focus)
+	shortcuts := sb.vaultBadge() + sb.getShortcutsForContext(focus) + sb.expiredBadge(focus)
 
 	// Direct SetText is sufficient - tview redraws automatically on next frame
 	sb.SetText(shortcuts)
@@ -90,6 +90,12 @@ func (sb *StatusBar) ShowError(err error) {
 	sb.showTemporaryMessage(formatted, 5*time.Second)
 }
 
+// ShowUndo displays a success message with an undo hint for the given duration.
+func (sb *StatusBar) ShowUndo(message string, duration time.Duration) {
+	formatted := fmt.Sprintf("[green]%s[-]  [yellow]z[-]:Undo", message)
+	sb.showTemporaryMessage(formatted, duration)
+}
+
 // showTemporaryMessage displays a message for the specified duration, then restores shortcuts.
 func (sb *StatusBar) showTemporaryMessage(message string, duration time.Duration) {
 	// Cancel previous message timer if it exists
@@ -106,6 +112,35 @@ func (sb *StatusBar) showTemporaryMessage(message string, duration time.Duration
 	})
 }
 
+// vaultBadge names the registry vault in use (selected with --vault or 'vault use'),
+// or returns an empty string when none is.
+func (sb *StatusBar) vaultBadge() string {
+	if sb.config == nil || sb.config.ActiveVault == "" {
+		return ""
+	}
+	return fmt.Sprintf("[cyan]vault:[-] %s  ", sb.config.ActiveVault)
+}
+
+// expiredBadge returns a warning with the number of expired credentials, or an empty
+// string when none have expired. Hidden while a modal is open.
+func (sb *StatusBar) expiredBadge(focus FocusContext) string {
+	if focus == FocusModal {
+		return ""
+	}
+
+	now := time.Now()
+	expired := 0
+	for _, cred := range sb.appState.GetCredentials() {
+		if cred.IsExpired(now) {
+			expired++
+		}
+	}
+	if expired == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  [red]⚠ %d expired[-]", expired)
+}
+
 // getShortcutsForContext returns the appropriate shortcut text for the given focus context.
 func (sb *StatusBar) getShortcutsForContext(focus FocusContext) string {
 	// Check if search is active
@@ -71,13 +71,14 @@ func (ct *CredentialTable) buildHeader() {
 }
 
 // Refresh rebuilds the table from filtered credentials.
-// Gets credentials from AppState, filters by selected category and search query, and updates rows.
+// Gets credentials from AppState, filters by selected category or tag and search query, and updates rows.
 // Uses incremental updates: reuses existing rows instead of full rebuild for better performance.
 func (ct *CredentialTable) Refresh() {
 	// Get credentials and filter by category (thread-safe read)
 	allCreds := ct.appState.GetCredentials()
 	category := ct.appState.GetSelectedCategory()
 	categoryFiltered := ct.filterByCategory(allCreds, category)
+	categoryFiltered = ct.filterByTag(categoryFiltered, ct.appState.GetSelectedTag())
 
 	// Apply search filter on top of category filter
 	searchState := ct.appState.GetSearchState()
@@ -88,14 +89,23 @@ func (ct *CredentialTable) Refresh() {
 	newRowCount := len(ct.filteredCreds)
 
 	theme := styles.GetCurrentTheme()
+	now := time.Now()
 
 	// Update existing rows and add new ones if needed
 	for i, cred := range ct.filteredCreds {
 		row := i + 1 // +1 to skip header row
 
+		// Expired credentials get a badge and are shown in the error color
+		serviceText := cred.Service
+		serviceColor := theme.TextPrimary
+		if cred.IsExpired(now) {
+			serviceText += " (expired)"
+			serviceColor = theme.Error
+		}
+
 		if i < currentRowCount {
 			// Reuse existing row - update cell contents
-			ct.GetCell(row, 0).SetText(cred.Service).SetReference(cred)
+			ct.GetCell(row, 0).SetText(serviceText).SetTextColor(serviceColor).SetReference(cred)
 			ct.GetCell(row, 1).SetText(cred.Username)
 
 			lastUsed := "Never"
@@ -105,8 +115,8 @@ func (ct *CredentialTable) Refresh() {
 			ct.GetCell(row, 2).SetText(lastUsed)
 		} else {
 			// Add new row (same as populateRows logic)
-			serviceCell := tview.NewTableCell(cred.Service).
-				SetTextColor(theme.TextPrimary).
+			serviceCell := tview.NewTableCell(serviceText).
+				SetTextColor(serviceColor).
 				SetAlign(tview.AlignLeft).
 				SetReference(cred)
 
@@ -172,6 +182,7 @@ func (ct *CredentialTable) applySelection(row int) {
 }
 
 // filterByCategory filters credentials by selected category.
+// A folder shows the credentials in its subfolders as well.
 // Empty category returns all credentials.
 func (ct *CredentialTable) filterByCategory(creds []vault.CredentialMetadata, category string) []vault.CredentialMetadata {
 	if category == "" {
@@ -180,7 +191,23 @@ func (ct *CredentialTable) filterByCategory(creds []vault.CredentialMetadata, ca
 
 	filtered := make([]vault.CredentialMetadata, 0)
 	for _, cred := range creds {
-		if cred.Category == category {
+		if vault.InFolder(vault.NormalizeFolder(cred.Category), category, true) {
+			filtered = append(filtered, cred)
+		}
+	}
+	return filtered
+}
+
+// filterByTag filters credentials by selected tag.
+// Empty tag returns all credentials.
+func (ct *CredentialTable) filterByTag(creds []vault.CredentialMetadata, tag string) []vault.CredentialMetadata {
+	if tag == "" {
+		return creds // Show all
+	}
+
+	filtered := make([]vault.CredentialMetadata, 0)
+	for _, cred := range creds {
+		if cred.HasTag(tag) {
 			filtered = append(filtered, cred)
 		}
 	}
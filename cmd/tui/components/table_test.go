@@ -365,6 +365,35 @@ func TestCredentialTableFilter_ByCategory(t *testing.T) {
 	}
 }
 
+// TestCredentialTableFilter_ByFolder verifies a folder includes its subfolders.
+func TestCredentialTableFilter_ByFolder(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := models.NewAppState(mockVault)
+
+	table := NewCredentialTable(state)
+
+	allCreds := []vault.CredentialMetadata{
+		{Service: "acme-db", Category: "Clients/Acme/Prod"},
+		{Service: "acme-portal", Category: "Clients/Acme"},
+		{Service: "acme-corp", Category: "Clients/Acme Corp"},
+		{Service: "aws", Category: "Cloud"},
+	}
+
+	filtered := table.filterByCategory(allCreds, "Clients/Acme")
+	if len(filtered) != 2 {
+		t.Fatalf("Expected 2 credentials in Clients/Acme, got %d", len(filtered))
+	}
+	for _, cred := range filtered {
+		if cred.Service == "acme-corp" {
+			t.Error("Clients/Acme Corp is a sibling folder, not a subfolder of Clients/Acme")
+		}
+	}
+
+	if filtered = table.filterByCategory(allCreds, "Clients"); len(filtered) != 3 {
+		t.Errorf("Expected 3 credentials under Clients, got %d", len(filtered))
+	}
+}
+
 // TestCredentialTableRefresh_UpdatesTitle verifies title shows count.
 func TestCredentialTableRefresh_UpdatesTitle(t *testing.T) {
 	mockVault := NewMockVaultService()
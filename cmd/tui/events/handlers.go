@@ -2,6 +2,8 @@ package events
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -12,6 +14,9 @@ import (
 	"github.com/arimxyer/pass-cli/internal/config"
 )
 
+// undoWindow is how long 'z' restores the last deleted credential from the trash
+const undoWindow = 10 * time.Second
+
 // EventHandler manages global keyboard shortcuts with focus-aware input protection.
 // Prevents shortcuts from interfering with form input while enabling app-wide navigation.
 type EventHandler struct {
@@ -23,6 +28,10 @@ type EventHandler struct {
 	detailView  *components.DetailView // Direct reference for password operations
 	layoutMgr   *layout.LayoutManager  // Reference for layout manipulation
 	config      *config.Config         // User configuration for keybindings
+
+	// Last deleted credential, restorable with 'z' until undoDeadline
+	undoService  string
+	undoDeadline time.Time
 }
 
 // NewEventHandler creates a new event handler with all required dependencies.
@@ -183,6 +192,15 @@ func (eh *EventHandler) handleGlobalKey(event *tcell.EventKey) *tcell.EventKey {
 		case 'T':
 			eh.handleToggleTOTP()
 			return nil
+		case 'f':
+			eh.handleCopyCustomField()
+			return nil
+		case 'h':
+			eh.handleToggleHistory()
+			return nil
+		case 'z':
+			eh.handleUndoDelete()
+			return nil
 		}
 	}
 
@@ -230,9 +248,16 @@ func (eh *EventHandler) handleEditCredential() {
 	}
 
 	form := components.NewEditForm(eh.appState, cred)
+	service := cred.Service
+	referrers := eh.appState.Referrers(service)
 
 	form.SetOnSubmit(func() {
 		eh.pageManager.CloseModal("edit-form")
+		// Renaming leaves {ref:...} values pointing at the old name
+		if selected := eh.appState.GetSelectedCredential(); len(referrers) > 0 && selected != nil && selected.Service != service {
+			eh.statusBar.ShowInfo(fmt.Sprintf("Credential renamed; still referenced by %s", strings.Join(referrers, ", ")))
+			return
+		}
 		eh.statusBar.ShowSuccess("Credential updated!")
 	})
 
@@ -255,16 +280,29 @@ func (eh *EventHandler) handleDeleteCredential() {
 		return
 	}
 
-	message := fmt.Sprintf("Delete credential '%s'?\nThis action cannot be undone.", cred.Service)
+	service := cred.Service
+	trashEnabled := eh.appState.TrashRetentionDays() > 0
+
+	message := fmt.Sprintf("Delete credential '%s'?\nThis action cannot be undone.", service)
+	if trashEnabled {
+		message = fmt.Sprintf("Delete credential '%s'?\nIt will be moved to the trash.", service)
+	}
+	if referrers := eh.appState.Referrers(service); len(referrers) > 0 {
+		message += fmt.Sprintf("\nReferenced by: %s", strings.Join(referrers, ", "))
+	}
 
 	eh.pageManager.ShowConfirmDialog(
 		"Delete Credential",
 		message,
 		func() {
 			// Yes - delete credential
-			err := eh.appState.DeleteCredential(cred.Service)
+			err := eh.appState.DeleteCredential(service)
 			if err != nil {
 				eh.statusBar.ShowError(err)
+			} else if trashEnabled {
+				eh.undoService = service
+				eh.undoDeadline = time.Now().Add(undoWindow)
+				eh.statusBar.ShowUndo(fmt.Sprintf("Moved '%s' to trash", service), undoWindow)
 			} else {
 				eh.statusBar.ShowSuccess("Credential deleted")
 			}
@@ -275,6 +313,22 @@ func (eh *EventHandler) handleDeleteCredential() {
 	)
 }
 
+// handleUndoDelete restores the last deleted credential while the undo toast is showing.
+func (eh *EventHandler) handleUndoDelete() {
+	if eh.undoService == "" || time.Now().After(eh.undoDeadline) {
+		return
+	}
+
+	service := eh.undoService
+	eh.undoService = ""
+
+	if err := eh.appState.RestoreCredential(service); err != nil {
+		eh.statusBar.ShowError(err)
+		return
+	}
+	eh.statusBar.ShowSuccess(fmt.Sprintf("Restored '%s' from trash", service))
+}
+
 // handleTogglePassword toggles password visibility in the detail view.
 func (eh *EventHandler) handleTogglePassword() {
 	if eh.detailView == nil {
@@ -331,6 +385,58 @@ func (eh *EventHandler) handleCopyTOTP() {
 	}
 }
 
+// handleCopyCustomField copies a custom field of the selected credential to clipboard.
+// Copies directly when there is a single field, otherwise shows a picker.
+func (eh *EventHandler) handleCopyCustomField() {
+	if eh.detailView == nil {
+		return
+	}
+
+	cred := eh.appState.GetSelectedCredential()
+	if cred == nil {
+		eh.statusBar.ShowError(fmt.Errorf("no credential selected"))
+		return
+	}
+	if len(cred.CustomFieldNames) == 0 {
+		eh.statusBar.ShowError(fmt.Errorf("no custom fields for %s", cred.Service))
+		return
+	}
+
+	copyField := func(name string) {
+		if err := eh.detailView.CopyCustomFieldToClipboard(name); err != nil {
+			eh.statusBar.ShowError(err)
+		} else {
+			eh.statusBar.ShowSuccess(fmt.Sprintf("%s copied to clipboard!", name))
+		}
+	}
+
+	if len(cred.CustomFieldNames) == 1 {
+		copyField(cred.CustomFieldNames[0])
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	for _, name := range cred.CustomFieldNames {
+		fieldName := name
+		list.AddItem(fieldName, "", 0, func() {
+			eh.pageManager.CloseModal("field-picker")
+			copyField(fieldName)
+		})
+	}
+	list.SetBorder(true).
+		SetTitle(" Copy Field (Enter to copy, Esc to cancel) ").
+		SetTitleAlign(tview.AlignLeft)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			eh.pageManager.CloseModal("field-picker")
+			return nil
+		}
+		return event
+	})
+
+	eh.pageManager.ShowModal("field-picker", list, layout.ConfirmDialogWidth, len(cred.CustomFieldNames)+2)
+}
+
 // handleToggleTOTP toggles TOTP code visibility in the detail view.
 func (eh *EventHandler) handleToggleTOTP() {
 	if eh.detailView == nil {
@@ -340,6 +446,15 @@ func (eh *EventHandler) handleToggleTOTP() {
 	eh.detailView.ToggleTOTPVisibility()
 }
 
+// handleToggleHistory toggles password history visibility in the detail view.
+func (eh *EventHandler) handleToggleHistory() {
+	if eh.detailView == nil {
+		return
+	}
+
+	eh.detailView.ToggleHistoryVisibility()
+}
+
 // handleToggleDetailPanel toggles the detail panel visibility through three states.
 // Cycles: Auto (responsive) -> Hide -> Show -> Auto
 // Displays status bar message showing the new state.
@@ -432,6 +547,7 @@ func (eh *EventHandler) handleShowHelp() {
 	addShortcut(getKey("add_credential"), "New credential")
 	addShortcut(getKey("edit_credential"), "Edit credential")
 	addShortcut(getKey("delete_credential"), "Delete credential")
+	addShortcut("z", "Undo delete (while the toast is shown)")
 	addShortcut("p", "Toggle password visibility")
 	row++ // Blank line (just skip row, don't add cells)
 
@@ -443,12 +559,14 @@ func (eh *EventHandler) handleShowHelp() {
 	addShortcut("n", "Copy notes")
 	addShortcut("t", "Copy TOTP code")
 	addShortcut("T", "Toggle TOTP visibility")
+	addShortcut("f", "Copy custom field")
 	row++ // Blank line (just skip row, don't add cells)
 
 	// View section
 	addSection("View")
 	addShortcut(getKey("toggle_detail"), "Toggle detail panel")
 	addShortcut(getKey("toggle_sidebar"), "Toggle sidebar")
+	addShortcut("h", "Toggle password history")
 	addShortcut(getKey("search"), "Search / Filter credentials")
 	row++ // Blank line (just skip row, don't add cells)
 
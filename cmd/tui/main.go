@@ -65,6 +65,11 @@ func Run(vaultPath string) error {
 		}
 	}
 
+	// 4a. Try the identity file (vault shared with this user as a recipient)
+	if !vaultService.IsUnlocked() {
+		_ = vaultService.UnlockWithIdentity()
+	}
+
 	// 5. Prompt for password if not unlocked via keychain (T019 - FR-025)
 	if !vaultService.IsUnlocked() {
 		unlocked := false
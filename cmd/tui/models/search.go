@@ -16,21 +16,46 @@ type SearchState struct {
 }
 
 // MatchesCredential determines if a credential matches the current search query
-// Returns true if: (1) search inactive, (2) query empty, or (3) query substring-matches any field
+// Returns true if: (1) search inactive, (2) query empty, or (3) query substring-matches any field.
+// Words starting with '#' are tag filters: the credential must carry every such tag exactly.
 func (ss *SearchState) MatchesCredential(cred *vault.CredentialMetadata) bool {
 	// If search is inactive or query is empty, all credentials match
 	if !ss.Active || ss.Query == "" {
 		return true
 	}
 
+	// Split "#tag" filters from the free-text part of the query
+	var textWords []string
+	for _, word := range strings.Fields(ss.Query) {
+		if tag, isTag := strings.CutPrefix(word, "#"); isTag && tag != "" {
+			if !cred.HasTag(tag) {
+				return false
+			}
+			continue
+		}
+		textWords = append(textWords, word)
+	}
+
+	if len(textWords) == 0 {
+		return true // Only tag filters, all satisfied
+	}
+
 	// Case-insensitive substring matching
-	query := strings.ToLower(ss.Query)
+	query := strings.ToLower(strings.Join(textWords, " "))
 
-	// Search across Service, Username, URL, Category fields (Notes excluded per spec)
-	return strings.Contains(strings.ToLower(cred.Service), query) ||
+	// Search across Service, Username, URL, Category, Tags fields (Notes excluded per spec)
+	if strings.Contains(strings.ToLower(cred.Service), query) ||
 		strings.Contains(strings.ToLower(cred.Username), query) ||
 		strings.Contains(strings.ToLower(cred.URL), query) ||
-		strings.Contains(strings.ToLower(cred.Category), query)
+		strings.Contains(strings.ToLower(cred.Category), query) {
+		return true
+	}
+	for _, tag := range cred.Tags {
+		if strings.Contains(tag, query) {
+			return true
+		}
+	}
+	return false
 }
 
 // Activate creates InputField and sets Active=true
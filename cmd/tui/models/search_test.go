@@ -220,6 +220,42 @@ func TestMatchesCredential_EmptyQuery(t *testing.T) {
 	}
 }
 
+// TestMatchesCredential_TagFilters verifies '#tag' filters and tag substring matching
+func TestMatchesCredential_TagFilters(t *testing.T) {
+	cred := createTestCredentialMetadata("Stripe", "billing", "finance", "https://stripe.com")
+	cred.Tags = []string{"prod", "work"}
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"Single tag filter", "#prod", true},
+		{"Tag filter is case-insensitive", "#PROD", true},
+		{"All tag filters must match", "#prod #work", true},
+		{"Missing tag excludes", "#prod #staging", false},
+		{"Tag filter is exact", "#pro", false},
+		{"Tag filter plus text", "#work stripe", true},
+		{"Tag filter plus non-matching text", "#work github", false},
+		{"Plain text matches tags", "wor", true},
+		{"Bare hash is text", "#", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := &SearchState{
+				Active: true,
+				Query:  tt.query,
+			}
+
+			got := ss.MatchesCredential(cred)
+			if got != tt.want {
+				t.Errorf("MatchesCredential() = %v, want %v (query=%q)", got, tt.want, tt.query)
+			}
+		})
+	}
+}
+
 // TestMatchesCredential_ZeroMatches verifies behavior when nothing matches
 func TestMatchesCredential_ZeroMatches(t *testing.T) {
 	credentials := []*vault.CredentialMetadata{
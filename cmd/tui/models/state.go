@@ -19,11 +19,18 @@ import (
 type VaultService interface {
 	ListCredentialsWithMetadata() ([]vault.CredentialMetadata, error)
 	AddCredential(service, username string, password []byte, category, url, notes string) error // T020d: []byte password
+	AddRecord(record vault.Credential) error                                                    // Typed records with custom fields and tags
 	UpdateCredential(service string, opts vault.UpdateOpts) error
+	RenameCredential(oldService, newService string) error // Keeps history, usage and TOTP
 	DeleteCredential(service string) error
 	GetCredential(service string, trackUsage bool) (*vault.Credential, error)
-	RecordFieldAccess(service, field string) error   // Track field-specific access
-	GetTOTPCode(service string) (string, int, error) // Generate TOTP code with remaining seconds
+	GetCredentialRaw(service string) (*vault.Credential, error) // {ref:...} values unresolved
+	Referrers(service string) []string                          // Credentials referencing service
+	RecordFieldAccess(service, field string) error              // Track field-specific access
+	GetTOTPCode(service string) (string, int, error)            // Generate TOTP code with remaining seconds
+	GetHistory(service string) ([]vault.Revision, error)        // Previous values, oldest first
+	RestoreFromTrash(serviceOrID string) (string, error)        // Undo a delete
+	TrashRetentionDays() int                                    // 0 when deletes are permanent
 }
 
 // UpdateCredentialOpts mirrors vault.UpdateOpts for AppState layer.
@@ -43,6 +50,10 @@ type UpdateCredentialOpts struct {
 	TOTPPeriod    *int
 	TOTPIssuer    *string
 	ClearTOTP     bool // If true, clears all TOTP fields
+
+	// Custom fields (removals applied before sets)
+	SetCustomFields    []vault.CustomField
+	RemoveCustomFields []string
 }
 
 // AppState holds all application state with thread-safe access.
@@ -57,9 +68,11 @@ type AppState struct {
 	// Credential data
 	credentials []vault.CredentialMetadata
 	categories  []string
+	tags        []string
 
 	// Current selections
 	selectedCategory   string
+	selectedTag        string // Mutually exclusive with selectedCategory
 	selectedCredential *vault.CredentialMetadata
 
 	// UI components (single instances, created once)
@@ -96,6 +109,7 @@ func NewAppState(vaultService VaultService) *AppState {
 		vault:       vaultService,
 		credentials: make([]vault.CredentialMetadata, 0),
 		categories:  make([]string, 0),
+		tags:        make([]string, 0),
 		searchState: NewSearchState(),
 	}
 }
@@ -118,6 +132,16 @@ func (s *AppState) GetCategories() []string {
 	return categories
 }
 
+// GetTags returns a copy of the tags slice (thread-safe read).
+func (s *AppState) GetTags() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := make([]string, len(s.tags))
+	copy(tags, s.tags)
+	return tags
+}
+
 // GetSelectedCredential returns a copy of the selected credential (thread-safe read).
 func (s *AppState) GetSelectedCredential() *vault.CredentialMetadata {
 	s.mu.RLock()
@@ -132,6 +156,13 @@ func (s *AppState) GetSelectedCategory() string {
 	return s.selectedCategory
 }
 
+// GetSelectedTag returns the selected tag (thread-safe read).
+func (s *AppState) GetSelectedTag() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.selectedTag
+}
+
 // FindCredentialByService searches for a credential by service name (thread-safe read).
 // Returns the credential metadata and true if found, nil and false otherwise.
 func (s *AppState) FindCredentialByService(service string) (*vault.CredentialMetadata, bool) {
@@ -162,6 +193,23 @@ func (s *AppState) GetFullCredentialWithTracking(service string, track bool) (*v
 	return s.vault.GetCredential(service, track)
 }
 
+// GetRawCredential retrieves a credential with {ref:...} values unresolved.
+// Used to pre-populate the edit form so saving keeps references intact.
+func (s *AppState) GetRawCredential(service string) (*vault.Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.vault.GetCredentialRaw(service)
+}
+
+// Referrers returns the credentials whose fields reference the given service.
+func (s *AppState) Referrers(service string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.vault.Referrers(service)
+}
+
 // RecordFieldAccess tracks access to a specific credential field.
 // Used to record when fields are actually accessed (e.g., password copied to clipboard).
 func (s *AppState) RecordFieldAccess(service, field string) error {
@@ -180,6 +228,20 @@ func (s *AppState) GetTOTPCode(service string) (string, int, error) {
 	return s.vault.GetTOTPCode(service)
 }
 
+// GetHistory retrieves the revision history for the specified service, oldest first.
+// SECURITY: Revisions contain previous passwords; only call when history is shown.
+func (s *AppState) GetHistory(service string) ([]vault.Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.vault.GetHistory(service)
+}
+
+// TrashRetentionDays returns how long deleted credentials can be restored (0 when deletes are permanent).
+func (s *AppState) TrashRetentionDays() int {
+	return s.vault.TrashRetentionDays()
+}
+
 // LoadCredentials loads all credentials from the vault.
 // CRITICAL: Follows Lock→Mutate→Unlock→Notify pattern to prevent deadlocks.
 func (s *AppState) LoadCredentials() error {
@@ -197,6 +259,7 @@ func (s *AppState) LoadCredentials() error {
 	// Update state
 	s.credentials = creds
 	s.updateCategories() // Internal helper, safe to call while locked
+	s.updateTags()
 
 	s.mu.Unlock()                // ✅ RELEASE LOCK
 	s.notifyCredentialsChanged() // ✅ THEN notify
@@ -233,6 +296,41 @@ func (s *AppState) AddCredential(service, username, password, category, url, not
 	s.mu.Lock()
 	s.credentials = creds
 	s.updateCategories() // Update categories while locked
+	s.updateTags()
+	s.mu.Unlock()
+
+	// Notify after releasing lock
+	s.notifyCredentialsChanged()
+
+	return nil
+}
+
+// AddRecord adds a credential of any record type, including its custom fields and tags.
+// CRITICAL: Minimizes lock duration by releasing lock during vault I/O operations.
+func (s *AppState) AddRecord(record vault.Credential) error {
+	// Perform vault I/O without holding lock (vault has its own synchronization)
+	err := s.vault.AddRecord(record)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to add credential: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	s.MarkWriteOperation()
+
+	// Reload credentials without holding lock
+	creds, err := s.vault.ListCredentialsWithMetadata()
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to reload credentials: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	// Only lock to update state
+	s.mu.Lock()
+	s.credentials = creds
+	s.updateCategories() // Update categories while locked
+	s.updateTags()
 	s.mu.Unlock()
 
 	// Notify after releasing lock
@@ -258,6 +356,9 @@ func (s *AppState) UpdateCredential(service string, opts UpdateCredentialOpts) e
 		TOTPPeriod:    opts.TOTPPeriod,
 		TOTPIssuer:    opts.TOTPIssuer,
 		ClearTOTP:     opts.ClearTOTP,
+
+		SetCustomFields:    opts.SetCustomFields,
+		RemoveCustomFields: opts.RemoveCustomFields,
 	}
 
 	// Perform vault I/O without holding lock (vault has its own synchronization)
@@ -282,6 +383,49 @@ func (s *AppState) UpdateCredential(service string, opts UpdateCredentialOpts) e
 	s.mu.Lock()
 	s.credentials = creds
 	s.updateCategories() // Update categories while locked
+	s.updateTags()
+	s.mu.Unlock()
+
+	// Notify after releasing lock
+	s.notifyCredentialsChanged()
+
+	return nil
+}
+
+// RenameCredential changes a credential's service name.
+// The selection follows the credential so it stays usable after the rename.
+// CRITICAL: Minimizes lock duration by releasing lock during vault I/O operations.
+func (s *AppState) RenameCredential(oldService, newService string) error {
+	// Perform vault I/O without holding lock (vault has its own synchronization)
+	if err := s.vault.RenameCredential(oldService, newService); err != nil {
+		wrappedErr := fmt.Errorf("failed to rename credential: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	s.MarkWriteOperation()
+
+	// Reload credentials without holding lock
+	creds, err := s.vault.ListCredentialsWithMetadata()
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to reload credentials: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	// Only lock to update state
+	s.mu.Lock()
+	s.credentials = creds
+	s.updateCategories() // Update categories while locked
+	s.updateTags()
+	if s.selectedCredential != nil && s.selectedCredential.Service == oldService {
+		for i := range s.credentials {
+			if s.credentials[i].Service == newService {
+				s.selectedCredential = &s.credentials[i]
+				break
+			}
+		}
+	}
 	s.mu.Unlock()
 
 	// Notify after releasing lock
@@ -315,6 +459,40 @@ func (s *AppState) DeleteCredential(service string) error {
 	s.mu.Lock()
 	s.credentials = creds
 	s.updateCategories() // Update categories while locked
+	s.updateTags()
+	s.mu.Unlock()
+
+	// Notify after releasing lock
+	s.notifyCredentialsChanged()
+
+	return nil
+}
+
+// RestoreCredential moves a deleted credential back from the trash.
+// CRITICAL: Minimizes lock duration by releasing lock during vault I/O operations.
+func (s *AppState) RestoreCredential(service string) error {
+	// Perform vault I/O without holding lock (vault has its own synchronization)
+	if _, err := s.vault.RestoreFromTrash(service); err != nil {
+		wrappedErr := fmt.Errorf("failed to restore credential: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	s.MarkWriteOperation()
+
+	// Reload credentials without holding lock
+	creds, err := s.vault.ListCredentialsWithMetadata()
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to reload credentials: %w", err)
+		s.notifyError(wrappedErr)
+		return wrappedErr
+	}
+
+	// Only lock to update state
+	s.mu.Lock()
+	s.credentials = creds
+	s.updateCategories() // Update categories while locked
+	s.updateTags()
 	s.mu.Unlock()
 
 	// Notify after releasing lock
@@ -366,12 +544,25 @@ func (s *AppState) SetSelectedCredential(credential *vault.CredentialMetadata) {
 func (s *AppState) SetSelection(category string, credential *vault.CredentialMetadata) {
 	s.mu.Lock()
 	s.selectedCategory = category
+	s.selectedTag = "" // Category and tag filters are mutually exclusive
 	s.selectedCredential = credential
 	s.mu.Unlock() // ✅ RELEASE LOCK
 
 	s.notifySelectionChanged() // ✅ THEN notify (single notification)
 }
 
+// SetTagSelection filters by tag, clearing the category filter and credential selection.
+// CRITICAL: Follows Lock→Mutate→Unlock→Notify pattern.
+func (s *AppState) SetTagSelection(tag string) {
+	s.mu.Lock()
+	s.selectedTag = tag
+	s.selectedCategory = ""
+	s.selectedCredential = nil
+	s.mu.Unlock() // ✅ RELEASE LOCK
+
+	s.notifySelectionChanged() // ✅ THEN notify (single notification)
+}
+
 // SetSidebar stores the sidebar component reference.
 func (s *AppState) SetSidebar(sidebar *tview.TreeView) {
 	s.mu.Lock()
@@ -553,19 +744,24 @@ func (s *AppState) HasWriteOperations() bool {
 }
 
 // updateCategories extracts unique categories from credentials.
+// Folder paths contribute their parent folders too, so "Clients/Acme" adds "Clients".
 // CRITICAL: Must be called while holding a write lock.
 func (s *AppState) updateCategories() {
 	categoryMap := make(map[string]bool)
 
+	folders := make([]string, 0, len(s.credentials))
 	for _, cred := range s.credentials {
 		// Extract category from credential's Category field
 		if cred.Category != "" {
-			categoryMap[cred.Category] = true
+			folders = append(folders, cred.Category)
 		} else {
 			// Empty category becomes "Uncategorized"
 			categoryMap["Uncategorized"] = true
 		}
 	}
+	for _, folder := range vault.FolderPaths(folders) {
+		categoryMap[folder] = true
+	}
 
 	// Convert map to sorted slice
 	categories := make([]string, 0, len(categoryMap))
@@ -576,3 +772,23 @@ func (s *AppState) updateCategories() {
 
 	s.categories = categories
 }
+
+// updateTags extracts unique tags from credentials.
+// CRITICAL: Must be called while holding a write lock.
+func (s *AppState) updateTags() {
+	tagMap := make(map[string]bool)
+
+	for _, cred := range s.credentials {
+		for _, tag := range cred.Tags {
+			tagMap[tag] = true
+		}
+	}
+
+	tags := make([]string, 0, len(tagMap))
+	for tag := range tagMap {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	s.tags = tags
+}
@@ -18,6 +18,7 @@ type MockVaultService struct {
 
 	// Mock data
 	credentials []vault.CredentialMetadata
+	trash       []vault.CredentialMetadata
 
 	// Mock behaviors
 	listError   error
@@ -79,6 +80,30 @@ func (m *MockVaultService) AddCredential(service, username string, password []by
 	return nil
 }
 
+// AddRecord adds a mock typed record.
+func (m *MockVaultService) AddRecord(record vault.Credential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.addCalled++
+	if m.addError != nil {
+		return m.addError
+	}
+
+	m.credentials = append(m.credentials, vault.CredentialMetadata{
+		Service:   record.Service,
+		Type:      record.RecordType(),
+		Username:  record.Username,
+		Category:  record.Category,
+		URL:       record.URL,
+		Notes:     record.Notes,
+		Tags:      record.Tags,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+	return nil
+}
+
 // UpdateCredential updates a mock credential.
 func (m *MockVaultService) UpdateCredential(service string, opts vault.UpdateOpts) error {
 	m.mu.Lock()
@@ -121,9 +146,10 @@ func (m *MockVaultService) DeleteCredential(service string) error {
 		return m.deleteError
 	}
 
-	// Find and remove credential
+	// Find and move credential to the trash
 	for i, cred := range m.credentials {
 		if cred.Service == service {
+			m.trash = append(m.trash, cred)
 			m.credentials = append(m.credentials[:i], m.credentials[i+1:]...)
 			return nil
 		}
@@ -159,6 +185,14 @@ func (m *MockVaultService) GetCredential(service string, trackUsage bool) (*vaul
 	return nil, errors.New("credential not found")
 }
 
+func (m *MockVaultService) GetCredentialRaw(service string) (*vault.Credential, error) {
+	return m.GetCredential(service, false)
+}
+
+func (m *MockVaultService) Referrers(service string) []string {
+	return nil
+}
+
 func (m *MockVaultService) RecordFieldAccess(service, field string) error {
 	return nil
 }
@@ -167,6 +201,48 @@ func (m *MockVaultService) GetTOTPCode(service string) (string, int, error) {
 	return "", 0, errors.New("TOTP not configured")
 }
 
+func (m *MockVaultService) GetHistory(service string) ([]vault.Revision, error) {
+	return nil, nil
+}
+
+// RenameCredential renames a mock credential.
+func (m *MockVaultService) RenameCredential(oldService, newService string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, cred := range m.credentials {
+		if cred.Service == newService {
+			return vault.ErrCredentialExists
+		}
+	}
+	for i, cred := range m.credentials {
+		if cred.Service == oldService {
+			m.credentials[i].Service = newService
+			return nil
+		}
+	}
+	return errors.New("credential not found")
+}
+
+// RestoreFromTrash moves a deleted mock credential back.
+func (m *MockVaultService) RestoreFromTrash(serviceOrID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, cred := range m.trash {
+		if cred.Service == serviceOrID {
+			m.credentials = append(m.credentials, cred)
+			m.trash = append(m.trash[:i], m.trash[i+1:]...)
+			return cred.Service, nil
+		}
+	}
+	return "", vault.ErrNotInTrash
+}
+
+func (m *MockVaultService) TrashRetentionDays() int {
+	return vault.DefaultTrashRetentionDays
+}
+
 // SetCredentials sets the mock credentials for testing.
 func (m *MockVaultService) SetCredentials(creds []vault.CredentialMetadata) {
 	m.mu.Lock()
@@ -465,6 +541,73 @@ func TestDeleteCredential(t *testing.T) {
 	}
 }
 
+// TestRestoreCredential verifies undoing a delete from the trash.
+func TestRestoreCredential(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := NewAppState(mockVault)
+
+	mockVault.SetCredentials([]vault.CredentialMetadata{
+		{Service: "AWS", Username: "admin", CreatedAt: time.Now()},
+	})
+	_ = state.LoadCredentials()
+
+	if err := state.DeleteCredential("AWS"); err != nil {
+		t.Fatalf("DeleteCredential failed: %v", err)
+	}
+	if len(state.GetCredentials()) != 0 {
+		t.Fatalf("Expected no credentials after delete, got %d", len(state.GetCredentials()))
+	}
+
+	callbackInvoked := false
+	state.SetOnCredentialsChanged(func() {
+		callbackInvoked = true
+	})
+
+	if err := state.RestoreCredential("AWS"); err != nil {
+		t.Fatalf("RestoreCredential failed: %v", err)
+	}
+	if !callbackInvoked {
+		t.Error("onCredentialsChanged callback was not invoked")
+	}
+	creds := state.GetCredentials()
+	if len(creds) != 1 || creds[0].Service != "AWS" {
+		t.Errorf("Expected AWS to be restored, got %+v", creds)
+	}
+
+	// Restoring again fails: nothing left in the trash
+	if err := state.RestoreCredential("AWS"); err == nil {
+		t.Error("Expected error restoring a credential that is not in the trash")
+	}
+}
+
+func TestRenameCredential(t *testing.T) {
+	mockVault := NewMockVaultService()
+	state := NewAppState(mockVault)
+
+	mockVault.SetCredentials([]vault.CredentialMetadata{
+		{Service: "AWS", Username: "admin", CreatedAt: time.Now()},
+		{Service: "GitHub", Username: "user", CreatedAt: time.Now()},
+	})
+	_ = state.LoadCredentials()
+
+	selected, _ := state.FindCredentialByService("AWS")
+	state.SetSelectedCredential(selected)
+
+	if err := state.RenameCredential("AWS", "AWS-Prod"); err != nil {
+		t.Fatalf("RenameCredential failed: %v", err)
+	}
+	if _, found := state.FindCredentialByService("AWS-Prod"); !found {
+		t.Error("Expected renamed credential in state")
+	}
+	if cred := state.GetSelectedCredential(); cred == nil || cred.Service != "AWS-Prod" {
+		t.Errorf("Expected selection to follow the rename, got %+v", cred)
+	}
+
+	if err := state.RenameCredential("AWS-Prod", "GitHub"); err == nil {
+		t.Error("Expected error renaming onto an existing credential")
+	}
+}
+
 // TestCallbackInvocation_AfterUnlock is the CRITICAL deadlock prevention test.
 // It verifies that callbacks are invoked AFTER releasing locks.
 func TestCallbackInvocation_AfterUnlock(t *testing.T) {
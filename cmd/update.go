@@ -26,8 +26,21 @@ var (
 	clearNotes             bool
 	updateGeneratePassword bool
 	updateGenLength        int
-	updateTOTPURI          string // TOTP otpauth:// URI
-	clearTOTP              bool   // Clear TOTP configuration
+	updateTOTPURI          string   // TOTP otpauth:// URI
+	clearTOTP              bool     // Clear TOTP configuration
+	updateFields           []string // Custom fields to add or replace (name=value)
+	updateHiddenFields     []string // Hidden custom fields to add or replace (name=value)
+	removeFields           []string // Custom field names to remove
+	updateFieldFiles       []string // Hidden custom fields read from files (name=path)
+	updateTags             []string // Tags to add
+	removeTags             []string // Tags to remove
+	clearTags              bool     // Remove all tags
+	updateExpires          string   // Expiry date or duration from now
+	clearExpiry            bool     // Remove the expiry date
+	updateRotateEvery      string   // Rotation interval (0 disables)
+	updateMatchURLs        []string // Additional URLs to add
+	removeURLs             []string // Additional URLs to remove
+	clearURLs              bool     // Remove all additional URLs
 )
 
 var updateCmd = &cobra.Command{
@@ -48,6 +61,21 @@ password will be copied to clipboard automatically.
 Use --totp-uri to add or update TOTP/2FA configuration for the credential.
 Use --clear-totp to remove TOTP configuration.
 
+Use --field name=value (or --hidden-field for secrets) to add or replace a custom field,
+and --remove-field name to delete one. All three flags can be repeated.
+--field-file name=path stores a file's contents as a hidden field (e.g. a rotated SSH key).
+Type-specific fields keep the visibility their record type defines.
+
+Use --tag to add tags, --remove-tag to remove them, or --clear-tags to remove all.
+
+Use --match-url to add URLs for 'pass-cli find --url' (prefix with host:, prefix:
+or regex: to change how they match), --remove-url to remove them, or --clear-urls
+to remove all. The --url value itself is always matched by domain.
+
+Use --expires to set an expiry date (YYYY-MM-DD or a duration such as 90d) and
+--clear-expiry to remove it. --rotate-every sets a rotation reminder interval
+(0 disables it); the interval restarts whenever the password or a hidden field changes.
+
 By default, you'll see a usage warning if the credential has been accessed before,
 showing where and when it was last used. Use --force to skip the confirmation.`,
 	Example: `  # Update password only (interactive prompt)
@@ -92,6 +120,21 @@ showing where and when it was last used. Use --force to skip the confirmation.`,
   # Remove TOTP/2FA configuration
   pass-cli update github --clear-totp
 
+  # Add or replace custom fields
+  pass-cli update aws --field region=us-east-1 --hidden-field secret-key=wJalrXUtn
+
+  # Remove a custom field
+  pass-cli update aws --remove-field region
+
+  # Add and remove tags
+  pass-cli update aws --tag prod,billing --remove-tag staging
+
+  # Match an extra URL prefix, drop an old domain
+  pass-cli update gitlab --match-url prefix:https://git.example.com/acme/ --remove-url gitlab.old.com
+
+  # Set an expiry date and a rotation reminder
+  pass-cli update ci-token --expires 2026-12-31 --rotate-every 90d
+
   # Skip confirmation
   pass-cli update github --force`,
 	Args: cobra.ExactArgs(1),
@@ -105,19 +148,36 @@ func init() {
 	updateCmd.Flags().BoolVarP(&updateGeneratePassword, "generate", "g", false, "auto-generate a new secure password")
 	updateCmd.Flags().IntVar(&updateGenLength, "gen-length", 20, "length of generated password (default: 20)")
 	updateCmd.Flags().StringVar(&updateNotes, "notes", "", "new notes")
-	updateCmd.Flags().StringVar(&updateCategory, "category", "", "new category")
+	updateCmd.Flags().StringVar(&updateCategory, "category", "", "new category or folder path (e.g., 'Clients/Acme/Prod')")
 	updateCmd.Flags().StringVar(&updateURL, "url", "", "new URL")
 	updateCmd.Flags().BoolVar(&clearCategory, "clear-category", false, "clear category field to empty")
 	updateCmd.Flags().BoolVar(&clearURL, "clear-url", false, "clear URL field to empty")
 	updateCmd.Flags().BoolVar(&clearNotes, "clear-notes", false, "clear notes field to empty")
 	updateCmd.Flags().StringVar(&updateTOTPURI, "totp-uri", "", "TOTP/2FA otpauth:// URI to add or update")
 	updateCmd.Flags().BoolVar(&clearTOTP, "clear-totp", false, "remove TOTP/2FA configuration")
+	updateCmd.Flags().StringArrayVar(&updateFields, "field", nil, "add or replace a custom field as name=value (repeatable)")
+	updateCmd.Flags().StringArrayVar(&updateHiddenFields, "hidden-field", nil, "add or replace a hidden custom field as name=value (repeatable)")
+	updateCmd.Flags().StringArrayVar(&removeFields, "remove-field", nil, "remove a custom field by name (repeatable)")
+	updateCmd.Flags().StringArrayVar(&updateFieldFiles, "field-file", nil, "add or replace a hidden custom field read from a file as name=path (repeatable)")
+	updateCmd.Flags().StringSliceVar(&updateTags, "tag", nil, "add a tag (repeatable or comma-separated)")
+	updateCmd.Flags().StringSliceVar(&removeTags, "remove-tag", nil, "remove a tag (repeatable or comma-separated)")
+	updateCmd.Flags().BoolVar(&clearTags, "clear-tags", false, "remove all tags")
+	updateCmd.Flags().StringArrayVar(&updateMatchURLs, "match-url", nil, "add a URL, optionally prefixed with host:, prefix: or regex: (repeatable)")
+	updateCmd.Flags().StringArrayVar(&removeURLs, "remove-url", nil, "remove a URL added with --match-url (repeatable)")
+	updateCmd.Flags().BoolVar(&clearURLs, "clear-urls", false, "remove all URLs added with --match-url")
+	updateCmd.Flags().StringVar(&updateExpires, "expires", "", "expiry date (YYYY-MM-DD) or duration from now (e.g. 90d)")
+	updateCmd.Flags().BoolVar(&clearExpiry, "clear-expiry", false, "remove the expiry date")
+	updateCmd.Flags().StringVar(&updateRotateEvery, "rotate-every", "", "rotation reminder interval (e.g. 90d, 12w, 1y; 0 disables)")
 	updateCmd.Flags().BoolVar(&updateForce, "force", false, "skip confirmation prompt")
 
 	// Mark --password and --generate as mutually exclusive
 	updateCmd.MarkFlagsMutuallyExclusive("password", "generate")
 	// Mark --totp-uri and --clear-totp as mutually exclusive
 	updateCmd.MarkFlagsMutuallyExclusive("totp-uri", "clear-totp")
+	// Mark --remove-tag and --clear-tags as mutually exclusive
+	updateCmd.MarkFlagsMutuallyExclusive("remove-tag", "clear-tags")
+	updateCmd.MarkFlagsMutuallyExclusive("expires", "clear-expiry")
+	updateCmd.MarkFlagsMutuallyExclusive("remove-url", "clear-urls")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
@@ -126,6 +186,31 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("service name cannot be empty")
 	}
 
+	// Parse custom fields up front so bad input fails before unlocking
+	customFields, err := parseCustomFieldFlags(updateFields, updateHiddenFields)
+	if err != nil {
+		return err
+	}
+	fileFields, err := readCustomFieldFiles(updateFieldFiles)
+	if err != nil {
+		return err
+	}
+	customFields = append(customFields, fileFields...)
+	tags, err := normalizeTagFlags(updateTags)
+	if err != nil {
+		return err
+	}
+	urls, err := parseURLRuleFlags(updateMatchURLs)
+	if err != nil {
+		return err
+	}
+	expiresAt, rotationDays, err := parseExpiryFlags(updateExpires, updateRotateEvery)
+	if err != nil {
+		return err
+	}
+	hasFieldChanges := len(customFields) > 0 || len(removeFields) > 0 || len(tags) > 0 || len(removeTags) > 0 || clearTags ||
+		len(urls) > 0 || len(removeURLs) > 0 || clearURLs || expiresAt != nil || clearExpiry || rotationDays != nil
+
 	vaultPath := GetVaultPath()
 
 	// Check if vault exists
@@ -148,11 +233,15 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 	defer vaultService.Lock()
 
-	// Check if credential exists
-	cred, err := vaultService.GetCredential(service, false)
+	// Check if credential exists (prompts show stored values, references unresolved)
+	cred, err := vaultService.GetCredentialRaw(service)
 	if err != nil {
 		return fmt.Errorf("failed to get credential: %w", err)
 	}
+	schema, _ := vault.GetRecordSchema(cred.RecordType())
+	if schema.PasswordLabel == "" && (updatePassword != "" || updateGeneratePassword) {
+		return fmt.Errorf("%s records have no password; store secrets with --field or --hidden-field", cred.RecordType())
+	}
 
 	// Handle password generation
 	if updateGeneratePassword {
@@ -172,28 +261,31 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	// If no flags provided (including clear flags), prompt for what to update
 	if updateUsername == "" && updatePassword == "" && updateNotes == "" && updateCategory == "" && updateURL == "" &&
-		updateTOTPURI == "" && !clearCategory && !clearURL && !clearNotes && !clearTOTP && !updateGeneratePassword {
+		updateTOTPURI == "" && !clearCategory && !clearURL && !clearNotes && !clearTOTP && !updateGeneratePassword && !hasFieldChanges {
 		fmt.Println("What would you like to update? (leave empty to keep current value)")
 		fmt.Println()
 
 		reader := bufio.NewReader(os.Stdin)
 
-		// Prompt for username
-		fmt.Printf("Username [%s]: ", cred.Username)
-		username, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("failed to read username: %w", err)
+		// Prompt for username and password when the record type has them
+		if schema.UsernameLabel != "" {
+			fmt.Printf("%s [%s]: ", schema.UsernameLabel, cred.Username)
+			username, err := reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to read username: %w", err)
+			}
+			updateUsername = strings.TrimSpace(username)
 		}
-		updateUsername = strings.TrimSpace(username)
 
-		// Prompt for password
-		fmt.Print("Password (hidden): ")
-		password, err := readPassword()
-		if err != nil {
-			return fmt.Errorf("failed to read password: %w", err)
+		if schema.PasswordLabel != "" {
+			fmt.Printf("%s (hidden): ", schema.PasswordLabel)
+			password, err := readPassword()
+			if err != nil {
+				return fmt.Errorf("failed to read password: %w", err)
+			}
+			fmt.Println()
+			updatePassword = string(password) // TODO: Remove string conversion in Phase 3 (T020d)
 		}
-		fmt.Println()
-		updatePassword = string(password) // TODO: Remove string conversion in Phase 3 (T020d)
 
 		// Prompt for category
 		fmt.Printf("Category [%s]: ", cred.Category)
@@ -222,7 +314,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	// Check if anything is being updated
 	if updateUsername == "" && updatePassword == "" && updateNotes == "" && updateCategory == "" && updateURL == "" &&
-		updateTOTPURI == "" && !clearCategory && !clearURL && !clearNotes && !clearTOTP && !updateGeneratePassword {
+		updateTOTPURI == "" && !clearCategory && !clearURL && !clearNotes && !clearTOTP && !updateGeneratePassword && !hasFieldChanges {
 		fmt.Println("No changes specified.")
 		return nil
 	}
@@ -309,6 +401,31 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Custom fields: removals are applied before additions by the vault
+	opts.SetCustomFields = customFields
+	opts.RemoveCustomFields = removeFields
+
+	// Tags: clear flag removes every current tag before additions
+	opts.AddTags = tags
+	opts.RemoveTags = removeTags
+	if clearTags {
+		opts.RemoveTags = cred.Tags
+	}
+
+	// Additional URLs: same pattern as tags
+	opts.AddURLs = urls
+	opts.RemoveURLs = removeURLs
+	if clearURLs {
+		opts.RemoveURLs = nil
+		for _, rule := range cred.URLs {
+			opts.RemoveURLs = append(opts.RemoveURLs, rule.URL)
+		}
+	}
+
+	opts.ExpiresAt = expiresAt
+	opts.ClearExpiry = clearExpiry
+	opts.RotationDays = rotationDays
+
 	if err := vaultService.UpdateCredential(service, opts); err != nil {
 		return fmt.Errorf("failed to update credential: %w", err)
 	}
@@ -343,6 +460,43 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	} else if updateTOTPURI != "" {
 		fmt.Printf("🔐 TOTP configured\n")
 	}
+	for _, name := range removeFields {
+		fmt.Printf("🔹 Field removed: %s\n", name)
+	}
+	for _, field := range customFields {
+		if schemaField, ok := schema.Field(field.Name); ok {
+			field.Hidden = schemaField.Hidden
+		}
+		fmt.Printf("🔹 Field set: %s = %s\n", field.Name, displayCustomFieldValue(field, true))
+	}
+	if clearTags {
+		fmt.Printf("🔖 Tags cleared\n")
+	} else if len(removeTags) > 0 {
+		fmt.Printf("🔖 Tags removed: %s\n", strings.Join(removeTags, ", "))
+	}
+	if len(tags) > 0 {
+		fmt.Printf("🔖 Tags added: %s\n", strings.Join(tags, ", "))
+	}
+	if clearURLs {
+		fmt.Printf("🔗 Match URLs cleared\n")
+	} else if len(removeURLs) > 0 {
+		fmt.Printf("🔗 Match URLs removed: %s\n", strings.Join(removeURLs, ", "))
+	}
+	for _, rule := range urls {
+		fmt.Printf("🔗 Match URL added: %s\n", rule)
+	}
+	if clearExpiry {
+		fmt.Printf("⏳ Expiry cleared\n")
+	} else if expiresAt != nil {
+		fmt.Printf("⏳ Expires: %s\n", formatExpiry(*expiresAt))
+	}
+	if rotationDays != nil {
+		if *rotationDays == 0 {
+			fmt.Printf("🔄 Rotation reminder disabled\n")
+		} else {
+			fmt.Printf("🔄 Rotate every: %d days\n", *rotationDays)
+		}
+	}
 
 	syncPushAfterCommand(vaultService)
 	return nil
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+)
+
+var (
+	vaultAddSyncRemote string
+	vaultAddNoKeychain bool
+	vaultAddUse        bool
+)
+
+var vaultAddCmd = &cobra.Command{
+	Use:   "add <name> <path>",
+	Short: "Register a named vault",
+	Long: `Add registers a vault under a name in the vault registry of your config file.
+Each vault has its own path, sync remote and keychain setting; select one per
+command with --vault <name>, or make it the default with 'pass-cli vault use'.
+
+Registering does not create the vault file. For a new vault, run
+'pass-cli --vault <name> init' afterwards.
+
+Names may contain lowercase letters, digits, '-' and '_'.`,
+	Example: `  # Register the existing vault
+  pass-cli vault add personal ~/.pass-cli/vault.enc
+
+  # Add a team vault that syncs and always asks for the master password
+  pass-cli vault add team ~/team-vault/vault.enc --sync-remote gdrive:team-vault --no-keychain
+  pass-cli --vault team init
+
+  # Register and make it the default
+  pass-cli vault add work ~/work/vault.enc --use`,
+	Args: cobra.ExactArgs(2),
+	RunE: runVaultAdd,
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultAddCmd)
+	vaultAddCmd.Flags().StringVar(&vaultAddSyncRemote, "sync-remote", "", "rclone remote to sync this vault with (e.g., gdrive:team-vault)")
+	vaultAddCmd.Flags().BoolVar(&vaultAddNoKeychain, "no-keychain", false, "never unlock this vault from the OS keychain")
+	vaultAddCmd.Flags().BoolVar(&vaultAddUse, "use", false, "make this the default vault")
+}
+
+func runVaultAdd(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	path := strings.TrimSpace(args[1])
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("vault path cannot be empty")
+	}
+	if vaultAddSyncRemote != "" && !strings.Contains(vaultAddSyncRemote, ":") {
+		return fmt.Errorf("invalid remote format: %s\n\nRemote should be in format: <remote-name>:<path>", vaultAddSyncRemote)
+	}
+
+	// --vault only affects this command, so check the registry itself
+	config.SelectVault("")
+	cfg, result := config.Load()
+	if !result.Valid {
+		return fmt.Errorf("invalid configuration: %s: %s", result.Errors[0].Field, result.Errors[0].Message)
+	}
+	if _, exists := cfg.Vaults[name]; exists {
+		return fmt.Errorf("vault '%s' already exists (edit it with 'pass-cli config edit')", name)
+	}
+
+	if err := updateConfigFile(func(configMap map[string]interface{}) error {
+		section := profileConfigSection(configMap, name)
+		section["path"] = path
+		if vaultAddSyncRemote != "" {
+			section["sync"] = map[string]interface{}{
+				"enabled": true,
+				"remote":  vaultAddSyncRemote,
+			}
+		}
+		if vaultAddNoKeychain {
+			section["keychain"] = false
+		}
+		if vaultAddUse {
+			configMap["default_vault"] = name
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Vault '%s' added\n", name)
+	fmt.Printf("📁 %s\n", path)
+	if vaultAddUse {
+		fmt.Printf("⭐ Now the default vault\n")
+	}
+	if !pathExists(expandVaultPath(path)) {
+		fmt.Printf("\nThe vault file does not exist yet. Create it with: pass-cli --vault %s init\n", name)
+	}
+	return nil
+}
@@ -118,7 +118,7 @@ func runVaultBackupPreview(cmd *cobra.Command, args []string) error {
 		// Verbose: show table with more details
 		var builder strings.Builder
 		table := tablewriter.NewWriter(&builder)
-		table.Header([]string{"#", "Service", "Username", "Category", "Created"})
+		table.Header([]string{"#", "Service", "Username", "Category", "Created", "Attachments"})
 
 		for i, service := range services {
 			cred := vaultData.Credentials[service]
@@ -127,12 +127,17 @@ func runVaultBackupPreview(cmd *cobra.Command, args []string) error {
 				category = "-"
 			}
 			created := cred.CreatedAt.Format("2006-01-02")
+			attachments := "-"
+			if len(cred.Attachments) > 0 {
+				attachments = fmt.Sprintf("%d", len(cred.Attachments))
+			}
 			_ = table.Append([]string{
 				fmt.Sprintf("%d", i+1),
 				service,
 				cred.Username,
 				category,
 				created,
+				attachments,
 			})
 		}
 
@@ -145,6 +150,31 @@ func runVaultBackupPreview(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Sidecar blobs are stored next to the backup; report any that are missing
+	attachmentCount, sidecarCount := 0, 0
+	var missing []string
+	for _, service := range services {
+		for _, attachment := range vaultData.Credentials[service].Attachments {
+			attachmentCount++
+			if !attachment.Sidecar {
+				continue
+			}
+			sidecarCount++
+			if !storageSvc.HasAttachmentBlob(attachment.ID) {
+				missing = append(missing, fmt.Sprintf("%s/%s", service, attachment.Name))
+			}
+		}
+	}
+	if attachmentCount > 0 {
+		fmt.Printf("\nAttachments: %d (%d stored as sidecar blobs)\n", attachmentCount, sidecarCount)
+		if len(missing) > 0 {
+			fmt.Printf("⚠️  Missing attachment blobs (these files cannot be restored):\n")
+			for _, name := range missing {
+				fmt.Printf("  - %s\n", name)
+			}
+		}
+	}
+
 	fmt.Printf("\nBackup file: %s\n", previewFile)
 	fmt.Printf("Modified: %s\n", info.ModTime().Format("2006-01-02 15:04:05"))
 
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+)
+
+var vaultListFormat string
+
+var vaultListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the vaults in the vault registry",
+	Long: `List shows the named vaults registered in your config file, with their path,
+sync remote and keychain setting. The vault used by commands is marked with '*':
+the one chosen with --vault, otherwise the default set with 'pass-cli vault use'.`,
+	Example: `  # List registered vaults
+  pass-cli vault list
+
+  # JSON output for scripting
+  pass-cli vault list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runVaultList,
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultListCmd)
+	vaultListCmd.Flags().StringVar(&vaultListFormat, "format", "table", "output format: table, json")
+}
+
+// vaultEntry is the JSON representation of a registered vault
+type vaultEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Exists   bool   `json:"exists"`
+	Sync     string `json:"sync,omitempty"` // Remote, when sync is enabled
+	Keychain bool   `json:"keychain"`
+	Default  bool   `json:"default"`
+	Active   bool   `json:"active"`
+}
+
+func runVaultList(cmd *cobra.Command, args []string) error {
+	if vaultListFormat != "table" && vaultListFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", vaultListFormat)
+	}
+
+	cfg, result := config.Load()
+	if !result.Valid {
+		return fmt.Errorf("invalid configuration: %s: %s", result.Errors[0].Field, result.Errors[0].Message)
+	}
+
+	entries := make([]vaultEntry, 0, len(cfg.Vaults))
+	for _, name := range cfg.ProfileNames() {
+		profile := cfg.Vaults[name]
+		entry := vaultEntry{
+			Name:     name,
+			Path:     profile.Path,
+			Exists:   pathExists(expandVaultPath(profile.Path)),
+			Keychain: profile.KeychainEnabled(),
+			Default:  name == cfg.DefaultVault,
+			Active:   name == cfg.ActiveVault,
+		}
+		if profile.Sync.Enabled {
+			entry.Sync = profile.Sync.Remote
+		}
+		entries = append(entries, entry)
+	}
+
+	if vaultListFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No vaults registered. Add one with: pass-cli vault add <name> <path>")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"", "Name", "Path", "Sync", "Keychain"})
+	data := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		marker := ""
+		if entry.Active {
+			marker = "*"
+		}
+		name := entry.Name
+		if entry.Default {
+			name += " (default)"
+		}
+		path := entry.Path
+		if !entry.Exists {
+			path += " (not created)"
+		}
+		sync := "off"
+		if entry.Sync != "" {
+			sync = entry.Sync
+		}
+		keychain := "on"
+		if !entry.Keychain {
+			keychain = "off"
+		}
+		data = append(data, []string{marker, name, path, sync, keychain})
+	}
+	_ = table.Bulk(data)
+	_ = table.Render()
+
+	if cfg.ActiveVault == "" {
+		fmt.Println("\nNo vault selected: commands use vault_path (or the default location).")
+		fmt.Println("Pick one with: pass-cli vault use <name>")
+	}
+	return nil
+}
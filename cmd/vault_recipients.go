@@ -0,0 +1,25 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// vaultRecipientsCmd represents the vault recipients command
+var vaultRecipientsCmd = &cobra.Command{
+	Use:   "recipients",
+	Short: "Manage who can open a shared vault",
+	Long: `Recipients lets a team share one vault (for example synced through rclone)
+without sharing the master password.
+
+Each member creates an identity with 'pass-cli vault recipients keygen' and
+sends their public key to the vault owner, who adds it with
+'pass-cli vault recipients add'. The vault's data key is then also wrapped to
+that public key, and the member's pass-cli unlocks the vault with their
+identity file instead of asking for the master password.
+
+Removing a recipient rotates the data key, so the removed member cannot open
+anything written afterwards. Recipients require a v2 vault
+('pass-cli vault migrate').`,
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultRecipientsCmd)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var vaultRecipientsAddCmd = &cobra.Command{
+	Use:   "add <name> <public-key>",
+	Short: "Let a team member open the vault with their identity",
+	Long: `Add wraps the vault's data key to a team member's public key (printed by
+'pass-cli vault recipients keygen' on their machine). Once the vault is synced,
+they can open it with their own identity file.
+
+The recipient gets full read and write access to the vault, but cannot change
+the master password or remove other recipients.`,
+	Example: `  # Add a team member (public key from their 'vault recipients keygen')
+  pass-cli vault recipients add alice pass-cli-pub-3q2Jv...
+
+  # Add a shared team vault member
+  pass-cli --vault team vault recipients add bob pass-cli-pub-Xk9fL...`,
+	Args: cobra.ExactArgs(2),
+	RunE: runVaultRecipientsAdd,
+}
+
+func init() {
+	vaultRecipientsCmd.AddCommand(vaultRecipientsAddCmd)
+}
+
+func runVaultRecipientsAdd(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("recipient name cannot be empty")
+	}
+	publicKey := strings.TrimSpace(args[1])
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if err := unlockVault(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	if err := vaultService.AddRecipient(name, publicKey); err != nil {
+		return fmt.Errorf("failed to add recipient: %w", err)
+	}
+
+	fmt.Printf("✅ Recipient '%s' added\n", name)
+	fmt.Printf("🔑 %s\n", publicKey)
+	if vaultService.IsSyncEnabled() {
+		fmt.Printf("\n%s can open the vault with their identity once it has synced.\n", name)
+	} else {
+		fmt.Printf("\n%s can open a copy of this vault with their identity.\n", name)
+	}
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var vaultRecipientsKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Create your identity and print its public key",
+	Long: `Keygen creates your identity: an X25519 key pair stored in
+~/.pass-cli/identity (next to your config file, or PASS_CLI_IDENTITY if set).
+Send the printed public key to the owner of a shared vault so they can add you
+as a recipient.
+
+The identity file opens every vault you are a recipient of. It is written with
+owner-only permissions; protect it like an SSH private key. If an identity
+already exists, its public key is printed and nothing is changed.`,
+	Example: `  # Create your identity and show the public key to share
+  pass-cli vault recipients keygen`,
+	Args: cobra.NoArgs,
+	RunE: runVaultRecipientsKeygen,
+}
+
+func init() {
+	vaultRecipientsCmd.AddCommand(vaultRecipientsKeygenCmd)
+}
+
+func runVaultRecipientsKeygen(cmd *cobra.Command, args []string) error {
+	path, err := vault.IdentityPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate identity: %w", err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		publicKey, err := vault.IdentityPublicKey(path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Identity already exists: %s\n", path)
+		fmt.Printf("🔑 Public key: %s\n", publicKey)
+		return nil
+	}
+
+	publicKey, err := vault.GenerateIdentity(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Identity created: %s\n", path)
+	fmt.Printf("🔑 Public key: %s\n", publicKey)
+	fmt.Println("\nShare the public key with the vault owner, who adds you with:")
+	fmt.Printf("  pass-cli vault recipients add <your-name> %s\n", publicKey)
+	fmt.Println("\n⚠️  Keep the identity file private: it opens every vault shared with you.")
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var vaultRecipientsListFormat string
+
+var vaultRecipientsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the recipients of the vault",
+	Long: `List shows the team members who can open the vault with their identity.
+Recipients are stored in the vault header, so the vault does not need to be
+unlocked. The recipient matching your own identity file is marked with '*'.`,
+	Example: `  # List recipients
+  pass-cli vault recipients list
+
+  # JSON output for scripting
+  pass-cli vault recipients list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runVaultRecipientsList,
+}
+
+func init() {
+	vaultRecipientsCmd.AddCommand(vaultRecipientsListCmd)
+	vaultRecipientsListCmd.Flags().StringVar(&vaultRecipientsListFormat, "format", "table", "output format: table, json")
+}
+
+// recipientEntry is the JSON representation of a recipient
+type recipientEntry struct {
+	Name      string    `json:"name"`
+	PublicKey string    `json:"public_key"`
+	AddedAt   time.Time `json:"added_at"`
+	You       bool      `json:"you"`
+}
+
+func runVaultRecipientsList(cmd *cobra.Command, args []string) error {
+	if vaultRecipientsListFormat != "table" && vaultRecipientsListFormat != "json" {
+		return fmt.Errorf("invalid format: %s (must be table or json)", vaultRecipientsListFormat)
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull to show the latest recipients
+	syncPullBeforeUnlock(vaultService)
+
+	recipients, err := vaultService.ListRecipients()
+	if err != nil {
+		return fmt.Errorf("failed to read recipients: %w", err)
+	}
+
+	// Mark the local identity, if there is one
+	ownKey := ""
+	if path, err := vault.IdentityPath(); err == nil {
+		ownKey, _ = vault.IdentityPublicKey(path)
+	}
+
+	entries := make([]recipientEntry, 0, len(recipients))
+	for _, recipient := range recipients {
+		entries = append(entries, recipientEntry{
+			Name:      recipient.Name,
+			PublicKey: recipient.PublicKey,
+			AddedAt:   recipient.AddedAt,
+			You:       ownKey != "" && recipient.PublicKey == ownKey,
+		})
+	}
+
+	if vaultRecipientsListFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No recipients. Add one with: pass-cli vault recipients add <name> <public-key>")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"", "Name", "Public Key", "Added"})
+	data := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		marker := ""
+		if entry.You {
+			marker = "*"
+		}
+		data = append(data, []string{marker, entry.Name, entry.PublicKey, formatRelativeTime(entry.AddedAt)})
+	}
+	_ = table.Bulk(data)
+	_ = table.Render()
+	return nil
+}
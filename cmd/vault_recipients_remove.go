@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+var vaultRecipientsRemoveForce bool
+
+var vaultRecipientsRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a recipient and rotate the vault key",
+	Long: `Remove deletes a recipient's key slot and rotates the vault's data key: the
+vault and its attachments are re-encrypted with a new key, wrapped again for the
+master password and every remaining recipient. A copy of the old key kept by
+the removed member opens nothing written from now on.
+
+Rotation needs the master password (keychain or prompt), so it cannot be done
+from an identity unlock. If recovery is set up, a NEW recovery phrase is
+generated and the old one stops working; write the new one down.
+
+Rotation does not take back what the member could already read: change the
+passwords they had access to.`,
+	Example: `  # Remove a recipient (asks for confirmation)
+  pass-cli vault recipients remove bob
+
+  # Remove without confirmation
+  pass-cli vault recipients remove bob --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVaultRecipientsRemove,
+}
+
+func init() {
+	vaultRecipientsCmd.AddCommand(vaultRecipientsRemoveCmd)
+	vaultRecipientsRemoveCmd.Flags().BoolVarP(&vaultRecipientsRemoveForce, "force", "f", false, "skip confirmation prompt")
+}
+
+func runVaultRecipientsRemove(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("recipient name cannot be empty")
+	}
+
+	vaultPath := GetVaultPath()
+
+	// Check if vault exists
+	if _, err := os.Stat(vaultPath); os.IsNotExist(err) {
+		return fmt.Errorf("vault not found at %s\nRun 'pass-cli init' to create a vault first", vaultPath)
+	}
+
+	vaultService, err := vault.New(vaultPath)
+	if err != nil {
+		return fmt.Errorf("failed to create vault service at %s: %w", vaultPath, err)
+	}
+
+	// Smart sync pull before unlock to get latest version
+	syncPullBeforeUnlock(vaultService)
+
+	if !vaultRecipientsRemoveForce {
+		confirmed, err := promptYesNo(fmt.Sprintf("Remove recipient %s and rotate the vault key?", name), false)
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Removal cancelled.")
+			return nil
+		}
+	}
+
+	// Rotation re-wraps the key with the master password
+	if err := unlockVaultWithPassword(vaultService); err != nil {
+		return err
+	}
+	defer vaultService.Lock()
+
+	// Keep passphrase protection for the new recovery phrase
+	var passphrase []byte
+	metadata, err := vaultService.LoadMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to load vault metadata: %w", err)
+	}
+	if metadata.Recovery != nil && metadata.Recovery.Enabled && metadata.Recovery.PassphraseRequired {
+		fmt.Println("Your recovery phrase is protected with a passphrase (25th word).")
+		fmt.Print("Recovery passphrase for the new phrase: ")
+		passphrase, err = readPassword()
+		if err != nil {
+			return fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		fmt.Println()
+
+		fmt.Print("Confirm recovery passphrase: ")
+		confirmPassphrase, err := readPassword()
+		if err != nil {
+			crypto.ClearBytes(passphrase)
+			return fmt.Errorf("failed to read confirmation passphrase: %w", err)
+		}
+		fmt.Println()
+
+		if string(passphrase) != string(confirmPassphrase) {
+			crypto.ClearBytes(passphrase)
+			crypto.ClearBytes(confirmPassphrase)
+			return fmt.Errorf("passphrases do not match")
+		}
+		crypto.ClearBytes(confirmPassphrase)
+	}
+
+	fmt.Println("🔄 Rotating vault key...")
+	mnemonic, err := vaultService.RemoveRecipient(name, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to remove recipient: %w", err)
+	}
+
+	fmt.Printf("✅ Recipient '%s' removed\n", name)
+	fmt.Println("🔐 Vault re-encrypted with a new key")
+
+	if mnemonic != "" {
+		fmt.Println()
+		fmt.Println("⚠️  Your recovery phrase has changed. The old phrase no longer works.")
+		fmt.Println()
+		displayMnemonic(mnemonic)
+	}
+
+	syncPushAfterCommand(vaultService)
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+)
+
+var vaultUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default vault",
+	Long: `Use makes a registered vault the default: commands run without --vault use
+its path, sync remote and keychain setting. The choice is saved as default_vault
+in your config file.`,
+	Example: `  # Work in the team vault by default
+  pass-cli vault use team
+
+  # One command against another vault
+  pass-cli --vault personal get github`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVaultUse,
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultUseCmd)
+}
+
+func runVaultUse(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+
+	// --vault only affects this command, so check the registry itself
+	config.SelectVault("")
+	cfg, result := config.Load()
+	if !result.Valid {
+		return fmt.Errorf("invalid configuration: %s: %s", result.Errors[0].Field, result.Errors[0].Message)
+	}
+	profile, ok := cfg.Vaults[name]
+	if !ok {
+		return fmt.Errorf("unknown vault '%s'\nRegister it first with: pass-cli vault add %s <path>", name, name)
+	}
+
+	if err := updateConfigFile(func(configMap map[string]interface{}) error {
+		configMap["default_vault"] = name
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Default vault: %s\n", name)
+	fmt.Printf("📁 %s\n", profile.Path)
+	if !pathExists(expandVaultPath(profile.Path)) {
+		fmt.Printf("\nThe vault file does not exist yet. Create it with: pass-cli init\n")
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+// Package archive reads and writes pass-cli export archives: a set of
+// credentials encrypted with a passphrase of their own, independent of any
+// vault's password, key slots or file format.
+//
+// An archive is a JSON document. Its header is readable without the passphrase
+// and says how to decrypt the rest:
+//
+//	{
+//	  "format": "pass-cli-archive",
+//	  "version": 1,
+//	  "created_at": "2026-01-02T15:04:05Z",
+//	  "count": 2,
+//	  "kdf": {"algorithm": "argon2id", "salt": "...", "time": 3, "memory": 65536, "threads": 4},
+//	  "cipher": "aes-256-gcm",
+//	  "nonce": "...",
+//	  "data": "..."
+//	}
+//
+// The key is Argon2id(passphrase, salt) with the given cost (memory in KiB).
+// data is the AES-256-GCM encryption of the JSON payload {"entries": [...]}
+// with the header fields as additional data, so the header cannot be altered
+// either. Binary values are base64 encoded.
+package archive
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+const (
+	// Format identifies pass-cli archives
+	Format = "pass-cli-archive"
+	// Version is the archive version written by Seal
+	Version = 1
+
+	kdfArgon2id  = "argon2id"
+	cipherAESGCM = "aes-256-gcm"
+
+	saltLength = 32
+	keyLength  = 32
+
+	// Argon2id cost of new archives (RFC 9106 second recommended option)
+	defaultTime    = 3
+	defaultMemory  = 64 * 1024 // KiB
+	defaultThreads = 4
+
+	// Upper bounds accepted when opening, so a crafted header cannot exhaust memory
+	maxTime   = 64
+	maxMemory = 2 * 1024 * 1024 // KiB
+)
+
+var (
+	// ErrNotArchive indicates a file that is not a pass-cli archive
+	ErrNotArchive = errors.New("not a pass-cli archive")
+	// ErrWrongPassphrase indicates a wrong passphrase or a modified archive
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted archive")
+)
+
+// header is the unencrypted part of an archive, authenticated as GCM additional data
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Count     int       `json:"count"`
+	KDF       kdfParams `json:"kdf"`
+	Cipher    string    `json:"cipher"`
+	Nonce     []byte    `json:"nonce"`
+}
+
+type kdfParams struct {
+	Algorithm string `json:"algorithm"`
+	Salt      []byte `json:"salt"`
+	Time      uint32 `json:"time"`
+	Memory    uint32 `json:"memory"` // KiB
+	Threads   uint8  `json:"threads"`
+}
+
+type file struct {
+	header
+	Data []byte `json:"data"`
+}
+
+type payload struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is one credential in an archive. Only what describes the credential is
+// kept: no usage records, history or vault-internal identifiers.
+type Entry struct {
+	Service      string              `json:"service"`
+	Type         string              `json:"type"`
+	Username     string              `json:"username,omitempty"`
+	Password     string              `json:"password,omitempty"`
+	Category     string              `json:"category,omitempty"`
+	URL          string              `json:"url,omitempty"`
+	URLs         []vault.URLRule     `json:"urls,omitempty"`
+	Notes        string              `json:"notes,omitempty"`
+	Tags         []string            `json:"tags,omitempty"`
+	Fields       []vault.CustomField `json:"fields,omitempty"`
+	TOTP         *TOTP               `json:"totp,omitempty"`
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	RotationDays int                 `json:"rotation_days,omitempty"`
+	Attachments  []File              `json:"attachments,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// TOTP is an entry's two-factor configuration
+type TOTP struct {
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Digits    int    `json:"digits,omitempty"`
+	Period    int    `json:"period,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+}
+
+// File is an attachment with its content
+type File struct {
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// Seal encrypts credentials (as returned by VaultService.ExportCredentials)
+// into an archive protected by passphrase
+func Seal(credentials []vault.Credential, passphrase []byte) ([]byte, error) {
+	plaintext, err := Plaintext(credentials)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.ClearBytes(plaintext)
+
+	h := header{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Count:     len(credentials),
+		KDF: kdfParams{
+			Algorithm: kdfArgon2id,
+			Salt:      make([]byte, saltLength),
+			Time:      defaultTime,
+			Memory:    defaultMemory,
+			Threads:   defaultThreads,
+		},
+		Cipher: cipherAESGCM,
+		Nonce:  make([]byte, crypto.NonceLength),
+	}
+	if _, err := rand.Read(h.KDF.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	if _, err := rand.Read(h.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, h.KDF)
+	if err != nil {
+		return nil, err
+	}
+	aad, err := json.Marshal(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive header: %w", err)
+	}
+
+	// #nosec G407 -- the nonce is random (crypto/rand above) and the key is unique per archive (random salt)
+	data := gcm.Seal(nil, h.Nonce, plaintext, aad)
+	out, err := json.MarshalIndent(file{header: h, Data: data}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// Plaintext returns the unencrypted content of an archive for credentials:
+// indented JSON of the form {"entries": [...]}. It is also the plaintext JSON
+// export format.
+func Plaintext(credentials []vault.Credential) ([]byte, error) {
+	entries := make([]Entry, 0, len(credentials))
+	for _, credential := range credentials {
+		entries = append(entries, newEntry(credential))
+	}
+	data, err := json.MarshalIndent(payload{Entries: entries}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// IsArchive reports whether data looks like a pass-cli archive, without decrypting it
+func IsArchive(data []byte) bool {
+	var h header
+	return json.Unmarshal(data, &h) == nil && h.Format == Format
+}
+
+// Open decrypts an archive and returns its entries as credentials ready for
+// VaultService.ImportCredentials
+func Open(data, passphrase []byte) ([]vault.Credential, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil || f.Format != Format {
+		return nil, ErrNotArchive
+	}
+	if f.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version %d (this pass-cli reads version %d)", f.Version, Version)
+	}
+	if f.Cipher != cipherAESGCM || f.KDF.Algorithm != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported archive encryption: %s with %s", f.Cipher, f.KDF.Algorithm)
+	}
+	if len(f.KDF.Salt) < 16 || len(f.Nonce) != crypto.NonceLength ||
+		f.KDF.Time < 1 || f.KDF.Time > maxTime || f.KDF.Memory < 8 || f.KDF.Memory > maxMemory || f.KDF.Threads < 1 {
+		return nil, fmt.Errorf("invalid archive header: key derivation parameters out of range")
+	}
+
+	gcm, err := newGCM(passphrase, f.KDF)
+	if err != nil {
+		return nil, err
+	}
+	aad, err := json.Marshal(f.header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive header: %w", err)
+	}
+	plaintext, err := gcm.Open(nil, f.Nonce, f.Data, aad)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	defer crypto.ClearBytes(plaintext)
+
+	var p payload
+	if err := json.Unmarshal(plaintext, &p); err != nil {
+		return nil, fmt.Errorf("invalid archive content: %w", err)
+	}
+	credentials := make([]vault.Credential, 0, len(p.Entries))
+	for _, entry := range p.Entries {
+		credentials = append(credentials, entry.credential())
+	}
+	return credentials, nil
+}
+
+// newGCM derives the archive key and returns the AEAD
+func newGCM(passphrase []byte, kdf kdfParams) (cipher.AEAD, error) {
+	key := argon2.IDKey(passphrase, kdf.Salt, kdf.Time, kdf.Memory, kdf.Threads, keyLength)
+	defer crypto.ClearBytes(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// newEntry converts a credential to its archive form
+func newEntry(credential vault.Credential) Entry {
+	entry := Entry{
+		Service:      credential.Service,
+		Type:         credential.Type,
+		Username:     credential.Username,
+		Password:     string(credential.Password),
+		Category:     credential.Category,
+		URL:          credential.URL,
+		URLs:         credential.URLs,
+		Notes:        credential.Notes,
+		Tags:         credential.Tags,
+		Fields:       credential.CustomFields,
+		ExpiresAt:    credential.ExpiresAt,
+		RotationDays: credential.RotationDays,
+		CreatedAt:    credential.CreatedAt,
+		UpdatedAt:    credential.UpdatedAt,
+	}
+	if entry.Type == "" {
+		entry.Type = vault.RecordTypeLogin
+	}
+	if credential.TOTPSecret != "" {
+		entry.TOTP = &TOTP{
+			Secret:    credential.TOTPSecret,
+			Algorithm: credential.TOTPAlgorithm,
+			Digits:    credential.TOTPDigits,
+			Period:    credential.TOTPPeriod,
+			Issuer:    credential.TOTPIssuer,
+		}
+	}
+	for _, attachment := range credential.Attachments {
+		entry.Attachments = append(entry.Attachments, File{Name: attachment.Name, Data: attachment.Data})
+	}
+	return entry
+}
+
+// credential converts an archive entry back to a credential
+func (e Entry) credential() vault.Credential {
+	credential := vault.Credential{
+		Service:      e.Service,
+		Type:         e.Type,
+		Username:     e.Username,
+		Password:     []byte(e.Password),
+		Category:     e.Category,
+		URL:          e.URL,
+		URLs:         e.URLs,
+		Notes:        e.Notes,
+		Tags:         e.Tags,
+		CustomFields: e.Fields,
+		ExpiresAt:    e.ExpiresAt,
+		RotationDays: e.RotationDays,
+	}
+	if e.TOTP != nil {
+		credential.TOTPSecret = e.TOTP.Secret
+		credential.TOTPAlgorithm = e.TOTP.Algorithm
+		credential.TOTPDigits = e.TOTP.Digits
+		credential.TOTPPeriod = e.TOTP.Period
+		credential.TOTPIssuer = e.TOTP.Issuer
+	}
+	for _, attachment := range e.Attachments {
+		credential.Attachments = append(credential.Attachments, vault.Attachment{Name: attachment.Name, Data: attachment.Data})
+	}
+	return credential
+}
@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+func TestSealOpen(t *testing.T) {
+	expires := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	credentials := []vault.Credential{
+		{
+			Service:      "github",
+			Type:         vault.RecordTypeLogin,
+			Username:     "alice",
+			Password:     []byte("s3cret"),
+			Category:     "Work/Code",
+			URL:          "https://github.com",
+			Notes:        "main account",
+			Tags:         []string{"work"},
+			CustomFields: []vault.CustomField{{Name: "pin", Value: "1234", Hidden: true}},
+			TOTPSecret:   "JBSWY3DPEHPK3PXP",
+			TOTPDigits:   8,
+			TOTPIssuer:   "GitHub",
+			ExpiresAt:    &expires,
+			RotationDays: 90,
+			Attachments:  []vault.Attachment{{Name: "codes.txt", Data: []byte("recovery codes")}},
+		},
+		{Service: "wifi", Type: vault.RecordTypeNote, Notes: "hunter2"},
+	}
+
+	data, err := Seal(credentials, []byte("export passphrase"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+	if !IsArchive(data) {
+		t.Error("IsArchive() = false for a sealed archive")
+	}
+	if bytes.Contains(data, []byte("s3cret")) || bytes.Contains(data, []byte("github")) {
+		t.Error("archive contains plaintext credential data")
+	}
+
+	var h header
+	if err := json.Unmarshal(data, &h); err != nil {
+		t.Fatalf("header is not JSON: %v", err)
+	}
+	if h.Format != Format || h.Version != Version || h.Count != 2 || h.KDF.Algorithm != "argon2id" || h.Cipher != "aes-256-gcm" {
+		t.Errorf("header = %+v", h)
+	}
+
+	opened, err := Open(data, []byte("export passphrase"))
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	if len(opened) != 2 {
+		t.Fatalf("Open() returned %d credentials, want 2", len(opened))
+	}
+	github := opened[0]
+	if github.Service != "github" || github.Username != "alice" || string(github.Password) != "s3cret" ||
+		github.Category != "Work/Code" || github.URL != "https://github.com" || github.Notes != "main account" ||
+		len(github.Tags) != 1 || len(github.CustomFields) != 1 || !github.CustomFields[0].Hidden ||
+		github.TOTPSecret != "JBSWY3DPEHPK3PXP" || github.TOTPDigits != 8 || github.TOTPIssuer != "GitHub" ||
+		github.ExpiresAt == nil || !github.ExpiresAt.Equal(expires) || github.RotationDays != 90 {
+		t.Errorf("github = %+v", github)
+	}
+	if len(github.Attachments) != 1 || string(github.Attachments[0].Data) != "recovery codes" {
+		t.Errorf("attachments = %+v", github.Attachments)
+	}
+	if opened[1].Type != vault.RecordTypeNote || opened[1].Notes != "hunter2" {
+		t.Errorf("wifi = %+v", opened[1])
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	data, err := Seal([]vault.Credential{{Service: "github", Password: []byte("s3cret")}}, []byte("right"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+
+	if _, err := Open(data, []byte("wrong")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+
+	// The header is authenticated: changing the count breaks decryption
+	tampered := bytes.Replace(data, []byte(`"count": 1`), []byte(`"count": 5`), 1)
+	if bytes.Equal(tampered, data) {
+		t.Fatal("test setup: count not found in archive")
+	}
+	if _, err := Open(tampered, []byte("right")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("tampered header error = %v, want ErrWrongPassphrase", err)
+	}
+
+	var f map[string]any
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	f["kdf"].(map[string]any)["memory"] = 1 << 30
+	expensive, _ := json.Marshal(f)
+	if _, err := Open(expensive, []byte("right")); err == nil || errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("excessive KDF memory error = %v, want a header error", err)
+	}
+
+	f["version"] = 2
+	future, _ := json.Marshal(f)
+	if _, err := Open(future, []byte("right")); err == nil {
+		t.Error("Open() accepted an unknown version")
+	}
+
+	for _, input := range []string{"", "{}", `{"format":"other"}`, "not json"} {
+		if _, err := Open([]byte(input), []byte("right")); !errors.Is(err, ErrNotArchive) {
+			t.Errorf("Open(%q) error = %v, want ErrNotArchive", input, err)
+		}
+		if IsArchive([]byte(input)) {
+			t.Errorf("IsArchive(%q) = true", input)
+		}
+	}
+}
@@ -17,6 +17,19 @@ type Config struct {
 	VaultPath   string            `mapstructure:"vault_path"`
 	Theme       string            `mapstructure:"theme"`
 	Sync        SyncConfig        `mapstructure:"sync"`
+	History     HistoryConfig     `mapstructure:"history"`
+	Trash       TrashConfig       `mapstructure:"trash"`
+
+	// Credential helpers run by other tools
+	GitCredential    GitCredentialConfig    `mapstructure:"git_credential"`
+	DockerCredential DockerCredentialConfig `mapstructure:"docker_credential"`
+
+	// Vault registry: named vaults selectable with --vault, and the one used by default
+	Vaults       map[string]VaultProfile `mapstructure:"vaults"`
+	DefaultVault string                  `mapstructure:"default_vault"`
+
+	// ActiveVault is the profile whose path and sync settings were applied (not in YAML)
+	ActiveVault string `mapstructure:"-"`
 
 	// LoadErrors populated during config loading (not in YAML)
 	LoadErrors []string `mapstructure:"-"`
@@ -40,6 +53,26 @@ type SyncConfig struct {
 	Remote  string `mapstructure:"remote"`  // rclone remote name + path (e.g., "gdrive:.pass-cli")
 }
 
+// HistoryConfig represents per-credential revision history settings
+type HistoryConfig struct {
+	MaxRevisions int `mapstructure:"max_revisions"` // Revisions kept per credential (0 disables history)
+}
+
+// TrashConfig represents settings for deleted credentials
+type TrashConfig struct {
+	RetentionDays int `mapstructure:"retention_days"` // Days deleted credentials stay restorable (0 disables the trash)
+}
+
+// GitCredentialConfig represents settings for 'pass-cli git-credential'
+type GitCredentialConfig struct {
+	Category string `mapstructure:"category"` // Category of the credentials stored (and erased) by git
+}
+
+// DockerCredentialConfig represents settings for 'pass-cli docker-credential'
+type DockerCredentialConfig struct {
+	Category string `mapstructure:"category"` // Category of the registry credentials stored, listed and erased by Docker
+}
+
 // ValidationResult represents the outcome of checking configuration correctness
 type ValidationResult struct {
 	Valid    bool
@@ -87,6 +120,18 @@ func GetDefaults() *Config {
 			Enabled: false,
 			Remote:  "",
 		},
+		History: HistoryConfig{
+			MaxRevisions: 10,
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		GitCredential: GitCredentialConfig{
+			Category: "git",
+		},
+		DockerCredential: DockerCredentialConfig{
+			Category: "docker",
+		},
 		LoadErrors: []string{},
 	}
 
@@ -202,6 +247,51 @@ theme: "dracula"
 #
 # See: https://arimxyer.github.io/pass-cli/docs/02-guides/sync-guide/
 
+# Vault Registry (optional)
+# Named vaults, each with its own path, sync remote and keychain setting.
+# Select one per command with --vault <name>, or set the default with
+# 'pass-cli vault use <name>'. The selected vault's path and sync settings
+# replace vault_path and sync above.
+#
+# vaults:
+#   personal:
+#     path: "~/.pass-cli/vault.enc"
+#   team:
+#     path: "~/team-vault/vault.enc"
+#     keychain: false              # Always prompt for the master password
+#     sync:
+#       enabled: true
+#       remote: "gdrive:team-vault"
+# default_vault: personal
+
+# Credential History (optional)
+# Previous values are kept (encrypted inside the vault) each time a credential
+# is updated, and can be restored with 'pass-cli history restore'.
+#
+# history:
+#   max_revisions: 10    # Revisions kept per credential (0 disables history, max 1000)
+
+# Trash (optional)
+# Deleted credentials are kept (encrypted inside the vault) for a while and can
+# be brought back with 'pass-cli trash restore'.
+#
+# trash:
+#   retention_days: 30   # Days before deleted credentials are purged (0 deletes immediately, max 3650)
+
+# Git Credential Helper (optional)
+# Credentials that git saves through 'pass-cli git-credential' are put in this
+# category, and git can only erase credentials in it.
+#
+# git_credential:
+#   category: "git"
+
+# Docker Credential Helper (optional)
+# Registry logins saved by Docker or Podman through 'pass-cli docker-credential'
+# are put in this category. Docker can only list and erase credentials in it.
+#
+# docker_credential:
+#   category: "docker"
+
 # Terminal size warning configuration
 terminal:
   # Enable or disable terminal size warnings (default: true)
@@ -290,11 +380,20 @@ func detectUnknownFields(v *viper.Viper) []ValidationWarning {
 		"sync":                          true,
 		"sync.enabled":                  true,
 		"sync.remote":                   true,
+		"history":                       true,
+		"history.max_revisions":         true,
+		"trash":                         true,
+		"trash.retention_days":          true,
+		"git_credential":                true,
+		"git_credential.category":       true,
+		"docker_credential":             true,
+		"docker_credential.category":    true,
+		"default_vault":                 true,
 	}
 
 	// Check for unknown fields
 	for _, key := range allKeys {
-		if !knownFields[key] {
+		if !knownFields[key] && !isProfileKey(key) {
 			warnings = append(warnings, ValidationWarning{
 				Field:   key,
 				Message: fmt.Sprintf("unknown field '%s' (will be ignored)", key),
@@ -324,7 +423,11 @@ func LoadFromPath(configPath string) (*Config, *ValidationResult) {
 		if shouldLogConfig() {
 			fmt.Fprintf(os.Stderr, "[Config] No config file found, using defaults\n")
 		}
-		return GetDefaults(), &ValidationResult{Valid: true}
+		// A vault selected with --vault cannot exist without a config file
+		cfg := GetDefaults()
+		result := cfg.validateSelectedVault(&ValidationResult{Valid: true})
+		result.Valid = len(result.Errors) == 0
+		return cfg, result
 	}
 	if err != nil {
 		// T051: Log file access error
@@ -375,6 +478,10 @@ func LoadFromPath(configPath string) (*Config, *ValidationResult) {
 	v.SetDefault("theme", defaults.Theme)
 	v.SetDefault("sync.enabled", defaults.Sync.Enabled)
 	v.SetDefault("sync.remote", defaults.Sync.Remote)
+	v.SetDefault("history.max_revisions", defaults.History.MaxRevisions)
+	v.SetDefault("trash.retention_days", defaults.Trash.RetentionDays)
+	v.SetDefault("git_credential.category", defaults.GitCredential.Category)
+	v.SetDefault("docker_credential.category", defaults.DockerCredential.Category)
 
 	// Read and parse YAML
 	if err := v.ReadInConfig(); err != nil {
@@ -425,6 +532,9 @@ func LoadFromPath(configPath string) (*Config, *ValidationResult) {
 		return GetDefaults(), validationResult
 	}
 
+	// Use the selected vault profile's path and sync settings
+	cfg.applyProfile()
+
 	// T051: Log successful load
 	if shouldLogConfig() {
 		fmt.Fprintf(os.Stderr, "[Config] Successfully loaded config\n")
@@ -472,6 +582,15 @@ func (c *Config) Validate() *ValidationResult {
 	// Validate sync
 	result = c.validateSync(result)
 
+	// Validate history
+	result = c.validateHistory(result)
+
+	// Validate trash
+	result = c.validateTrash(result)
+
+	// Validate vault registry
+	result = c.validateVaults(result)
+
 	// Set Valid flag based on error count
 	if len(result.Errors) > 0 {
 		result.Valid = false
@@ -714,3 +833,25 @@ func (c *Config) validateSync(result *ValidationResult) *ValidationResult {
 
 	return result
 }
+
+// validateHistory validates the revision history configuration
+func (c *Config) validateHistory(result *ValidationResult) *ValidationResult {
+	if c.History.MaxRevisions < 0 || c.History.MaxRevisions > 1000 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "history.max_revisions",
+			Message: fmt.Sprintf("must be between 0 and 1000 (got: %d)", c.History.MaxRevisions),
+		})
+	}
+	return result
+}
+
+// validateTrash validates the trash retention configuration
+func (c *Config) validateTrash(result *ValidationResult) *ValidationResult {
+	if c.Trash.RetentionDays < 0 || c.Trash.RetentionDays > 3650 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "trash.retention_days",
+			Message: fmt.Sprintf("must be between 0 and 3650 (got: %d)", c.Trash.RetentionDays),
+		})
+	}
+	return result
+}
@@ -365,3 +365,55 @@ func TestVaultPathValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryConfigValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRevisions int
+		expectValid  bool
+	}{
+		{"default", 10, true},
+		{"disabled", 0, true},
+		{"upper bound", 1000, true},
+		{"negative", -1, false},
+		{"too large", 1001, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := GetDefaults()
+			cfg.History.MaxRevisions = tt.maxRevisions
+			result := cfg.Validate()
+
+			if result.Valid != tt.expectValid {
+				t.Errorf("expected Valid=%v, got %v: %v", tt.expectValid, result.Valid, result.Errors)
+			}
+		})
+	}
+}
+
+func TestTrashConfigValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		retentionDays int
+		expectValid   bool
+	}{
+		{"default", 30, true},
+		{"disabled", 0, true},
+		{"upper bound", 3650, true},
+		{"negative", -1, false},
+		{"too large", 3651, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := GetDefaults()
+			cfg.Trash.RetentionDays = tt.retentionDays
+			result := cfg.Validate()
+
+			if result.Valid != tt.expectValid {
+				t.Errorf("expected Valid=%v, got %v: %v", tt.expectValid, result.Valid, result.Errors)
+			}
+		})
+	}
+}
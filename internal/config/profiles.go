@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VaultProfile is a named vault in the vault registry (the "vaults" config section)
+type VaultProfile struct {
+	Path     string     `mapstructure:"path"`     // Vault file location (same forms as vault_path)
+	Sync     SyncConfig `mapstructure:"sync"`     // Sync settings for this vault only
+	Keychain *bool      `mapstructure:"keychain"` // Unlock with the OS keychain (default: true)
+}
+
+// KeychainEnabled reports whether keychain unlock is allowed for the profile
+func (p VaultProfile) KeychainEnabled() bool {
+	return p.Keychain == nil || *p.Keychain
+}
+
+// selectedVault is the profile chosen with the global --vault flag
+var selectedVault string
+
+// SelectVault chooses the vault profile applied by Load, overriding default_vault.
+// An empty name falls back to default_vault.
+func SelectVault(name string) {
+	selectedVault = strings.ToLower(strings.TrimSpace(name))
+}
+
+// SelectedVault returns the profile chosen with SelectVault, if any
+func SelectedVault() string {
+	return selectedVault
+}
+
+// ValidateProfileName checks that a profile name can be used as a config key
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("vault name cannot be empty")
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("invalid vault name '%s' (use lowercase letters, digits, '-' and '_')", name)
+		}
+	}
+	return nil
+}
+
+// ProfileNames returns the registered vault profile names, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Vaults))
+	for name := range c.Vaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveProfile returns the profile applied to this config, if any
+func (c *Config) ActiveProfile() (VaultProfile, bool) {
+	if c.ActiveVault == "" {
+		return VaultProfile{}, false
+	}
+	profile, ok := c.Vaults[c.ActiveVault]
+	return profile, ok
+}
+
+// applyProfile makes the selected (or default) profile's path and sync settings the
+// effective ones. Without a profile, vault_path and sync are used as configured.
+func (c *Config) applyProfile() {
+	name := selectedVault
+	if name == "" {
+		name = c.DefaultVault
+	}
+	profile, ok := c.Vaults[name]
+	if name == "" || !ok {
+		return
+	}
+
+	c.ActiveVault = name
+	c.VaultPath = profile.Path
+	c.Sync = profile.Sync
+}
+
+// validateVaults validates the vault registry and the selected profile
+func (c *Config) validateVaults(result *ValidationResult) *ValidationResult {
+	for _, name := range c.ProfileNames() {
+		field := "vaults." + name
+		if err := ValidateProfileName(name); err != nil {
+			result.Errors = append(result.Errors, ValidationError{Field: field, Message: err.Error()})
+			continue
+		}
+
+		profile := c.Vaults[name]
+		if strings.TrimSpace(profile.Path) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".path",
+				Message: "path is required for every vault",
+			})
+		} else if containsNullByte(profile.Path) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".path",
+				Message: "path contains null byte",
+			})
+		}
+		if profile.Sync.Enabled && profile.Sync.Remote == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".sync.remote",
+				Message: "sync.remote is required when sync.enabled is true (e.g., 'gdrive:.pass-cli')",
+			})
+		}
+	}
+
+	if c.DefaultVault != "" {
+		if _, ok := c.Vaults[c.DefaultVault]; !ok {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "default_vault",
+				Message: fmt.Sprintf("unknown vault '%s' (not listed under vaults)", c.DefaultVault),
+			})
+		}
+	}
+
+	return c.validateSelectedVault(result)
+}
+
+// validateSelectedVault reports a --vault name missing from the registry
+func (c *Config) validateSelectedVault(result *ValidationResult) *ValidationResult {
+	if selectedVault == "" {
+		return result
+	}
+	if _, ok := c.Vaults[selectedVault]; !ok {
+		message := fmt.Sprintf("unknown vault '%s'", selectedVault)
+		if strings.ContainsAny(selectedVault, `/\`) {
+			// --vault used to take a path
+			message += " (--vault takes a vault name; register the path with 'pass-cli vault add <name> <path>')"
+		} else if names := c.ProfileNames(); len(names) > 0 {
+			message += fmt.Sprintf(" (available: %s)", strings.Join(names, ", "))
+		} else {
+			message += " (add one with 'pass-cli vault add')"
+		}
+		result.Errors = append(result.Errors, ValidationError{Field: "--vault", Message: message})
+	}
+	return result
+}
+
+// isProfileKey reports whether a config key belongs to the vault registry
+// ("vaults.<name>.path", "vaults.<name>.sync.remote", ...)
+func isProfileKey(key string) bool {
+	parts := strings.SplitN(key, ".", 3)
+	if parts[0] != "vaults" {
+		return false
+	}
+	if len(parts) < 3 {
+		return true
+	}
+	switch parts[2] {
+	case "path", "sync", "sync.enabled", "sync.remote", "keychain":
+		return true
+	}
+	return false
+}
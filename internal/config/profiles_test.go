@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const profilesYAML = `vault_path: /tmp/legacy.enc
+sync:
+  enabled: true
+  remote: "gdrive:legacy"
+vaults:
+  personal:
+    path: ~/.pass-cli/vault.enc
+  team:
+    path: /tmp/team.enc
+    keychain: false
+    sync:
+      enabled: true
+      remote: "gdrive:team"
+default_vault: personal
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestVaultProfiles(t *testing.T) {
+	path := writeTestConfig(t, profilesYAML)
+	defer SelectVault("")
+
+	// default_vault applies when nothing is selected
+	cfg, result := LoadFromPath(path)
+	if !result.Valid {
+		t.Fatalf("expected valid config, got errors: %v", result.Errors)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("registry keys should not be reported as unknown: %v", result.Warnings)
+	}
+	if cfg.ActiveVault != "personal" || cfg.VaultPath != "~/.pass-cli/vault.enc" {
+		t.Errorf("active = %q, vault_path = %q, want the personal vault", cfg.ActiveVault, cfg.VaultPath)
+	}
+	if cfg.Sync.Enabled {
+		t.Error("a profile without sync settings should not inherit the top-level sync")
+	}
+	if got := cfg.ProfileNames(); len(got) != 2 || got[0] != "personal" || got[1] != "team" {
+		t.Errorf("ProfileNames() = %v", got)
+	}
+
+	// --vault overrides the default
+	SelectVault("Team")
+	cfg, result = LoadFromPath(path)
+	if !result.Valid {
+		t.Fatalf("expected valid config, got errors: %v", result.Errors)
+	}
+	profile, ok := cfg.ActiveProfile()
+	if !ok || cfg.ActiveVault != "team" || cfg.VaultPath != "/tmp/team.enc" {
+		t.Fatalf("active = %q, vault_path = %q, want the team vault", cfg.ActiveVault, cfg.VaultPath)
+	}
+	if !cfg.Sync.Enabled || cfg.Sync.Remote != "gdrive:team" {
+		t.Errorf("sync = %+v, want the team remote", cfg.Sync)
+	}
+	if profile.KeychainEnabled() {
+		t.Error("keychain: false should disable keychain unlock")
+	}
+
+	// Unknown names are reported, with or without a config file
+	SelectVault("work")
+	if _, result = LoadFromPath(path); result.Valid {
+		t.Error("expected an error for an unknown --vault name")
+	}
+	if _, result = LoadFromPath(filepath.Join(t.TempDir(), "missing.yml")); result.Valid {
+		t.Error("expected an error for --vault without a config file")
+	}
+}
+
+func TestVaultProfilesLegacy(t *testing.T) {
+	cfg, result := LoadFromPath(writeTestConfig(t, "vault_path: /tmp/legacy.enc\n"))
+	if !result.Valid {
+		t.Fatalf("expected valid config, got errors: %v", result.Errors)
+	}
+	if cfg.ActiveVault != "" || cfg.VaultPath != "/tmp/legacy.enc" {
+		t.Errorf("without a registry vault_path should be used as is (active %q, path %q)", cfg.ActiveVault, cfg.VaultPath)
+	}
+}
+
+func TestVaultProfilesValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]VaultProfile
+		def      string
+		valid    bool
+	}{
+		{"valid", map[string]VaultProfile{"work": {Path: "/tmp/work.enc"}}, "work", true},
+		{"missing path", map[string]VaultProfile{"work": {}}, "", false},
+		{"sync without remote", map[string]VaultProfile{"work": {Path: "/tmp/w.enc", Sync: SyncConfig{Enabled: true}}}, "", false},
+		{"unknown default", map[string]VaultProfile{"work": {Path: "/tmp/work.enc"}}, "home", false},
+		{"invalid name", map[string]VaultProfile{"my vault": {Path: "/tmp/v.enc"}}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := GetDefaults()
+			cfg.Vaults = tt.profiles
+			cfg.DefaultVault = tt.def
+			result := cfg.Validate()
+
+			if result.Valid != tt.valid {
+				t.Errorf("expected Valid=%v, got %v: %v", tt.valid, result.Valid, result.Errors)
+			}
+		})
+	}
+}
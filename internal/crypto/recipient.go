@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// Recipient key wrapping: the DEK is wrapped to an X25519 public key so a team
+// member can open a shared vault with their own secret key. Each wrap uses a
+// fresh ephemeral key pair; the KEK is HKDF-SHA256 of the X25519 shared secret,
+// bound to both public keys, and the DEK is sealed with WrapKey (AES-256-GCM).
+
+const (
+	// RecipientKeyPrefix marks an encoded recipient public key
+	RecipientKeyPrefix = "pass-cli-pub-"
+	// IdentityKeyPrefix marks an encoded recipient secret key (identity)
+	IdentityKeyPrefix = "PASS-CLI-SECRET-"
+
+	recipientKDFInfo = "pass-cli recipient key wrap v1"
+)
+
+var (
+	// ErrInvalidRecipientKey indicates a malformed public or secret recipient key
+	ErrInvalidRecipientKey = errors.New("invalid recipient key")
+)
+
+// RecipientWrappedKey is a DEK wrapped to a recipient's public key
+type RecipientWrappedKey struct {
+	EphemeralPublicKey []byte     // 32 bytes: sender's one-time X25519 public key
+	Wrapped            WrappedKey // DEK sealed with the derived KEK
+}
+
+// GenerateRecipientKey creates a new X25519 key pair for a vault recipient.
+//
+// Security:
+//   - Caller MUST clear privateKey with ClearBytes() after use
+func GenerateRecipientKey() (publicKey, privateKey []byte, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, ErrRandomGenerationFailed
+	}
+	return key.PublicKey().Bytes(), key.Bytes(), nil
+}
+
+// RecipientPublicKey derives the public key belonging to a secret key
+func RecipientPublicKey(privateKey []byte) ([]byte, error) {
+	key, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, ErrInvalidRecipientKey
+	}
+	return key.PublicKey().Bytes(), nil
+}
+
+// WrapKeyToRecipient wraps a DEK so only the holder of publicKey's secret key can unwrap it
+func WrapKeyToRecipient(dek, publicKey []byte) (RecipientWrappedKey, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return RecipientWrappedKey{}, ErrInvalidRecipientKey
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return RecipientWrappedKey{}, ErrRandomGenerationFailed
+	}
+	ephemeralPublic := ephemeral.PublicKey().Bytes()
+
+	kek, err := recipientKEK(ephemeral, recipient, ephemeralPublic, publicKey)
+	if err != nil {
+		return RecipientWrappedKey{}, err
+	}
+	defer ClearBytes(kek)
+
+	wrapped, err := WrapKey(dek, kek)
+	if err != nil {
+		return RecipientWrappedKey{}, err
+	}
+	return RecipientWrappedKey{EphemeralPublicKey: ephemeralPublic, Wrapped: wrapped}, nil
+}
+
+// UnwrapKeyFromRecipient recovers a DEK wrapped with WrapKeyToRecipient.
+//
+// Security:
+//   - Caller MUST clear the returned DEK with ClearBytes() after use
+func UnwrapKeyFromRecipient(wrapped RecipientWrappedKey, privateKey []byte) ([]byte, error) {
+	key, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, ErrInvalidRecipientKey
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(wrapped.EphemeralPublicKey)
+	if err != nil {
+		return nil, ErrInvalidRecipientKey
+	}
+
+	kek, err := recipientKEK(key, ephemeral, wrapped.EphemeralPublicKey, key.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer ClearBytes(kek)
+
+	return UnwrapKey(wrapped.Wrapped, kek)
+}
+
+// recipientKEK derives the wrapping key from an X25519 exchange
+func recipientKEK(private *ecdh.PrivateKey, public *ecdh.PublicKey, ephemeralPublic, recipientPublic []byte) ([]byte, error) {
+	shared, err := private.ECDH(public)
+	if err != nil {
+		return nil, ErrInvalidRecipientKey
+	}
+	defer ClearBytes(shared)
+
+	salt := append(append([]byte{}, ephemeralPublic...), recipientPublic...)
+	kek, err := hkdf.Key(sha256.New, shared, salt, recipientKDFInfo, KeyLength)
+	if err != nil {
+		return nil, ErrEncryptionFailed
+	}
+	return kek, nil
+}
+
+// EncodeRecipientKey formats a public key for sharing ("pass-cli-pub-...")
+func EncodeRecipientKey(publicKey []byte) string {
+	return RecipientKeyPrefix + base64.RawURLEncoding.EncodeToString(publicKey)
+}
+
+// ParseRecipientKey decodes a public key produced by EncodeRecipientKey
+func ParseRecipientKey(s string) ([]byte, error) {
+	return parseEncodedKey(s, RecipientKeyPrefix)
+}
+
+// EncodeIdentityKey formats a secret key for the identity file ("PASS-CLI-SECRET-...")
+func EncodeIdentityKey(privateKey []byte) string {
+	return IdentityKeyPrefix + base64.RawURLEncoding.EncodeToString(privateKey)
+}
+
+// ParseIdentityKey decodes a secret key produced by EncodeIdentityKey
+func ParseIdentityKey(s string) ([]byte, error) {
+	return parseEncodedKey(s, IdentityKeyPrefix)
+}
+
+func parseEncodedKey(s, prefix string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, prefix) {
+		return nil, ErrInvalidRecipientKey
+	}
+	key, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(s, prefix))
+	if err != nil || len(key) != KeyLength {
+		return nil, ErrInvalidRecipientKey
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRecipientKeyWrap(t *testing.T) {
+	publicKey, privateKey, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatalf("GenerateRecipientKey failed: %v", err)
+	}
+	dek, err := GenerateDEK()
+	if err != nil {
+		t.Fatalf("GenerateDEK failed: %v", err)
+	}
+
+	wrapped, err := WrapKeyToRecipient(dek, publicKey)
+	if err != nil {
+		t.Fatalf("WrapKeyToRecipient failed: %v", err)
+	}
+	unwrapped, err := UnwrapKeyFromRecipient(wrapped, privateKey)
+	if err != nil {
+		t.Fatalf("UnwrapKeyFromRecipient failed: %v", err)
+	}
+	if !bytes.Equal(unwrapped, dek) {
+		t.Error("unwrapped DEK does not match")
+	}
+
+	derived, err := RecipientPublicKey(privateKey)
+	if err != nil || !bytes.Equal(derived, publicKey) {
+		t.Errorf("RecipientPublicKey() = %x, %v; want %x", derived, err, publicKey)
+	}
+
+	// Another recipient's secret must not unwrap the DEK
+	_, otherKey, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatalf("GenerateRecipientKey failed: %v", err)
+	}
+	if _, err := UnwrapKeyFromRecipient(wrapped, otherKey); err == nil {
+		t.Error("expected unwrap with the wrong secret key to fail")
+	}
+
+	// Every wrap uses a fresh ephemeral key
+	again, err := WrapKeyToRecipient(dek, publicKey)
+	if err != nil {
+		t.Fatalf("WrapKeyToRecipient failed: %v", err)
+	}
+	if bytes.Equal(again.EphemeralPublicKey, wrapped.EphemeralPublicKey) {
+		t.Error("ephemeral keys should differ between wraps")
+	}
+}
+
+func TestRecipientKeyEncoding(t *testing.T) {
+	publicKey, privateKey, err := GenerateRecipientKey()
+	if err != nil {
+		t.Fatalf("GenerateRecipientKey failed: %v", err)
+	}
+
+	decoded, err := ParseRecipientKey(EncodeRecipientKey(publicKey) + "\n")
+	if err != nil || !bytes.Equal(decoded, publicKey) {
+		t.Errorf("public key round trip failed: %v", err)
+	}
+	decoded, err = ParseIdentityKey(EncodeIdentityKey(privateKey))
+	if err != nil || !bytes.Equal(decoded, privateKey) {
+		t.Errorf("secret key round trip failed: %v", err)
+	}
+
+	for _, bad := range []string{"", "pass-cli-pub-", "pass-cli-pub-AAAA", EncodeIdentityKey(privateKey), "age1qqq"} {
+		if _, err := ParseRecipientKey(bad); err == nil {
+			t.Errorf("ParseRecipientKey(%q) should fail", bad)
+		}
+	}
+}
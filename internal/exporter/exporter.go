@@ -0,0 +1,161 @@
+// Package exporter writes credentials in plaintext formats other tools can
+// read: CSV for password managers and spreadsheets, dotenv for secret managers
+// and deployment tooling. Credentials come from VaultService.ExportCredentials;
+// the JSON format is the content of an export archive (archive.Plaintext).
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// csvColumns are the fixed CSV columns. They use the names the generic CSV
+// import recognizes, and no custom field can share them (they are built-in
+// field names), so custom fields follow as columns of their own.
+var csvColumns = []string{"service", "username", "password", "url", "notes", "totp", "category", "tags"}
+
+// CSV returns credentials as CSV with a header row. Each custom field name
+// becomes a column (matched case-insensitively across credentials, in order of
+// first appearance); TOTP settings are written as otpauth:// URIs. Attachments
+// are not included.
+func CSV(credentials []vault.Credential) ([]byte, error) {
+	header := append([]string(nil), csvColumns...)
+	fieldColumn := make(map[string]int)
+	for _, credential := range credentials {
+		for _, field := range credential.CustomFields {
+			key := strings.ToLower(field.Name)
+			if _, exists := fieldColumn[key]; !exists {
+				fieldColumn[key] = len(header)
+				header = append(header, field.Name)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, credential := range credentials {
+		var totp string
+		if credential.HasTOTP() {
+			uri, err := credential.BuildTOTPURI()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", credential.Service, err)
+			}
+			totp = uri
+		}
+
+		row := make([]string, len(header))
+		copy(row, []string{
+			credential.Service,
+			credential.Username,
+			string(credential.Password),
+			credential.URL,
+			credential.Notes,
+			totp,
+			credential.Category,
+			strings.Join(credential.Tags, ";"),
+		})
+		for _, field := range credential.CustomFields {
+			row[fieldColumn[strings.ToLower(field.Name)]] = field.Value
+		}
+		if err := w.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Dotenv returns credentials as KEY="value" lines, one per non-empty value:
+// SERVICE_USERNAME, SERVICE_PASSWORD, SERVICE_URL, SERVICE_NOTES, SERVICE_TOTP
+// (an otpauth:// URI) and SERVICE_<FIELD> for custom fields, where SERVICE and
+// FIELD are the names in upper case with other characters replaced by '_'
+// ("my-db.prod" becomes MY_DB_PROD). Values are double-quoted with newlines,
+// quotes, backslashes and '$' escaped. Keys that two values would share are
+// written once; the others are reported as warnings. Attachments are not included.
+func Dotenv(credentials []vault.Credential) ([]byte, []string, error) {
+	var buf bytes.Buffer
+	var warnings []string
+	written := make(map[string]string) // key -> service that wrote it
+
+	for _, credential := range credentials {
+		prefix := envName(credential.Service)
+		if prefix == "" {
+			warnings = append(warnings, fmt.Sprintf("%s: no usable variable name, skipped", credential.Service))
+			continue
+		}
+
+		type variable struct{ suffix, value string }
+		variables := []variable{
+			{"USERNAME", credential.Username},
+			{"PASSWORD", string(credential.Password)},
+			{"URL", credential.URL},
+			{"NOTES", credential.Notes},
+		}
+		if credential.HasTOTP() {
+			uri, err := credential.BuildTOTPURI()
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", credential.Service, err)
+			}
+			variables = append(variables, variable{"TOTP", uri})
+		}
+		for _, field := range credential.CustomFields {
+			variables = append(variables, variable{envName(field.Name), field.Value})
+		}
+
+		for _, v := range variables {
+			if v.value == "" || v.suffix == "" {
+				continue
+			}
+			key := prefix + "_" + v.suffix
+			if owner, taken := written[key]; taken {
+				warnings = append(warnings, fmt.Sprintf("%s: %s already written for %s, skipped", credential.Service, key, owner))
+				continue
+			}
+			written[key] = credential.Service
+			fmt.Fprintf(&buf, "%s=%s\n", key, quoteEnv(v.value))
+		}
+	}
+	return buf.Bytes(), warnings, nil
+}
+
+// envName converts a name to an environment variable name: upper case letters,
+// digits and single underscores, not starting with a digit
+func envName(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	env := strings.TrimRight(b.String(), "_")
+	if env != "" && env[0] >= '0' && env[0] <= '9' {
+		env = "_" + env
+	}
+	return env
+}
+
+// quoteEnv double-quotes a dotenv value
+func quoteEnv(value string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\r", `\r`,
+		"\n", `\n`,
+	).Replace(value) + `"`
+}
@@ -0,0 +1,108 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/importer"
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+func testCredentials() []vault.Credential {
+	return []vault.Credential{
+		{
+			Service:      "github",
+			Username:     "alice",
+			Password:     []byte(`pa"ss,word`),
+			URL:          "https://github.com",
+			Notes:        "line one\nline two",
+			Category:     "Work/Code",
+			Tags:         []string{"work", "prod"},
+			CustomFields: []vault.CustomField{{Name: "PIN", Value: "1234", Hidden: true}},
+			TOTPSecret:   "JBSWY3DPEHPK3PXP",
+		},
+		{
+			Service:      "my-db.prod",
+			Username:     "admin",
+			Password:     []byte("$ecret"),
+			CustomFields: []vault.CustomField{{Name: "pin", Value: "9999"}, {Name: "host", Value: "db.internal"}},
+		},
+	}
+}
+
+func TestCSV(t *testing.T) {
+	data, err := CSV(testCredentials())
+	if err != nil {
+		t.Fatalf("CSV() failed: %v", err)
+	}
+	header := strings.SplitN(string(data), "\n", 2)[0]
+	if header != "service,username,password,url,notes,totp,category,tags,PIN,host" {
+		t.Errorf("header = %q", header)
+	}
+
+	// The generic CSV import reads the export back
+	parsed, err := importer.ParseCSV(data, importer.CSVPresetGeneric, nil)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+	if len(parsed.Credentials) != 2 {
+		t.Fatalf("ParseCSV() returned %d credentials, want 2", len(parsed.Credentials))
+	}
+	github := parsed.Credentials[0]
+	if github.Service != "github" || github.Username != "alice" || string(github.Password) != `pa"ss,word` ||
+		github.Notes != "line one\nline two" || github.Category != "Work/Code" || len(github.Tags) != 2 ||
+		github.TOTPSecret != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("github = %+v", github)
+	}
+	if field, ok := github.GetCustomField("pin"); !ok || field.Value != "1234" {
+		t.Errorf("github PIN field = %+v, %v", field, ok)
+	}
+	if field, ok := parsed.Credentials[1].GetCustomField("pin"); !ok || field.Value != "9999" {
+		t.Errorf("my-db.prod pin field = %+v, %v", field, ok)
+	}
+}
+
+func TestDotenv(t *testing.T) {
+	credentials := append(testCredentials(),
+		vault.Credential{Service: "my db prod", Password: []byte("clash")},
+		vault.Credential{Service: "---", Password: []byte("unnamed")},
+		vault.Credential{Service: "1password", Password: []byte("digits")},
+	)
+	data, warnings, err := Dotenv(credentials)
+	if err != nil {
+		t.Fatalf("Dotenv() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		`GITHUB_USERNAME="alice"`,
+		`GITHUB_PASSWORD="pa\"ss,word"`,
+		`GITHUB_URL="https://github.com"`,
+		`GITHUB_NOTES="line one\nline two"`,
+		`GITHUB_PIN="1234"`,
+		`MY_DB_PROD_USERNAME="admin"`,
+		`MY_DB_PROD_PASSWORD="\$ecret"`,
+		`MY_DB_PROD_PIN="9999"`,
+		`MY_DB_PROD_HOST="db.internal"`,
+		`_1PASSWORD_PASSWORD="digits"`,
+	}
+	var withoutTOTP []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "GITHUB_TOTP=") {
+			if !strings.HasPrefix(line, `GITHUB_TOTP="otpauth://totp/`) {
+				t.Errorf("TOTP line = %q", line)
+			}
+			continue
+		}
+		withoutTOTP = append(withoutTOTP, line)
+	}
+	if strings.Join(withoutTOTP, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Dotenv() =\n%s\nwant\n%s", strings.Join(withoutTOTP, "\n"), strings.Join(want, "\n"))
+	}
+
+	if len(warnings) != 2 ||
+		!strings.Contains(warnings[0], "MY_DB_PROD_PASSWORD already written for my-db.prod") ||
+		!strings.Contains(warnings[1], "---: no usable variable name") {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
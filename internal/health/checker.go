@@ -56,13 +56,15 @@ type HealthReport struct {
 
 // CheckOptions contains configuration for health check execution
 type CheckOptions struct {
-	CurrentVersion  string            // Current binary version
-	GitHubRepo      string            // GitHub repository (format: owner/repo)
-	VaultPath       string            // Path to vault file
-	VaultPathSource string            // Source of vault path ("config" or "default")
-	VaultDir        string            // Directory containing vault
-	ConfigPath      string            // Path to config file
-	SyncConfig      config.SyncConfig // ARI-53: Sync configuration for health check
+	CurrentVersion  string             // Current binary version
+	GitHubRepo      string             // GitHub repository (format: owner/repo)
+	VaultPath       string             // Path to vault file
+	VaultPathSource string             // Source of vault path ("config" or "default")
+	VaultDir        string             // Directory containing vault
+	ConfigPath      string             // Path to config file
+	SyncConfig      config.SyncConfig  // ARI-53: Sync configuration for health check
+	Expiries        []CredentialExpiry // Credential expiry dates (only meaningful when ExpiryChecked)
+	ExpiryChecked   bool               // Whether the vault could be read for the expiry check
 }
 
 // DetermineExitCode maps health summary to exit code
@@ -86,6 +88,7 @@ func RunChecks(ctx context.Context, opts CheckOptions) HealthReport {
 		NewKeychainChecker(opts.VaultPath),
 		NewBackupChecker(opts.VaultDir),
 		NewSyncChecker(opts.SyncConfig), // ARI-53: Cloud sync health check
+		NewExpiryChecker(opts.Expiries, opts.ExpiryChecked),
 	}
 
 	// Execute all checks
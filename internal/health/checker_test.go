@@ -63,8 +63,8 @@ audit_enabled: false
 			report.Summary.Warnings, acceptableWarnings)
 	}
 
-	// Should have 6 checks (version, vault, config, keychain, backup, sync)
-	expectedChecks := 6
+	// Should have 7 checks (version, vault, config, keychain, backup, sync, expiry)
+	expectedChecks := 7
 	if len(report.Checks) != expectedChecks {
 		t.Errorf("Expected %d checks, got %d", expectedChecks, len(report.Checks))
 	}
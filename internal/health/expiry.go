@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// expirySoonWindow is how far ahead the expiry check looks for upcoming expiries
+const expirySoonWindow = 30 * 24 * time.Hour
+
+// ExpiryChecker warns about expired credentials. Health checks never prompt for
+// the master password, so the caller supplies the expiry dates when it could
+// unlock the vault (e.g. from the keychain).
+type ExpiryChecker struct {
+	expiries []CredentialExpiry
+	checked  bool
+}
+
+// NewExpiryChecker creates a new credential expiry checker
+func NewExpiryChecker(expiries []CredentialExpiry, checked bool) HealthChecker {
+	return &ExpiryChecker{
+		expiries: expiries,
+		checked:  checked,
+	}
+}
+
+// Name returns the check name
+func (e *ExpiryChecker) Name() string {
+	return "expiry"
+}
+
+// Run executes the expiry check
+func (e *ExpiryChecker) Run(ctx context.Context) CheckResult {
+	details := ExpiryCheckDetails{
+		Checked:      e.checked,
+		Expired:      []CredentialExpiry{},
+		ExpiringSoon: []CredentialExpiry{},
+	}
+
+	if !e.checked {
+		return CheckResult{
+			Name:    e.Name(),
+			Status:  CheckPass,
+			Message: "Skipped (vault is locked)",
+			Recommendation: "Enable keychain integration to check credential expiry automatically, " +
+				"or run 'pass-cli expiring'",
+			Details: details,
+		}
+	}
+
+	now := time.Now()
+	for _, expiry := range e.expiries {
+		switch {
+		case !now.Before(expiry.ExpiresAt):
+			details.Expired = append(details.Expired, expiry)
+		case expiry.ExpiresAt.Sub(now) <= expirySoonWindow:
+			details.ExpiringSoon = append(details.ExpiringSoon, expiry)
+		}
+	}
+
+	if len(details.Expired) > 0 {
+		message := fmt.Sprintf("%d credentials expired", len(details.Expired))
+		if len(details.Expired) == 1 {
+			message = fmt.Sprintf("1 credential expired (%s)", details.Expired[0].Service)
+		}
+		return CheckResult{
+			Name:           e.Name(),
+			Status:         CheckWarning,
+			Message:        message,
+			Recommendation: "Rotate expired secrets with 'pass-cli update <service>' (see 'pass-cli expiring')",
+			Details:        details,
+		}
+	}
+
+	message := "No expired credentials"
+	if len(details.ExpiringSoon) > 0 {
+		message = fmt.Sprintf("No expired credentials (%d expiring within 30 days)", len(details.ExpiringSoon))
+	}
+	return CheckResult{
+		Name:    e.Name(),
+		Status:  CheckPass,
+		Message: message,
+		Details: details,
+	}
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExpiryCheck_Skipped(t *testing.T) {
+	checker := NewExpiryChecker(nil, false)
+
+	result := checker.Run(context.Background())
+
+	if result.Status != CheckPass {
+		t.Errorf("Expected status %s, got %s", CheckPass, result.Status)
+	}
+	if result.Name != "expiry" {
+		t.Errorf("Expected name 'expiry', got %s", result.Name)
+	}
+	details, ok := result.Details.(ExpiryCheckDetails)
+	if !ok {
+		t.Fatal("Expected ExpiryCheckDetails type")
+	}
+	if details.Checked {
+		t.Error("Expected Checked to be false when the vault is locked")
+	}
+}
+
+func TestExpiryCheck_Expired(t *testing.T) {
+	now := time.Now()
+	checker := NewExpiryChecker([]CredentialExpiry{
+		{Service: "old-token", ExpiresAt: now.Add(-24 * time.Hour)},
+		{Service: "soon", ExpiresAt: now.Add(5 * 24 * time.Hour)},
+		{Service: "later", ExpiresAt: now.Add(90 * 24 * time.Hour)},
+	}, true)
+
+	result := checker.Run(context.Background())
+
+	if result.Status != CheckWarning {
+		t.Errorf("Expected status %s, got %s", CheckWarning, result.Status)
+	}
+	if result.Recommendation == "" {
+		t.Error("Expected a recommendation for expired credentials")
+	}
+	details := result.Details.(ExpiryCheckDetails)
+	if len(details.Expired) != 1 || details.Expired[0].Service != "old-token" {
+		t.Errorf("Expected old-token to be expired, got %+v", details.Expired)
+	}
+	if len(details.ExpiringSoon) != 1 || details.ExpiringSoon[0].Service != "soon" {
+		t.Errorf("Expected soon to be expiring soon, got %+v", details.ExpiringSoon)
+	}
+}
+
+func TestExpiryCheck_NoneExpired(t *testing.T) {
+	checker := NewExpiryChecker([]CredentialExpiry{
+		{Service: "later", ExpiresAt: time.Now().Add(90 * 24 * time.Hour)},
+	}, true)
+
+	result := checker.Run(context.Background())
+
+	if result.Status != CheckPass {
+		t.Errorf("Expected status %s, got %s", CheckPass, result.Status)
+	}
+}
@@ -70,6 +70,19 @@ type BackupFile struct {
 	Status     string    `json:"status"`      // "recent", "old", "abandoned"
 }
 
+// CredentialExpiry is the effective expiry date of one credential
+type CredentialExpiry struct {
+	Service   string    `json:"service"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ExpiryCheckDetails contains credential expiry check results
+type ExpiryCheckDetails struct {
+	Checked      bool               `json:"checked"`       // False when the vault could not be unlocked without a prompt
+	Expired      []CredentialExpiry `json:"expired"`       // Credentials past their expiry date
+	ExpiringSoon []CredentialExpiry `json:"expiring_soon"` // Credentials expiring within 30 days
+}
+
 // SyncCheckDetails contains cloud sync health check results
 // ARI-53: Added for rclone sync status in doctor command
 type SyncCheckDetails struct {
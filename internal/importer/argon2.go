@@ -0,0 +1,278 @@
+package importer
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// argon2.go implements Argon2d and Argon2id (RFC 9106) for KeePass databases.
+// golang.org/x/crypto/argon2 only exposes Argon2i and Argon2id without a secret
+// or associated data, while KeePass defaults to Argon2d and allows both.
+
+const (
+	argon2d  = 0
+	argon2id = 2
+
+	argon2Version10 = 0x10
+	argon2Version13 = 0x13
+
+	argon2BlockWords = 128 // 1 KiB blocks of 64-bit words
+	argon2SyncPoints = 4   // Slices per pass
+)
+
+type argon2Block [argon2BlockWords]uint64
+
+// argon2Params are the cost parameters and inputs of one derivation
+type argon2Params struct {
+	mode        int
+	version     uint32
+	salt        []byte
+	secret      []byte
+	data        []byte // Associated data
+	iterations  uint32
+	memoryKiB   uint32
+	parallelism uint32
+	keyLen      uint32
+}
+
+// argon2Key derives a key from password with the given parameters
+func argon2Key(password []byte, p argon2Params) []byte {
+	h0 := argon2InitHash(password, p)
+
+	// Memory is rounded down to a multiple of 4 blocks per lane
+	memory := p.memoryKiB / (argon2SyncPoints * p.parallelism) * (argon2SyncPoints * p.parallelism)
+	if memory < 2*argon2SyncPoints*p.parallelism {
+		memory = 2 * argon2SyncPoints * p.parallelism
+	}
+	laneLength := memory / p.parallelism
+	segmentLength := laneLength / argon2SyncPoints
+
+	blocks := make([]argon2Block, memory)
+	var buf [1024]byte
+	for lane := uint32(0); lane < p.parallelism; lane++ {
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], i)
+			binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+			argon2Hash(buf[:], h0[:])
+			for j := range blocks[lane*laneLength+i] {
+				blocks[lane*laneLength+i][j] = binary.LittleEndian.Uint64(buf[j*8:])
+			}
+		}
+	}
+
+	fillSegment := func(pass, slice, lane uint32) {
+		dataIndependent := p.mode == argon2id && pass == 0 && slice < argon2SyncPoints/2
+		var addresses, input, zero argon2Block
+		if dataIndependent {
+			input[0], input[1], input[2] = uint64(pass), uint64(lane), uint64(slice)
+			input[3], input[4], input[5] = uint64(memory), uint64(p.iterations), uint64(p.mode)
+		}
+
+		index := uint32(0)
+		if pass == 0 && slice == 0 {
+			index = 2 // The first two blocks of each lane come from H0
+			if dataIndependent {
+				input[6]++
+				argon2Compress(&addresses, &input, &zero, false)
+				argon2Compress(&addresses, &addresses, &zero, false)
+			}
+		}
+
+		offset := lane*laneLength + slice*segmentLength + index
+		for ; index < segmentLength; index, offset = index+1, offset+1 {
+			prev := offset - 1
+			if index == 0 && slice == 0 {
+				prev += laneLength // Wrap to the last block of the lane
+			}
+
+			var random uint64
+			if dataIndependent {
+				if index%argon2BlockWords == 0 {
+					input[6]++
+					argon2Compress(&addresses, &input, &zero, false)
+					argon2Compress(&addresses, &addresses, &zero, false)
+				}
+				random = addresses[index%argon2BlockWords]
+			} else {
+				random = blocks[prev][0]
+			}
+
+			ref := argon2RefIndex(random, laneLength, segmentLength, p.parallelism, pass, slice, lane, index)
+			xor := p.version == argon2Version13 && pass > 0
+			argon2Compress(&blocks[offset], &blocks[prev], &blocks[ref], xor)
+		}
+	}
+
+	for pass := uint32(0); pass < p.iterations; pass++ {
+		for slice := uint32(0); slice < argon2SyncPoints; slice++ {
+			var wg sync.WaitGroup
+			for lane := uint32(0); lane < p.parallelism; lane++ {
+				wg.Add(1)
+				go func(lane uint32) {
+					defer wg.Done()
+					fillSegment(pass, slice, lane)
+				}(lane)
+			}
+			wg.Wait()
+		}
+	}
+
+	// XOR the last block of every lane and hash it to the output length
+	final := blocks[memory-1]
+	for lane := uint32(0); lane < p.parallelism-1; lane++ {
+		for i, word := range blocks[lane*laneLength+laneLength-1] {
+			final[i] ^= word
+		}
+	}
+	for i, word := range final {
+		binary.LittleEndian.PutUint64(buf[i*8:], word)
+	}
+	key := make([]byte, p.keyLen)
+	argon2Hash(key, buf[:])
+	return key
+}
+
+// argon2InitHash computes H0, leaving 8 bytes for the block and lane counters
+func argon2InitHash(password []byte, p argon2Params) [blake2b.Size + 8]byte {
+	h, _ := blake2b.New512(nil)
+	writeUint32 := func(values ...uint32) {
+		var tmp [4]byte
+		for _, value := range values {
+			binary.LittleEndian.PutUint32(tmp[:], value)
+			h.Write(tmp[:])
+		}
+	}
+
+	writeUint32(p.parallelism, p.keyLen, p.memoryKiB, p.iterations, p.version, uint32(p.mode))
+	for _, input := range [][]byte{password, p.salt, p.secret, p.data} {
+		writeUint32(uint32(len(input)))
+		h.Write(input)
+	}
+
+	var h0 [blake2b.Size + 8]byte
+	h.Sum(h0[:0])
+	return h0
+}
+
+// argon2Hash is the variable-length hash H' of RFC 9106
+func argon2Hash(out, in []byte) {
+	var h hash.Hash
+	if len(out) < blake2b.Size {
+		h, _ = blake2b.New(len(out), nil)
+	} else {
+		h, _ = blake2b.New512(nil)
+	}
+	var prefix [4]byte
+	binary.LittleEndian.PutUint32(prefix[:], uint32(len(out)))
+	h.Write(prefix[:])
+	h.Write(in)
+	if len(out) <= blake2b.Size {
+		h.Sum(out[:0])
+		return
+	}
+
+	// Chain 64-byte hashes, keeping the first half of each, then finish with the remainder
+	var v [blake2b.Size]byte
+	h.Sum(v[:0])
+	copy(out, v[:32])
+	rest := out[32:]
+	for len(rest) > blake2b.Size {
+		h, _ = blake2b.New512(nil)
+		h.Write(v[:])
+		h.Sum(v[:0])
+		copy(rest, v[:32])
+		rest = rest[32:]
+	}
+	h, _ = blake2b.New(len(rest), nil)
+	h.Write(v[:])
+	h.Sum(rest[:0])
+}
+
+// argon2RefIndex maps a pseudo-random value to the reference block (RFC 9106 section 3.4.1.2)
+func argon2RefIndex(random uint64, laneLength, segmentLength, lanes, pass, slice, lane, index uint32) uint32 {
+	refLane := uint32(random>>32) % lanes
+	if pass == 0 && slice == 0 {
+		refLane = lane
+	}
+	sameLane := refLane == lane
+
+	// Size of the reference area and where it starts
+	var area, start uint32
+	if pass == 0 {
+		area = slice * segmentLength
+		if sameLane {
+			area += index
+		}
+	} else {
+		area = laneLength - segmentLength
+		if sameLane {
+			area += index
+		}
+		start = ((slice + 1) % argon2SyncPoints) * segmentLength
+	}
+	if index == 0 || sameLane {
+		area--
+	}
+
+	x := random & 0xFFFFFFFF
+	x = (x * x) >> 32
+	x = (uint64(area) * x) >> 32
+	relative := uint64(area) - 1 - x
+	return refLane*laneLength + uint32((uint64(start)+relative)%uint64(laneLength))
+}
+
+// argon2Compress is the compression function G. With xor the result is XORed into out
+// (Argon2 1.3 passes after the first) instead of replacing it.
+func argon2Compress(out, x, y *argon2Block, xor bool) {
+	var r, q argon2Block
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+	q = r
+
+	// Rows of 16 words, then columns of pairs of words
+	for i := 0; i < argon2BlockWords; i += 16 {
+		argon2Round(&q, i, i+1, i+2, i+3, i+4, i+5, i+6, i+7, i+8, i+9, i+10, i+11, i+12, i+13, i+14, i+15)
+	}
+	for i := 0; i < 16; i += 2 {
+		argon2Round(&q, i, i+1, i+16, i+17, i+32, i+33, i+48, i+49, i+64, i+65, i+80, i+81, i+96, i+97, i+112, i+113)
+	}
+
+	for i := range q {
+		if xor {
+			out[i] ^= r[i] ^ q[i]
+		} else {
+			out[i] = r[i] ^ q[i]
+		}
+	}
+}
+
+// argon2Round is the BLAKE2b round with multiplications (BlaMka) over 16 words of v
+func argon2Round(v *argon2Block, i0, i1, i2, i3, i4, i5, i6, i7, i8, i9, i10, i11, i12, i13, i14, i15 int) {
+	argon2Mix(v, i0, i4, i8, i12)
+	argon2Mix(v, i1, i5, i9, i13)
+	argon2Mix(v, i2, i6, i10, i14)
+	argon2Mix(v, i3, i7, i11, i15)
+	argon2Mix(v, i0, i5, i10, i15)
+	argon2Mix(v, i1, i6, i11, i12)
+	argon2Mix(v, i2, i7, i8, i13)
+	argon2Mix(v, i3, i4, i9, i14)
+}
+
+func argon2Mix(v *argon2Block, a, b, c, d int) {
+	blaMka := func(x, y uint64) uint64 {
+		return x + y + 2*uint64(uint32(x))*uint64(uint32(y))
+	}
+	v[a] = blaMka(v[a], v[b])
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] = blaMka(v[c], v[d])
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] = blaMka(v[a], v[b])
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] = blaMka(v[c], v[d])
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
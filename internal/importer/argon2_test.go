@@ -0,0 +1,44 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestArgon2d(t *testing.T) {
+	// RFC 9106 section 5.1 test vector
+	got := argon2Key(bytes.Repeat([]byte{0x01}, 32), argon2Params{
+		mode:        argon2d,
+		version:     argon2Version13,
+		salt:        bytes.Repeat([]byte{0x02}, 16),
+		secret:      bytes.Repeat([]byte{0x03}, 8),
+		data:        bytes.Repeat([]byte{0x04}, 12),
+		iterations:  3,
+		memoryKiB:   32,
+		parallelism: 4,
+		keyLen:      32,
+	})
+	want := "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("argon2d = %x, want %s", got, want)
+	}
+}
+
+func TestArgon2id(t *testing.T) {
+	password, salt := []byte("password"), []byte("somesaltsomesalt")
+	got := argon2Key(password, argon2Params{
+		mode:        argon2id,
+		version:     argon2Version13,
+		salt:        salt,
+		iterations:  2,
+		memoryKiB:   1024,
+		parallelism: 2,
+		keyLen:      32,
+	})
+	if want := argon2.IDKey(password, salt, 2, 1024, 2, 32); !bytes.Equal(got, want) {
+		t.Errorf("argon2id = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,349 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// authenticator.go reads authenticator app exports: Google Authenticator's
+// otpauth-migration:// transfer QR codes and Aegis and 2FAS JSON backups. Each
+// account becomes a credential holding only the TOTP configuration, named after
+// the issuer, for VaultService.ImportTOTP.
+
+// migrationScheme prefixes Google Authenticator transfer QR codes
+const migrationScheme = "otpauth-migration://"
+
+// Enum values of Google Authenticator's MigrationPayload.OtpParameters
+const (
+	migrationAlgorithmSHA1   = 1
+	migrationAlgorithmSHA256 = 2
+	migrationAlgorithmSHA512 = 3
+	migrationAlgorithmMD5    = 4
+
+	migrationDigitsEight = 2
+
+	migrationTypeHOTP = 1
+)
+
+// ErrEncryptedBackup indicates an authenticator backup protected by a password
+var ErrEncryptedBackup = errors.New("encrypted authenticator backups are not supported: export the backup without encryption")
+
+// IsAuthenticatorMigrationURI reports whether s is a Google Authenticator transfer URI
+func IsAuthenticatorMigrationURI(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), migrationScheme)
+}
+
+// ParseAuthenticatorExport reads a file holding Google Authenticator transfer URIs
+// (one per line, as decoded from the QR codes), an Aegis JSON export or a 2FAS
+// backup
+func ParseAuthenticatorExport(data []byte) (*Result, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if IsAuthenticatorMigrationURI(string(data)) {
+		result := &Result{}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			if err := parseMigrationURI(result, line); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("not an authenticator export (expected otpauth-migration:// URIs, Aegis or 2FAS JSON): %w", err)
+	}
+	switch {
+	case probe["db"] != nil:
+		return parseAegis(data)
+	case probe["services"] != nil || probe["servicesEncrypted"] != nil:
+		return parse2FAS(data)
+	}
+	return nil, fmt.Errorf("unrecognized JSON: expected an Aegis or 2FAS export")
+}
+
+// ParseAuthenticatorMigration decodes Google Authenticator transfer URIs
+// (otpauth-migration://offline?data=...). Large exports are split over several
+// QR codes; pass all of them.
+func ParseAuthenticatorMigration(uris []string) (*Result, error) {
+	result := &Result{}
+	for _, uri := range uris {
+		if err := parseMigrationURI(result, uri); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// parseMigrationURI decodes the MigrationPayload protobuf of one transfer URI
+func parseMigrationURI(result *Result, uri string) error {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || !strings.EqualFold(u.Scheme, "otpauth-migration") {
+		return fmt.Errorf("invalid transfer URI: expected otpauth-migration://offline?data=...")
+	}
+	// The data is standard base64, but QR scanners often leave '+' undecoded as a space
+	encoded := strings.ReplaceAll(u.Query().Get("data"), " ", "+")
+	payload, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		payload, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	}
+	if err != nil || len(payload) == 0 {
+		return fmt.Errorf("invalid transfer URI: data is not valid base64")
+	}
+
+	// MigrationPayload: repeated OtpParameters otp_parameters = 1; the batch fields are not needed
+	return readProtobuf(payload, func(field int, value []byte, _ uint64) error {
+		if field != 1 {
+			return nil
+		}
+		var secret []byte
+		var name, issuer string
+		var algorithm, digits, otpType uint64
+		err := readProtobuf(value, func(field int, value []byte, number uint64) error {
+			switch field {
+			case 1:
+				secret = value
+			case 2:
+				name = string(value)
+			case 3:
+				issuer = string(value)
+			case 4:
+				algorithm = number
+			case 5:
+				digits = number
+			case 6:
+				otpType = number
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Names are "Issuer:account" or just the account
+		if prefix, account, found := strings.Cut(name, ":"); found && (issuer == "" || strings.EqualFold(prefix, issuer)) {
+			issuer, name = strings.TrimSpace(prefix), account
+		}
+		label := serviceName(issuer)
+		if issuer == "" {
+			label = serviceName(name)
+		}
+		if otpType == migrationTypeHOTP {
+			result.warn(label, "counter-based (HOTP) codes are not supported, skipped")
+			return nil
+		}
+
+		algorithmName := "SHA1"
+		switch algorithm {
+		case migrationAlgorithmSHA256:
+			algorithmName = "SHA256"
+		case migrationAlgorithmSHA512:
+			algorithmName = "SHA512"
+		case migrationAlgorithmMD5:
+			result.warn(label, "MD5 codes are not supported, skipped")
+			return nil
+		}
+		digitCount := 6
+		if digits == migrationDigitsEight {
+			digitCount = 8
+		}
+		secretBase32 := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+		addTOTPAccount(result, issuer, name, secretBase32, algorithmName, digitCount, 30)
+		return nil
+	})
+}
+
+// readProtobuf calls fn for each field of a protobuf message: value holds the
+// bytes of length-delimited fields, number the value of varint fields.
+// Fixed-width fields are skipped.
+func readProtobuf(data []byte, fn func(field int, value []byte, number uint64) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("invalid transfer data: malformed field")
+		}
+		data = data[n:]
+
+		field := int(key >> 3) // #nosec G115 -- field numbers are small
+		switch key & 7 {
+		case 0: // varint
+			number, n := binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("invalid transfer data: malformed varint")
+			}
+			data = data[n:]
+			if err := fn(field, nil, number); err != nil {
+				return err
+			}
+		case 2: // length-delimited
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return fmt.Errorf("invalid transfer data: truncated field")
+			}
+			value := data[n : n+int(length)] // #nosec G115 -- bounded by len(data) above
+			data = data[n+int(length):]      // #nosec G115 -- bounded by len(data) above
+			if err := fn(field, value, 0); err != nil {
+				return err
+			}
+		case 1: // 64-bit
+			if len(data) < 8 {
+				return fmt.Errorf("invalid transfer data: truncated field")
+			}
+			data = data[8:]
+		case 5: // 32-bit
+			if len(data) < 4 {
+				return fmt.Errorf("invalid transfer data: truncated field")
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("invalid transfer data: unsupported wire type %d", key&7)
+		}
+	}
+	return nil
+}
+
+// aegisExport is the layout of an Aegis vault export. In encrypted exports
+// header.slots is set and db is an encrypted string instead of an object.
+type aegisExport struct {
+	Header struct {
+		Slots json.RawMessage `json:"slots"`
+	} `json:"header"`
+	DB json.RawMessage `json:"db"`
+}
+
+type aegisDB struct {
+	Entries []struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Issuer string `json:"issuer"`
+		Info   struct {
+			Secret string `json:"secret"`
+			Algo   string `json:"algo"`
+			Digits int    `json:"digits"`
+			Period int    `json:"period"`
+		} `json:"info"`
+	} `json:"entries"`
+}
+
+// parseAegis reads an unencrypted Aegis export
+func parseAegis(data []byte) (*Result, error) {
+	var export aegisExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse Aegis export: %w", err)
+	}
+	var db aegisDB
+	if len(export.DB) > 0 && export.DB[0] == '"' {
+		return nil, ErrEncryptedBackup
+	}
+	if err := json.Unmarshal(export.DB, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse Aegis export: %w", err)
+	}
+
+	result := &Result{}
+	for _, entry := range db.Entries {
+		if !strings.EqualFold(entry.Type, "totp") {
+			result.warn(serviceName(entry.Issuer), "%s codes are not supported, skipped", entry.Type)
+			continue
+		}
+		addTOTPAccount(result, entry.Issuer, entry.Name, entry.Info.Secret, entry.Info.Algo, entry.Info.Digits, entry.Info.Period)
+	}
+	return result, nil
+}
+
+// twoFASExport is the layout of a 2FAS backup (.2fas). Encrypted backups hold
+// servicesEncrypted instead of services.
+type twoFASExport struct {
+	Services []struct {
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+		OTP    struct {
+			Account   string `json:"account"`
+			Issuer    string `json:"issuer"`
+			Digits    int    `json:"digits"`
+			Period    int    `json:"period"`
+			Algorithm string `json:"algorithm"`
+			TokenType string `json:"tokenType"`
+		} `json:"otp"`
+	} `json:"services"`
+	ServicesEncrypted string `json:"servicesEncrypted"`
+}
+
+// parse2FAS reads an unencrypted 2FAS backup
+func parse2FAS(data []byte) (*Result, error) {
+	var export twoFASExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse 2FAS backup: %w", err)
+	}
+	if export.ServicesEncrypted != "" && len(export.Services) == 0 {
+		return nil, ErrEncryptedBackup
+	}
+
+	result := &Result{}
+	for _, service := range export.Services {
+		issuer := service.OTP.Issuer
+		if issuer == "" {
+			issuer = service.Name
+		}
+		if tokenType := service.OTP.TokenType; tokenType != "" && !strings.EqualFold(tokenType, "totp") {
+			result.warn(serviceName(issuer), "%s codes are not supported, skipped", tokenType)
+			continue
+		}
+		addTOTPAccount(result, issuer, service.OTP.Account, service.Secret, service.OTP.Algorithm, service.OTP.Digits, service.OTP.Period)
+	}
+	return result, nil
+}
+
+// addTOTPAccount adds an authenticator account as a credential holding its TOTP
+// configuration. Zero digits and period mean the defaults; accounts pass-cli
+// cannot generate codes for are skipped with a warning.
+func addTOTPAccount(result *Result, issuer, account, secret, algorithm string, digits, period int) {
+	issuer, account = strings.TrimSpace(issuer), strings.TrimSpace(account)
+	service := issuer
+	if service == "" {
+		service = serviceName(account)
+	}
+
+	config := vault.DefaultTOTPConfig()
+	if algorithm != "" {
+		config.Algorithm = strings.ToUpper(algorithm)
+	}
+	if digits != 0 {
+		config.Digits = digits
+	}
+	if period != 0 {
+		config.Period = period
+	}
+	switch {
+	case config.Algorithm != "SHA1" && config.Algorithm != "SHA256" && config.Algorithm != "SHA512":
+		result.warn(service, "%s codes are not supported, skipped", config.Algorithm)
+		return
+	case config.Digits != 6 && config.Digits != 8:
+		result.warn(service, "%d-digit codes are not supported, skipped", config.Digits)
+		return
+	case config.Period < 1 || config.Period > 300:
+		result.warn(service, "a period of %d seconds is not supported, skipped", config.Period)
+		return
+	}
+
+	result.Credentials = append(result.Credentials, vault.Credential{
+		Service:       service,
+		Type:          vault.RecordTypeNote,
+		Username:      account,
+		TOTPSecret:    strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "=")),
+		TOTPAlgorithm: config.Algorithm,
+		TOTPDigits:    config.Digits,
+		TOTPPeriod:    config.Period,
+		TOTPIssuer:    issuer,
+	})
+}
@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// protobufField encodes a length-delimited (string/bytes/message) or varint field
+func protobufField(field int, value interface{}) []byte {
+	switch v := value.(type) {
+	case uint64:
+		return binary.AppendUvarint(binary.AppendUvarint(nil, uint64(field<<3)), v)
+	case string:
+		return protobufField(field, []byte(v))
+	default:
+		data := v.([]byte)
+		out := binary.AppendUvarint(nil, uint64(field<<3|2))
+		out = binary.AppendUvarint(out, uint64(len(data)))
+		return append(out, data...)
+	}
+}
+
+// migrationURI builds a transfer URI holding the given OtpParameters messages
+func migrationURI(accounts ...[]byte) string {
+	var payload []byte
+	for _, account := range accounts {
+		payload = append(payload, protobufField(1, account)...)
+	}
+	payload = append(payload, protobufField(2, uint64(1))...) // version
+	return "otpauth-migration://offline?data=" + url.QueryEscape(base64.StdEncoding.EncodeToString(payload))
+}
+
+func otpParameters(secret, name, issuer string, algorithm, digits, otpType uint64) []byte {
+	var out []byte
+	out = append(out, protobufField(1, []byte(secret))...)
+	out = append(out, protobufField(2, name)...)
+	out = append(out, protobufField(3, issuer)...)
+	out = append(out, protobufField(4, algorithm)...)
+	out = append(out, protobufField(5, digits)...)
+	out = append(out, protobufField(6, otpType)...)
+	return out
+}
+
+func TestParseAuthenticatorMigration(t *testing.T) {
+	uri := migrationURI(
+		otpParameters("Hello!\xde\xad\xbe\xef", "GitHub:alice", "GitHub", 1, 1, 2),
+		otpParameters("12345678901234567890", "bob@example.com", "", 3, 2, 2),
+		otpParameters("counter", "hotp", "Bank", 1, 1, 1),
+	)
+
+	result, err := ParseAuthenticatorMigration([]string{uri})
+	if err != nil {
+		t.Fatalf("ParseAuthenticatorMigration() failed: %v", err)
+	}
+	if len(result.Credentials) != 2 {
+		t.Fatalf("got %d accounts, want 2 (HOTP skipped)", len(result.Credentials))
+	}
+
+	github := result.Credentials[0]
+	if github.Service != "GitHub" || github.Username != "alice" || github.TOTPIssuer != "GitHub" ||
+		github.TOTPSecret != "JBSWY3DPEHPK3PXP" || github.TOTPAlgorithm != "SHA1" || github.TOTPDigits != 6 || github.TOTPPeriod != 30 {
+		t.Errorf("GitHub = %+v", github)
+	}
+	if bob := result.Credentials[1]; bob.Service != "bob@example.com" || bob.TOTPAlgorithm != "SHA512" || bob.TOTPDigits != 8 {
+		t.Errorf("account without issuer = %+v", bob)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "HOTP") {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+
+	// A file with one URI per line (several QR codes) reads the same way
+	fromFile, err := ParseAuthenticatorExport([]byte(uri + "\n" + migrationURI(otpParameters("x", "a", "B", 0, 0, 0)) + "\n"))
+	if err != nil || len(fromFile.Credentials) != 3 {
+		t.Errorf("ParseAuthenticatorExport() = %+v, %v", fromFile, err)
+	}
+
+	for _, bad := range []string{"otpauth://totp/x?secret=AAAA", "otpauth-migration://offline?data=%%%", "otpauth-migration://offline?data=" + base64.StdEncoding.EncodeToString([]byte{0x0a, 0x50})} {
+		if _, err := ParseAuthenticatorMigration([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseAuthenticatorExport(t *testing.T) {
+	aegis := `{"version": 1, "header": {"slots": null, "params": null}, "db": {"version": 3, "entries": [
+		{"type": "totp", "name": "alice", "issuer": "GitHub", "info": {"secret": "JBSWY3DPEHPK3PXP", "algo": "SHA256", "digits": 8, "period": 60}},
+		{"type": "steam", "name": "gamer", "issuer": "Steam", "info": {"secret": "JBSWY3DPEHPK3PXP", "algo": "SHA1", "digits": 5, "period": 30}}
+	]}}`
+	result, err := ParseAuthenticatorExport([]byte(aegis))
+	if err != nil {
+		t.Fatalf("Aegis: %v", err)
+	}
+	if len(result.Credentials) != 1 || len(result.Warnings) != 1 {
+		t.Fatalf("Aegis result = %+v", result)
+	}
+	if github := result.Credentials[0]; github.TOTPAlgorithm != "SHA256" || github.TOTPDigits != 8 || github.TOTPPeriod != 60 || github.Username != "alice" {
+		t.Errorf("Aegis entry = %+v", github)
+	}
+
+	twoFAS := `{"schemaVersion": 4, "services": [
+		{"name": "Dropbox", "secret": "jbsw y3dp ehpk 3pxp", "otp": {"account": "carol", "digits": 6, "period": 30, "algorithm": "SHA1", "tokenType": "TOTP"}},
+		{"name": "Old", "secret": "JBSWY3DPEHPK3PXP", "otp": {"tokenType": "HOTP"}}
+	]}`
+	result, err = ParseAuthenticatorExport([]byte(twoFAS))
+	if err != nil {
+		t.Fatalf("2FAS: %v", err)
+	}
+	if len(result.Credentials) != 1 || result.Credentials[0].Service != "Dropbox" || result.Credentials[0].TOTPSecret != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("2FAS result = %+v", result)
+	}
+
+	for name, encrypted := range map[string]string{
+		"Aegis": `{"version": 1, "header": {"slots": [{"type": 1}]}, "db": "c2VjcmV0"}`,
+		"2FAS":  `{"schemaVersion": 4, "services": [], "servicesEncrypted": "abc"}`,
+	} {
+		if _, err := ParseAuthenticatorExport([]byte(encrypted)); !errors.Is(err, ErrEncryptedBackup) {
+			t.Errorf("%s encrypted error = %v, want ErrEncryptedBackup", name, err)
+		}
+	}
+	if _, err := ParseAuthenticatorExport([]byte(`{"items": []}`)); err == nil {
+		t.Error("expected error for unrecognized JSON")
+	}
+}
@@ -0,0 +1,307 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// Bitwarden item types
+const (
+	bitwardenTypeLogin    = 1
+	bitwardenTypeNote     = 2
+	bitwardenTypeCard     = 3
+	bitwardenTypeIdentity = 4
+	bitwardenTypeSSHKey   = 5
+)
+
+// Bitwarden custom field types (text and boolean fields are imported as plain values)
+const (
+	bitwardenFieldHidden = 1
+	bitwardenFieldLinked = 3
+)
+
+// ErrEncryptedExport indicates a Bitwarden export that was encrypted when exported
+var ErrEncryptedExport = errors.New("encrypted Bitwarden exports are not supported: export the vault as unencrypted JSON")
+
+// bitwardenExport is the layout of 'bw export --format json' and the web vault's
+// JSON export. Organization exports list collections instead of folders.
+type bitwardenExport struct {
+	Encrypted         bool              `json:"encrypted"`
+	PasswordProtected bool              `json:"passwordProtected"`
+	Folders           []bitwardenFolder `json:"folders"`
+	Collections       []bitwardenFolder `json:"collections"`
+	Items             []bitwardenItem   `json:"items"`
+}
+
+type bitwardenFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bitwardenItem struct {
+	Type          int              `json:"type"`
+	Name          string           `json:"name"`
+	Notes         string           `json:"notes"`
+	Favorite      bool             `json:"favorite"`
+	FolderID      string           `json:"folderId"`
+	CollectionIDs []string         `json:"collectionIds"`
+	Fields        []bitwardenField `json:"fields"`
+	Login         *bitwardenLogin  `json:"login"`
+	Card          *bitwardenCard   `json:"card"`
+	Identity      *bitwardenIdent  `json:"identity"`
+	SSHKey        *bitwardenSSH    `json:"sshKey"`
+}
+
+type bitwardenField struct {
+	Name  string  `json:"name"`
+	Value *string `json:"value"`
+	Type  int     `json:"type"`
+}
+
+type bitwardenLogin struct {
+	Username string         `json:"username"`
+	Password string         `json:"password"`
+	TOTP     string         `json:"totp"`
+	URIs     []bitwardenURI `json:"uris"`
+}
+
+type bitwardenURI struct {
+	URI   string `json:"uri"`
+	Match *int   `json:"match"` // null means the account's default (domain)
+}
+
+type bitwardenCard struct {
+	CardholderName string `json:"cardholderName"`
+	Brand          string `json:"brand"`
+	Number         string `json:"number"`
+	ExpMonth       string `json:"expMonth"`
+	ExpYear        string `json:"expYear"`
+	Code           string `json:"code"`
+}
+
+type bitwardenIdent struct {
+	Title          string `json:"title"`
+	FirstName      string `json:"firstName"`
+	MiddleName     string `json:"middleName"`
+	LastName       string `json:"lastName"`
+	Address1       string `json:"address1"`
+	Address2       string `json:"address2"`
+	Address3       string `json:"address3"`
+	City           string `json:"city"`
+	State          string `json:"state"`
+	PostalCode     string `json:"postalCode"`
+	Country        string `json:"country"`
+	Company        string `json:"company"`
+	Email          string `json:"email"`
+	Phone          string `json:"phone"`
+	SSN            string `json:"ssn"`
+	Username       string `json:"username"`
+	PassportNumber string `json:"passportNumber"`
+	LicenseNumber  string `json:"licenseNumber"`
+}
+
+type bitwardenSSH struct {
+	PrivateKey     string `json:"privateKey"`
+	PublicKey      string `json:"publicKey"`
+	KeyFingerprint string `json:"keyFingerprint"`
+}
+
+// ParseBitwarden converts an unencrypted Bitwarden JSON export. Folders (or, for
+// organization exports, the first collection) become categories and favorites
+// get the "favorite" tag. Password history and linked fields are not imported.
+func ParseBitwarden(data []byte) (*Result, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("not a Bitwarden JSON export: %w", err)
+	}
+	if export.Encrypted || export.PasswordProtected {
+		return nil, ErrEncryptedExport
+	}
+
+	folders := make(map[string]string, len(export.Folders)+len(export.Collections))
+	for _, folder := range append(export.Folders, export.Collections...) {
+		folders[folder.ID] = folder.Name
+	}
+
+	result := &Result{}
+	for _, item := range export.Items {
+		credential := vault.Credential{
+			Service:  serviceName(item.Name),
+			Notes:    item.Notes,
+			Category: folders[item.FolderID],
+		}
+		if credential.Category == "" && len(item.CollectionIDs) > 0 {
+			credential.Category = folders[item.CollectionIDs[0]]
+		}
+		if item.Favorite {
+			credential.Tags = []string{"favorite"}
+		}
+
+		switch item.Type {
+		case bitwardenTypeLogin:
+			convertBitwardenLogin(result, &credential, item.Login)
+		case bitwardenTypeNote:
+			credential.Type = vault.RecordTypeNote
+		case bitwardenTypeCard:
+			convertBitwardenCard(&credential, item.Card)
+		case bitwardenTypeIdentity:
+			convertBitwardenIdentity(&credential, item.Identity)
+		case bitwardenTypeSSHKey:
+			convertBitwardenSSHKey(result, &credential, item.SSHKey)
+		default:
+			result.warn(credential.Service, "unknown item type %d, skipped", item.Type)
+			continue
+		}
+
+		for _, field := range item.Fields {
+			if field.Type == bitwardenFieldLinked {
+				result.warn(credential.Service, "linked field %q skipped", field.Name)
+				continue
+			}
+			if field.Value == nil {
+				continue
+			}
+			addField(&credential, field.Name, *field.Value, field.Type == bitwardenFieldHidden)
+		}
+
+		result.Credentials = append(result.Credentials, credential)
+	}
+	return result, nil
+}
+
+// convertBitwardenLogin maps a login item. Logins without a password (for example
+// TOTP-only entries) become notes, since pass-cli logins need a password.
+func convertBitwardenLogin(result *Result, credential *vault.Credential, login *bitwardenLogin) {
+	credential.Type = vault.RecordTypeLogin
+	if login == nil {
+		login = &bitwardenLogin{}
+	}
+
+	credential.Username = login.Username
+	credential.Password = []byte(login.Password)
+	if login.Password == "" {
+		credential.Type = vault.RecordTypeNote
+		result.warn(credential.Service, "login has no password, imported as a note")
+	}
+
+	for _, uri := range login.URIs {
+		if strings.TrimSpace(uri.URI) == "" {
+			continue
+		}
+		spec, ok := bitwardenURLRule(uri)
+		if !ok {
+			result.warn(credential.Service, "URL %q has match detection \"never\", skipped", uri.URI)
+			continue
+		}
+		if !addURL(credential, spec) {
+			result.warn(credential.Service, "URL %q is not usable, skipped", uri.URI)
+		}
+	}
+
+	setTOTP(result, credential, login.TOTP)
+}
+
+// bitwardenURLRule converts a URI and its match detection to ParseURLRule form.
+// Returns false for "never", which has no equivalent.
+func bitwardenURLRule(uri bitwardenURI) (string, bool) {
+	if uri.Match == nil {
+		return uri.URI, true
+	}
+	switch *uri.Match {
+	case 1: // Host
+		return vault.URLMatchHost + ":" + uri.URI, true
+	case 2: // Starts with
+		return vault.URLMatchPrefix + ":" + uri.URI, true
+	case 3: // Exact
+		return vault.URLMatchRegex + ":^" + regexp.QuoteMeta(uri.URI) + "$", true
+	case 4: // Regular expression
+		return vault.URLMatchRegex + ":" + uri.URI, true
+	case 5: // Never
+		return "", false
+	default: // Base domain
+		return uri.URI, true
+	}
+}
+
+// convertBitwardenCard maps a card item onto the card record fields
+func convertBitwardenCard(credential *vault.Credential, card *bitwardenCard) {
+	credential.Type = vault.RecordTypeCard
+	if card == nil {
+		return
+	}
+
+	expiry := card.ExpMonth
+	if card.ExpYear != "" {
+		if expiry != "" {
+			expiry += "/"
+		}
+		expiry += card.ExpYear
+	}
+
+	addField(credential, "cardholder", card.CardholderName, false)
+	addField(credential, "number", card.Number, true)
+	addField(credential, "expiry", expiry, false)
+	addField(credential, "cvv", card.Code, true)
+	addField(credential, "brand", card.Brand, false)
+}
+
+// convertBitwardenIdentity maps an identity item. The name parts are joined into
+// full-name (falling back to the item name) and the address lines into address.
+func convertBitwardenIdentity(credential *vault.Credential, identity *bitwardenIdent) {
+	credential.Type = vault.RecordTypeIdentity
+	if identity == nil {
+		identity = &bitwardenIdent{}
+	}
+
+	fullName := joinNonEmpty(" ", identity.Title, identity.FirstName, identity.MiddleName, identity.LastName)
+	if fullName == "" {
+		fullName = credential.Service
+	}
+	address := joinNonEmpty("\n",
+		identity.Address1, identity.Address2, identity.Address3,
+		joinNonEmpty(" ", identity.City, identity.State, identity.PostalCode),
+		identity.Country)
+
+	credential.Username = identity.Username
+	addField(credential, "full-name", fullName, false)
+	addField(credential, "email", identity.Email, false)
+	addField(credential, "phone", identity.Phone, false)
+	addField(credential, "address", address, false)
+	addField(credential, "id-number", identity.SSN, true)
+	addField(credential, "company", identity.Company, false)
+	addField(credential, "passport-number", identity.PassportNumber, true)
+	addField(credential, "license-number", identity.LicenseNumber, true)
+}
+
+// convertBitwardenSSHKey maps an SSH key item. Items without a private key
+// become notes, since the ssh-key record requires one.
+func convertBitwardenSSHKey(result *Result, credential *vault.Credential, key *bitwardenSSH) {
+	credential.Type = vault.RecordTypeSSHKey
+	if key == nil || key.PrivateKey == "" {
+		credential.Type = vault.RecordTypeNote
+		result.warn(credential.Service, "SSH key has no private key, imported as a note")
+		if key == nil {
+			return
+		}
+	}
+
+	addField(credential, "private-key", key.PrivateKey, true)
+	addField(credential, "public-key", key.PublicKey, false)
+	addField(credential, "fingerprint", key.KeyFingerprint, false)
+}
+
+// joinNonEmpty joins the non-empty, trimmed parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,277 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// Credential fields a CSV column can be mapped to
+const (
+	CSVService  = "service"
+	CSVUsername = "username"
+	CSVPassword = "password"
+	CSVURL      = "url"
+	CSVNotes    = "notes"
+	CSVTOTP     = "totp"
+	CSVCategory = "category"
+	CSVTags     = "tags"
+	CSVFavorite = "favorite" // "1", "true" or "yes" adds the "favorite" tag
+)
+
+// CSV presets for the export formats of common password managers and browsers
+const (
+	CSVPresetChrome   = "chrome" // Also Edge, Brave and other Chromium browsers
+	CSVPresetFirefox  = "firefox"
+	CSVPresetLastPass = "lastpass"
+	CSVPresetSafari   = "safari" // Also Apple Passwords
+	CSVPresetGeneric  = "generic"
+)
+
+// lastPassSecureNoteURL marks secure notes in LastPass exports
+const lastPassSecureNoteURL = "http://sn"
+
+// ErrEmptyCSV indicates a CSV file without a header row
+var ErrEmptyCSV = errors.New("CSV file is empty")
+
+// csvPresets map credential fields to the (lowercase) column names of each format.
+// Columns a preset does not map, such as Firefox's timestamps, are not imported.
+var csvPresets = map[string]map[string]string{
+	CSVPresetChrome: {
+		CSVService: "name", CSVURL: "url", CSVUsername: "username", CSVPassword: "password", CSVNotes: "note",
+	},
+	CSVPresetFirefox: {
+		CSVURL: "url", CSVUsername: "username", CSVPassword: "password",
+	},
+	CSVPresetLastPass: {
+		CSVService: "name", CSVURL: "url", CSVUsername: "username", CSVPassword: "password",
+		CSVTOTP: "totp", CSVNotes: "extra", CSVCategory: "grouping", CSVFavorite: "fav",
+	},
+	CSVPresetSafari: {
+		CSVService: "title", CSVURL: "url", CSVUsername: "username", CSVPassword: "password",
+		CSVNotes: "notes", CSVTOTP: "otpauth",
+	},
+}
+
+// csvAliases are the column names recognized by the generic format, most common
+// first. Other columns become custom fields.
+var csvAliases = map[string][]string{
+	CSVService:  {"service", "name", "title", "account", "site", "item"},
+	CSVUsername: {"username", "user", "login", "login_username", "user name", "login name", "email"},
+	CSVPassword: {"password", "login_password", "pass"},
+	CSVURL:      {"url", "login_uri", "website", "web site", "uri", "address"},
+	CSVNotes:    {"notes", "note", "extra", "comments", "comment"},
+	CSVTOTP:     {"totp", "login_totp", "otp", "otpauth", "2fa"},
+	CSVCategory: {"category", "folder", "group", "grouping"},
+	CSVTags:     {"tags", "tag"},
+	CSVFavorite: {"favorite", "fav"},
+}
+
+// CSVPresets returns the supported presets
+func CSVPresets() []string {
+	return []string{CSVPresetChrome, CSVPresetFirefox, CSVPresetLastPass, CSVPresetSafari, CSVPresetGeneric}
+}
+
+// CSVFields returns the credential fields columns can be mapped to
+func CSVFields() []string {
+	return []string{CSVService, CSVUsername, CSVPassword, CSVURL, CSVNotes, CSVTOTP, CSVCategory, CSVTags, CSVFavorite}
+}
+
+// ParseCSVMapping parses a --map value ("service=name,url=login uri") into
+// credential field -> column name
+func ParseCSVMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=column)", pair)
+		}
+		if _, known := csvAliases[field]; !known {
+			return nil, fmt.Errorf("unknown field %q in mapping (valid: %s)", field, strings.Join(CSVFields(), ", "))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+// DetectCSVPreset guesses the export format from the header row. Files that
+// match no known format are "generic".
+func DetectCSVPreset(data []byte) (string, error) {
+	header, _, err := readCSV(data)
+	if err != nil {
+		return "", err
+	}
+	columns := make(map[string]bool, len(header))
+	for _, column := range header {
+		columns[strings.ToLower(column)] = true
+	}
+
+	switch {
+	case columns["grouping"] && columns["extra"]:
+		return CSVPresetLastPass, nil
+	case columns["httprealm"] || columns["formactionorigin"]:
+		return CSVPresetFirefox, nil
+	case columns["title"] && columns["otpauth"]:
+		return CSVPresetSafari, nil
+	case columns["name"] && columns["url"] && columns["username"] && columns["password"] && len(header) <= 5:
+		return CSVPresetChrome, nil
+	}
+	return CSVPresetGeneric, nil
+}
+
+// ParseCSV reads a CSV export with a header row. The preset gives the column
+// names of known formats; mapping (credential field -> column name, matched
+// case-insensitively) overrides or extends it. With the generic preset columns
+// are recognized by common names and unrecognized columns become custom fields.
+// Entries without a name are named after their website; rows without a password
+// become notes.
+func ParseCSV(data []byte, preset string, mapping map[string]string) (*Result, error) {
+	header, rows, err := readCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		if _, exists := index[strings.ToLower(column)]; !exists {
+			index[strings.ToLower(column)] = i
+		}
+	}
+
+	// columns maps credential fields to column positions
+	columns := make(map[string]int)
+	switch preset {
+	case CSVPresetGeneric:
+		for field, aliases := range csvAliases {
+			for _, alias := range aliases {
+				if i, ok := index[alias]; ok {
+					columns[field] = i
+					break
+				}
+			}
+		}
+	default:
+		presetColumns, ok := csvPresets[preset]
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV preset %q (valid: %s)", preset, strings.Join(CSVPresets(), ", "))
+		}
+		for field, column := range presetColumns {
+			if i, ok := index[column]; ok {
+				columns[field] = i
+			}
+		}
+	}
+	for field, column := range mapping {
+		i, ok := index[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, fmt.Errorf("column %q not found (columns: %s)", column, strings.Join(header, ", "))
+		}
+		columns[field] = i
+	}
+	if _, ok := columns[CSVPassword]; !ok {
+		return nil, fmt.Errorf("no password column found (columns: %s): map one to password", strings.Join(header, ", "))
+	}
+
+	// Generic files keep their other columns as custom fields
+	var extra []int
+	if preset == CSVPresetGeneric {
+		mapped := make(map[int]bool, len(columns))
+		for _, i := range columns {
+			mapped[i] = true
+		}
+		for i := range header {
+			if !mapped[i] {
+				extra = append(extra, i)
+			}
+		}
+	}
+
+	result := &Result{}
+	for _, row := range rows {
+		cell := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		rawURL := strings.TrimSpace(cell(CSVURL))
+		credential := vault.Credential{
+			Service:  csvServiceName(cell(CSVService), rawURL),
+			Username: cell(CSVUsername),
+			Password: []byte(cell(CSVPassword)),
+			Notes:    cell(CSVNotes),
+			Category: strings.Trim(strings.ReplaceAll(strings.TrimSpace(cell(CSVCategory)), `\`, "/"), "/"),
+			Tags:     splitTags(cell(CSVTags)),
+		}
+
+		if rawURL == lastPassSecureNoteURL {
+			rawURL = ""
+		}
+		if rawURL != "" && !addURL(&credential, rawURL) {
+			result.warn(credential.Service, "URL %q is not usable, kept in the field \"website\"", rawURL)
+			addField(&credential, "website", rawURL, false)
+		}
+		setTOTP(result, &credential, cell(CSVTOTP))
+		switch strings.ToLower(strings.TrimSpace(cell(CSVFavorite))) {
+		case "1", "true", "yes":
+			credential.Tags = append(credential.Tags, "favorite")
+		}
+		for _, i := range extra {
+			if i < len(row) {
+				addField(&credential, header[i], row[i], false)
+			}
+		}
+
+		credential.Type = vault.RecordTypeLogin
+		if len(credential.Password) == 0 {
+			credential.Type = vault.RecordTypeNote
+		}
+		result.Credentials = append(result.Credentials, credential)
+	}
+	return result, nil
+}
+
+// readCSV returns the header (trimmed column names) and the data rows
+func readCSV(data []byte) ([]string, [][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1 // Some exporters drop trailing empty cells
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrEmptyCSV
+	}
+
+	header := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		header[i] = strings.TrimSpace(column)
+	}
+	return header, records[1:], nil
+}
+
+// csvServiceName returns the entry's name, or the website's host for exports
+// without names (Firefox)
+func csvServiceName(name, rawURL string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		return strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	return untitled
+}
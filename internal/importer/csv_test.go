@@ -0,0 +1,119 @@
+package importer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+const (
+	chromeCSV = "name,url,username,password,note\n" +
+		"github.com,https://github.com/,alice,s3cret,\"two\nlines\"\n"
+	firefoxCSV = "\xef\xbb\xbf\"url\",\"username\",\"password\",\"httpRealm\",\"formActionOrigin\",\"guid\",\"timeCreated\",\"timeLastUsed\",\"timePasswordChanged\"\n" +
+		"\"https://www.example.com\",\"bob\",\"pw\",,\"https://www.example.com\",\"{1}\",\"1\",\"2\",\"3\"\n"
+	lastPassCSV = "url,username,password,totp,extra,name,grouping,fav\n" +
+		"https://aws.amazon.com,root,aws-pass,JBSWY3DPEHPK3PXP,,AWS,Work\\Cloud,1\n" +
+		"http://sn,,,,wifi: home,Wi-Fi,,0\n"
+	safariCSV = "Title,URL,Username,Password,Notes,OTPAuth\n" +
+		"Apple,https://apple.com,me@icloud.com,pw,,otpauth://totp/Apple?secret=JBSWY3DPEHPK3PXP\n"
+	genericCSV = "Account,Login,Password,Website,Folder,Tags,PIN\n" +
+		"Bank,client42,bank-pass,bank.example.com,Finance,money; on line,1234\n" +
+		",,,,,,\n"
+)
+
+func TestDetectCSVPreset(t *testing.T) {
+	for want, data := range map[string]string{
+		CSVPresetChrome:   chromeCSV,
+		CSVPresetFirefox:  firefoxCSV,
+		CSVPresetLastPass: lastPassCSV,
+		CSVPresetSafari:   safariCSV,
+		CSVPresetGeneric:  genericCSV,
+	} {
+		if got, err := DetectCSVPreset([]byte(data)); err != nil || got != want {
+			t.Errorf("DetectCSVPreset() = %q, %v; want %q", got, err, want)
+		}
+	}
+	if _, err := DetectCSVPreset(nil); !errors.Is(err, ErrEmptyCSV) {
+		t.Errorf("DetectCSVPreset(nil) error = %v, want ErrEmptyCSV", err)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	parse := func(data, preset string, mapping map[string]string) []vault.Credential {
+		t.Helper()
+		result, err := ParseCSV([]byte(data), preset, mapping)
+		if err != nil {
+			t.Fatalf("ParseCSV(%s) failed: %v", preset, err)
+		}
+		return result.Credentials
+	}
+
+	chrome := parse(chromeCSV, CSVPresetChrome, nil)
+	if len(chrome) != 1 || chrome[0].Service != "github.com" || chrome[0].Username != "alice" ||
+		string(chrome[0].Password) != "s3cret" || chrome[0].URL != "https://github.com/" || chrome[0].Notes != "two\nlines" {
+		t.Errorf("chrome = %+v", chrome)
+	}
+
+	firefox := parse(firefoxCSV, CSVPresetFirefox, nil)
+	if len(firefox) != 1 || firefox[0].Service != "example.com" || len(firefox[0].CustomFields) != 0 {
+		t.Errorf("firefox = %+v, want named after the host without extra fields", firefox)
+	}
+
+	lastPass := parse(lastPassCSV, CSVPresetLastPass, nil)
+	if len(lastPass) != 2 {
+		t.Fatalf("lastpass = %+v", lastPass)
+	}
+	if aws := lastPass[0]; aws.Category != "Work/Cloud" || aws.TOTPSecret != "JBSWY3DPEHPK3PXP" || len(aws.Tags) != 1 || aws.Tags[0] != "favorite" {
+		t.Errorf("lastpass login = %+v", aws)
+	}
+	if note := lastPass[1]; note.Type != vault.RecordTypeNote || note.URL != "" || note.Notes != "wifi: home" {
+		t.Errorf("lastpass secure note = %+v", note)
+	}
+
+	if safari := parse(safariCSV, CSVPresetSafari, nil); safari[0].Service != "Apple" || safari[0].TOTPSecret == "" {
+		t.Errorf("safari = %+v", safari)
+	}
+
+	generic := parse(genericCSV, CSVPresetGeneric, nil)
+	if len(generic) != 1 {
+		t.Fatalf("generic = %+v, want the empty row skipped", generic)
+	}
+	bank := generic[0]
+	if bank.Service != "Bank" || bank.Username != "client42" || bank.URL != "bank.example.com" || bank.Category != "Finance" {
+		t.Errorf("generic columns not recognized: %+v", bank)
+	}
+	if len(bank.Tags) != 2 || bank.Tags[1] != "on-line" {
+		t.Errorf("tags = %v", bank.Tags)
+	}
+	if field, ok := bank.GetCustomField("PIN"); !ok || field.Value != "1234" {
+		t.Errorf("unmapped column not kept as a custom field: %+v", bank.CustomFields)
+	}
+
+	// An explicit mapping overrides the preset
+	mapped := parse(genericCSV, CSVPresetGeneric, map[string]string{CSVNotes: "pin", CSVService: "website"})
+	if mapped[0].Notes != "1234" || mapped[0].Service != "bank.example.com" {
+		t.Errorf("mapping not applied: %+v", mapped[0])
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	if _, err := ParseCSV([]byte(chromeCSV), "keeper", nil); err == nil {
+		t.Error("expected error for an unknown preset")
+	}
+	if _, err := ParseCSV([]byte(chromeCSV), CSVPresetChrome, map[string]string{CSVNotes: "comments"}); err == nil {
+		t.Error("expected error for a missing mapped column")
+	}
+	if _, err := ParseCSV([]byte("site,user\na,b\n"), CSVPresetGeneric, nil); err == nil {
+		t.Error("expected error without a password column")
+	}
+
+	if mapping, err := ParseCSVMapping("service=Name, url = Login URI"); err != nil || mapping[CSVService] != "Name" || mapping[CSVURL] != "Login URI" {
+		t.Errorf("ParseCSVMapping() = %v, %v", mapping, err)
+	}
+	for _, spec := range []string{"service", "colour=name", "url="} {
+		if _, err := ParseCSVMapping(spec); err == nil {
+			t.Errorf("ParseCSVMapping(%q) should fail", spec)
+		}
+	}
+}
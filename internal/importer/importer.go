@@ -0,0 +1,104 @@
+// Package importer reads other password managers' export files and converts
+// their entries to vault credentials. Parsers never write to the vault; the
+// credentials they return are passed to VaultService.ImportCredentials, which
+// validates them like any other record.
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// untitled is the service name given to entries without a name
+const untitled = "Untitled"
+
+// Result holds the credentials read from an export file and anything that
+// could not be carried over exactly
+type Result struct {
+	Credentials []vault.Credential
+	Warnings    []string
+}
+
+// warn records a problem with one entry of the export
+func (r *Result) warn(service, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, service+": "+fmt.Sprintf(format, args...))
+}
+
+// serviceName returns the entry's name, or a placeholder for unnamed entries
+func serviceName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return untitled
+	}
+	return name
+}
+
+// splitTags splits a tag list separated by semicolons or commas. Tags from other
+// password managers may contain spaces, pass-cli tags may not: "on call" becomes
+// "on-call".
+func splitTags(list string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(list, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.Join(strings.Fields(tag), "-"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// addField appends a custom field unless the value is empty. Names that are
+// built-in or already used on the credential are made unique (e.g. "url" becomes
+// "custom-url", a second "pin" becomes "pin-2"), since exports do not share
+// pass-cli's field rules.
+func addField(credential *vault.Credential, name, value string, hidden bool) {
+	if value == "" {
+		return
+	}
+
+	name = strings.NewReplacer("=", "-", "\r", " ", "\n", " ").Replace(strings.TrimSpace(name))
+	if name == "" {
+		name = "field"
+	}
+	if vault.ValidateCustomFieldName(name) != nil {
+		name = "custom-" + name
+	}
+	unique := name
+	for n := 2; ; n++ {
+		if _, exists := credential.GetCustomField(unique); !exists {
+			break
+		}
+		unique = fmt.Sprintf("%s-%d", name, n)
+	}
+
+	credential.CustomFields = append(credential.CustomFields, vault.CustomField{Name: unique, Value: value, Hidden: hidden})
+}
+
+// addURL adds a URL rule given in ParseURLRule form. The first domain rule becomes
+// the primary URL. Returns false if the URL is not usable.
+func addURL(credential *vault.Credential, spec string) bool {
+	rule, err := vault.ParseURLRule(spec)
+	if err != nil {
+		return false
+	}
+	if credential.URL == "" && rule.MatchRule() == vault.URLMatchDomain {
+		credential.URL = rule.URL
+		return true
+	}
+	credential.AddURL(rule)
+	return true
+}
+
+// setTOTP sets the credential's TOTP from an otpauth:// URI or base32 secret.
+// Secrets pass-cli cannot generate codes for are kept in a hidden "otp" field.
+func setTOTP(result *Result, credential *vault.Credential, secret string) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return
+	}
+	if err := credential.SetTOTPFromURI(secret); err != nil {
+		result.warn(credential.Service, "TOTP secret not supported (%v), kept in the hidden field \"otp\"", err)
+		addField(credential, "otp", secret, true)
+	}
+}
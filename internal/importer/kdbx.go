@@ -0,0 +1,721 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20/salsa"
+	"golang.org/x/crypto/twofish"
+)
+
+// kdbx.go reads KeePass KDBX 4 databases: the outer header and KDF, the
+// HMAC-protected block stream, payload decryption, the inner header with its
+// stream cipher and attachments, and the XML document.
+
+var (
+	// ErrInvalidKeePassKey indicates a wrong password or key file (the header HMAC does not match)
+	ErrInvalidKeePassKey = errors.New("invalid KeePass password or key file")
+	// ErrUnsupportedKDBX indicates a database that is not in KDBX 4 format
+	ErrUnsupportedKDBX = errors.New("only KDBX 4 databases are supported: save the database in KDBX 4 format (KeePassXC: Database Security > Encryption Settings)")
+)
+
+const (
+	kdbxSignature1 = 0x9AA2D903
+	kdbxSignature2 = 0xB54BFB67
+)
+
+// Outer header field IDs
+const (
+	kdbxHeaderEnd         = 0
+	kdbxHeaderCipherID    = 2
+	kdbxHeaderCompression = 3
+	kdbxHeaderMasterSeed  = 4
+	kdbxHeaderIV          = 7
+	kdbxHeaderKDF         = 11
+)
+
+// Inner header field IDs
+const (
+	kdbxInnerEnd       = 0
+	kdbxInnerStreamID  = 1
+	kdbxInnerStreamKey = 2
+	kdbxInnerBinary    = 3
+)
+
+// Inner stream ciphers protecting values marked Protected="True"
+const (
+	kdbxStreamSalsa20  = 2
+	kdbxStreamChaCha20 = 3
+)
+
+// Cipher and KDF UUIDs
+var (
+	kdbxCipherAES256   = mustUUID("31c1f2e6bf714350be5805216afc5aff")
+	kdbxCipherChaCha20 = mustUUID("d6038a2b8b6f4cb5a524339a31dbb59a")
+	kdbxCipherTwofish  = mustUUID("ad68f29f576f4bb9a36ad47af965346c")
+	kdbxKDFAES         = mustUUID("c9d9f39a628a4460bf740d08c18a4fea")
+	kdbxKDFAESLegacy   = mustUUID("7c02bb8279a74ac0927d114a00648238")
+	kdbxKDFArgon2d     = mustUUID("ef636ddf8c29444b91f7a9a403e30a0c")
+	kdbxKDFArgon2id    = mustUUID("9e298b1956db4773b23dfc3ec6f0a1e6")
+)
+
+// Upper bounds on KDF costs accepted from a database header, so a crafted file
+// cannot exhaust memory or keep the import busy indefinitely. They are well above
+// what KeePass and KeePassXC configure, even after a one-second benchmark.
+const (
+	kdbxMaxAESRounds         = 500_000_000
+	kdbxMaxArgon2Iterations  = 1024
+	kdbxMaxArgon2MemoryKiB   = 2 * 1024 * 1024
+	kdbxMaxArgon2Parallelism = 256
+)
+
+// kdbxSalsa20Nonce is the fixed nonce of the Salsa20 inner stream
+var kdbxSalsa20Nonce = []byte{0xE8, 0x30, 0x09, 0x4B, 0x97, 0x20, 0x5D, 0x2A}
+
+func mustUUID(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 16 {
+		panic("invalid UUID " + s)
+	}
+	return string(b)
+}
+
+// kdbxDatabase is the decrypted content of a database
+type kdbxDatabase struct {
+	Root       kdbxGroup
+	RecycleBin string // UUID of the recycle bin group (empty when disabled)
+	Binaries   [][]byte
+}
+
+type kdbxGroup struct {
+	UUID    string
+	Name    string
+	Entries []kdbxEntry
+	Groups  []kdbxGroup
+}
+
+type kdbxEntry struct {
+	Strings  []kdbxString
+	Binaries []kdbxBinaryRef
+	Tags     string
+	Expires  bool
+	Expiry   time.Time
+}
+
+type kdbxString struct {
+	Key       string
+	Value     string
+	Protected bool
+}
+
+type kdbxBinaryRef struct {
+	Name string
+	Ref  int
+}
+
+// kdbxCompositeKey combines the password and key file into the composite key.
+// An empty password is left out when a key file is given.
+func kdbxCompositeKey(password, keyFile []byte) ([]byte, error) {
+	h := sha256.New()
+	if len(password) > 0 || keyFile == nil {
+		sum := sha256.Sum256(password)
+		h.Write(sum[:])
+	}
+	if keyFile != nil {
+		key, err := kdbxKeyFileKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(key)
+	}
+	return h.Sum(nil), nil
+}
+
+// kdbxKeyFileKey returns the 32-byte key of a key file: XML key files (versions 1
+// and 2), raw 32-byte keys, 64 hex characters, or the SHA-256 of any other file
+func kdbxKeyFileKey(data []byte) ([]byte, error) {
+	var keyFile struct {
+		XMLName xml.Name `xml:"KeyFile"`
+		Version string   `xml:"Meta>Version"`
+		Data    struct {
+			Hash  string `xml:"Hash,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"Key>Data"`
+	}
+	if bytes.Contains(data, []byte("<KeyFile")) && xml.Unmarshal(data, &keyFile) == nil {
+		value := strings.Join(strings.Fields(keyFile.Data.Value), "")
+		if strings.HasPrefix(keyFile.Version, "2.") {
+			key, err := hex.DecodeString(value)
+			if err != nil || len(key) != 32 {
+				return nil, errors.New("invalid key file: bad key data")
+			}
+			if keyFile.Data.Hash != "" {
+				sum := sha256.Sum256(key)
+				if !strings.EqualFold(hex.EncodeToString(sum[:4]), keyFile.Data.Hash) {
+					return nil, errors.New("invalid key file: checksum mismatch")
+				}
+			}
+			return key, nil
+		}
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(key) != 32 {
+			return nil, errors.New("invalid key file: bad key data")
+		}
+		return key, nil
+	}
+
+	if len(data) == 32 {
+		return data, nil
+	}
+	if len(data) == 64 {
+		if key, err := hex.DecodeString(string(data)); err == nil {
+			return key, nil
+		}
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+// readKDBX decrypts and parses a KDBX 4 database with the given composite key
+func readKDBX(data, compositeKey []byte) (*kdbxDatabase, error) {
+	if len(data) < 12 || binary.LittleEndian.Uint32(data[0:]) != kdbxSignature1 || binary.LittleEndian.Uint32(data[4:]) != kdbxSignature2 {
+		return nil, errors.New("not a KeePass database")
+	}
+	if major := binary.LittleEndian.Uint32(data[8:]) >> 16; major != 4 {
+		return nil, ErrUnsupportedKDBX
+	}
+
+	// Outer header: 1-byte ID, 4-byte length, value
+	fields := make(map[byte][]byte)
+	pos := 12
+	for {
+		if pos+5 > len(data) {
+			return nil, errors.New("truncated KeePass header")
+		}
+		id, size := data[pos], int(binary.LittleEndian.Uint32(data[pos+1:]))
+		if size < 0 || pos+5+size > len(data) {
+			return nil, errors.New("truncated KeePass header")
+		}
+		fields[id] = data[pos+5 : pos+5+size]
+		pos += 5 + size
+		if id == kdbxHeaderEnd {
+			break
+		}
+	}
+	header := data[:pos]
+	if pos+64 > len(data) {
+		return nil, errors.New("truncated KeePass header")
+	}
+	headerHash, headerHMAC := data[pos:pos+32], data[pos+32:pos+64]
+	if sum := sha256.Sum256(header); !hmac.Equal(sum[:], headerHash) {
+		return nil, errors.New("KeePass header is corrupted (checksum mismatch)")
+	}
+
+	masterSeed := fields[kdbxHeaderMasterSeed]
+	if len(masterSeed) != 32 {
+		return nil, errors.New("invalid KeePass master seed")
+	}
+	transformedKey, err := kdbxTransformKey(fields[kdbxHeaderKDF], compositeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey := sha512.Sum512(append(append(append([]byte{}, masterSeed...), transformedKey...), 0x01))
+	if !hmac.Equal(kdbxHMAC(hmacKey[:], math.MaxUint64, header), headerHMAC) {
+		return nil, ErrInvalidKeePassKey
+	}
+
+	ciphertext, err := kdbxReadBlocks(data[pos+64:], hmacKey[:])
+	if err != nil {
+		return nil, err
+	}
+	encryptionKey := sha256.Sum256(append(append([]byte{}, masterSeed...), transformedKey...))
+	payload, err := kdbxDecrypt(fields[kdbxHeaderCipherID], encryptionKey[:], fields[kdbxHeaderIV], ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	if compression := fields[kdbxHeaderCompression]; len(compression) == 4 && binary.LittleEndian.Uint32(compression) == 1 {
+		gz, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress KeePass data: %w", err)
+		}
+		if payload, err = io.ReadAll(gz); err != nil {
+			return nil, fmt.Errorf("failed to decompress KeePass data: %w", err)
+		}
+	}
+
+	return kdbxReadPayload(payload)
+}
+
+// kdbxTransformKey runs the KDF described by the header's variant dictionary
+func kdbxTransformKey(kdfParams, compositeKey []byte) ([]byte, error) {
+	params, err := kdbxVariantDictionary(kdfParams)
+	if err != nil {
+		return nil, err
+	}
+
+	switch string(params["$UUID"]) {
+	case kdbxKDFAES, kdbxKDFAESLegacy:
+		rounds, seed := params.uint64("R"), params["S"]
+		if rounds > kdbxMaxAESRounds {
+			return nil, fmt.Errorf("invalid AES-KDF parameters: %d rounds (at most %d supported)", rounds, kdbxMaxAESRounds)
+		}
+		block, err := aes.NewCipher(seed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AES-KDF seed: %w", err)
+		}
+		key := append([]byte{}, compositeKey...)
+		for i := uint64(0); i < rounds; i++ {
+			block.Encrypt(key[:16], key[:16])
+			block.Encrypt(key[16:], key[16:])
+		}
+		sum := sha256.Sum256(key)
+		return sum[:], nil
+
+	case kdbxKDFArgon2d, kdbxKDFArgon2id:
+		// Check the raw values before narrowing them to the derivation's uint32s
+		version, iterations := params.uint64("V"), params.uint64("I")
+		memory, parallelism := params.uint64("M")/1024, params.uint64("P")
+		if version != argon2Version10 && version != argon2Version13 {
+			return nil, fmt.Errorf("unsupported Argon2 version %#x", version)
+		}
+		if iterations < 1 || iterations > kdbxMaxArgon2Iterations ||
+			parallelism < 1 || parallelism > kdbxMaxArgon2Parallelism ||
+			memory < 8 || memory > kdbxMaxArgon2MemoryKiB {
+			return nil, errors.New("invalid Argon2 parameters: iterations, memory or parallelism out of range")
+		}
+		p := argon2Params{
+			mode:        argon2d,
+			version:     uint32(version),
+			salt:        params["S"],
+			secret:      params["K"],
+			data:        params["A"],
+			iterations:  uint32(iterations),
+			memoryKiB:   uint32(memory),
+			parallelism: uint32(parallelism),
+			keyLen:      32,
+		}
+		if string(params["$UUID"]) == kdbxKDFArgon2id {
+			p.mode = argon2id
+		}
+		return argon2Key(compositeKey, p), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported KeePass key derivation function %x", params["$UUID"])
+	}
+}
+
+// kdbxParams holds the raw values of a variant dictionary
+type kdbxParams map[string][]byte
+
+// uint64 returns a UInt32 or UInt64 value, or 0 if missing
+func (p kdbxParams) uint64(key string) uint64 {
+	switch value := p[key]; len(value) {
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(value))
+	case 8:
+		return binary.LittleEndian.Uint64(value)
+	}
+	return 0
+}
+
+// kdbxVariantDictionary parses KeePass's typed key/value encoding (used for KDF parameters)
+func kdbxVariantDictionary(data []byte) (kdbxParams, error) {
+	if len(data) < 2 || binary.LittleEndian.Uint16(data)&0xFF00 > 0x0100 {
+		return nil, errors.New("unsupported KeePass KDF parameters")
+	}
+	params := make(kdbxParams)
+	pos := 2
+	for pos < len(data) {
+		valueType := data[pos]
+		if valueType == 0 {
+			return params, nil
+		}
+		if pos+5 > len(data) {
+			break
+		}
+		keyLen := int(int32(binary.LittleEndian.Uint32(data[pos+1:])))
+		if keyLen < 0 || pos+5+keyLen+4 > len(data) {
+			break
+		}
+		key := string(data[pos+5 : pos+5+keyLen])
+		pos += 5 + keyLen
+		valueLen := int(int32(binary.LittleEndian.Uint32(data[pos:])))
+		if valueLen < 0 || pos+4+valueLen > len(data) {
+			break
+		}
+		params[key] = data[pos+4 : pos+4+valueLen]
+		pos += 4 + valueLen
+	}
+	return nil, errors.New("truncated KeePass KDF parameters")
+}
+
+// kdbxHMAC computes an HMAC-SHA256 with the key of block index. The header uses
+// index MaxUint64 and is authenticated as is; blocks are prefixed with their index.
+func kdbxHMAC(hmacKey []byte, index uint64, data ...[]byte) []byte {
+	var indexBytes [8]byte
+	binary.LittleEndian.PutUint64(indexBytes[:], index)
+	blockKey := sha512.Sum512(append(indexBytes[:], hmacKey...))
+
+	mac := hmac.New(sha256.New, blockKey[:])
+	for _, part := range data {
+		mac.Write(part)
+	}
+	return mac.Sum(nil)
+}
+
+// kdbxReadBlocks verifies and joins the HMAC block stream that follows the header
+func kdbxReadBlocks(data, hmacKey []byte) ([]byte, error) {
+	var out bytes.Buffer
+	pos := 0
+	for index := uint64(0); ; index++ {
+		if pos+36 > len(data) {
+			return nil, errors.New("truncated KeePass data")
+		}
+		mac := data[pos : pos+32]
+		size := int(int32(binary.LittleEndian.Uint32(data[pos+32:])))
+		if size < 0 || pos+36+size > len(data) {
+			return nil, errors.New("truncated KeePass data")
+		}
+		var indexBytes [8]byte
+		binary.LittleEndian.PutUint64(indexBytes[:], index)
+		if !hmac.Equal(kdbxHMAC(hmacKey, index, indexBytes[:], data[pos+32:pos+36+size]), mac) {
+			return nil, fmt.Errorf("KeePass data is corrupted (block %d)", index)
+		}
+		if size == 0 {
+			return out.Bytes(), nil
+		}
+		out.Write(data[pos+36 : pos+36+size])
+		pos += 36 + size
+	}
+}
+
+// kdbxDecrypt decrypts the payload with the cipher named in the header
+func kdbxDecrypt(cipherID, key, iv, ciphertext []byte) ([]byte, error) {
+	var block cipher.Block
+	var err error
+	switch string(cipherID) {
+	case kdbxCipherChaCha20:
+		stream, err := chacha20.NewUnauthenticatedCipher(key, iv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ChaCha20 parameters: %w", err)
+		}
+		plaintext := make([]byte, len(ciphertext))
+		stream.XORKeyStream(plaintext, ciphertext)
+		return plaintext, nil
+	case kdbxCipherAES256:
+		block, err = aes.NewCipher(key)
+	case kdbxCipherTwofish:
+		block, err = twofish.NewCipher(key)
+	default:
+		return nil, fmt.Errorf("unsupported KeePass cipher %x", cipherID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// CBC with PKCS#7 padding
+	size := block.BlockSize()
+	if len(iv) != size || len(ciphertext) == 0 || len(ciphertext)%size != 0 {
+		return nil, errors.New("invalid KeePass ciphertext")
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > size {
+		return nil, errors.New("invalid KeePass padding")
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+// kdbxReadPayload reads the inner header (stream cipher and attachments) and the XML
+func kdbxReadPayload(payload []byte) (*kdbxDatabase, error) {
+	db := &kdbxDatabase{}
+	var streamID uint32
+	var streamKey []byte
+
+	pos := 0
+	for {
+		if pos+5 > len(payload) {
+			return nil, errors.New("truncated KeePass inner header")
+		}
+		id, size := payload[pos], int(binary.LittleEndian.Uint32(payload[pos+1:]))
+		if size < 0 || pos+5+size > len(payload) {
+			return nil, errors.New("truncated KeePass inner header")
+		}
+		value := payload[pos+5 : pos+5+size]
+		pos += 5 + size
+
+		switch id {
+		case kdbxInnerStreamID:
+			if len(value) == 4 {
+				streamID = binary.LittleEndian.Uint32(value)
+			}
+		case kdbxInnerStreamKey:
+			streamKey = value
+		case kdbxInnerBinary:
+			if len(value) == 0 {
+				return nil, errors.New("invalid KeePass attachment")
+			}
+			db.Binaries = append(db.Binaries, value[1:]) // First byte holds flags
+		}
+		if id == kdbxInnerEnd {
+			break
+		}
+	}
+
+	stream, err := kdbxInnerStream(streamID, streamKey)
+	if err != nil {
+		return nil, err
+	}
+	reader := &kdbxXMLReader{dec: xml.NewDecoder(bytes.NewReader(payload[pos:])), stream: stream}
+	if err := reader.readDocument(db); err != nil {
+		return nil, fmt.Errorf("invalid KeePass XML: %w", err)
+	}
+	return db, nil
+}
+
+// kdbxInnerStream returns the cipher for protected values
+func kdbxInnerStream(id uint32, key []byte) (cipher.Stream, error) {
+	switch id {
+	case kdbxStreamChaCha20:
+		sum := sha512.Sum512(key)
+		return chacha20.NewUnauthenticatedCipher(sum[:32], sum[32:44])
+	case kdbxStreamSalsa20:
+		stream := &salsa20Stream{key: sha256.Sum256(key)}
+		copy(stream.counter[:8], kdbxSalsa20Nonce)
+		return stream, nil
+	default:
+		return nil, fmt.Errorf("unsupported KeePass inner stream cipher %d", id)
+	}
+}
+
+// salsa20Stream is Salsa20 as a cipher.Stream (x/crypto only offers one-shot calls)
+type salsa20Stream struct {
+	key       [32]byte
+	counter   [16]byte // Nonce, then the little-endian block counter
+	keystream [64]byte
+	used      int
+}
+
+func (s *salsa20Stream) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		if s.used == 0 || s.used == len(s.keystream) {
+			var zero [64]byte
+			salsa.XORKeyStream(s.keystream[:], zero[:], &s.counter, &s.key)
+			binary.LittleEndian.PutUint64(s.counter[8:], binary.LittleEndian.Uint64(s.counter[8:])+1)
+			s.used = 0
+		}
+		dst[i] = src[i] ^ s.keystream[s.used]
+		s.used++
+	}
+}
+
+// kdbxXMLReader walks the XML document in order, decrypting protected values as
+// they appear since the inner stream cipher is shared by all of them
+type kdbxXMLReader struct {
+	dec    *xml.Decoder
+	stream cipher.Stream
+}
+
+// children calls handle for each child element of the element just opened.
+// handle must consume the whole child element.
+func (r *kdbxXMLReader) children(handle func(xml.StartElement) error) error {
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if err := handle(t); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (r *kdbxXMLReader) readDocument(db *kdbxDatabase) error {
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			return err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "KeePassFile" {
+				return fmt.Errorf("unexpected root element %s", start.Name.Local)
+			}
+			break
+		}
+	}
+
+	var recycleBinEnabled bool
+	var recycleBin string
+	err := r.children(func(start xml.StartElement) error {
+		switch start.Name.Local {
+		case "Meta":
+			return r.children(func(start xml.StartElement) error {
+				var value string
+				switch start.Name.Local {
+				case "RecycleBinEnabled":
+					err := r.dec.DecodeElement(&value, &start)
+					recycleBinEnabled = strings.EqualFold(value, "true")
+					return err
+				case "RecycleBinUUID":
+					return r.dec.DecodeElement(&recycleBin, &start)
+				}
+				return r.dec.Skip()
+			})
+		case "Root":
+			return r.children(func(start xml.StartElement) error {
+				if start.Name.Local == "Group" {
+					group, err := r.readGroup()
+					db.Root = group
+					return err
+				}
+				return r.dec.Skip()
+			})
+		}
+		return r.dec.Skip()
+	})
+	if recycleBinEnabled {
+		db.RecycleBin = recycleBin
+	}
+	return err
+}
+
+func (r *kdbxXMLReader) readGroup() (kdbxGroup, error) {
+	var group kdbxGroup
+	err := r.children(func(start xml.StartElement) error {
+		switch start.Name.Local {
+		case "UUID":
+			return r.dec.DecodeElement(&group.UUID, &start)
+		case "Name":
+			return r.dec.DecodeElement(&group.Name, &start)
+		case "Entry":
+			entry, err := r.readEntry()
+			group.Entries = append(group.Entries, entry)
+			return err
+		case "Group":
+			child, err := r.readGroup()
+			group.Groups = append(group.Groups, child)
+			return err
+		}
+		return r.dec.Skip()
+	})
+	return group, err
+}
+
+func (r *kdbxXMLReader) readEntry() (kdbxEntry, error) {
+	var entry kdbxEntry
+	err := r.children(func(start xml.StartElement) error {
+		switch start.Name.Local {
+		case "String":
+			var field struct {
+				Key   string `xml:"Key"`
+				Value struct {
+					Protected string `xml:"Protected,attr"`
+					Text      string `xml:",chardata"`
+				} `xml:"Value"`
+			}
+			if err := r.dec.DecodeElement(&field, &start); err != nil {
+				return err
+			}
+			value := kdbxString{Key: field.Key, Value: field.Value.Text}
+			if strings.EqualFold(field.Value.Protected, "true") {
+				plaintext, err := r.unprotect(field.Value.Text)
+				if err != nil {
+					return fmt.Errorf("protected value %q: %w", field.Key, err)
+				}
+				value.Value, value.Protected = plaintext, true
+			}
+			entry.Strings = append(entry.Strings, value)
+			return nil
+		case "Binary":
+			var attachment struct {
+				Key   string `xml:"Key"`
+				Value struct {
+					Ref string `xml:"Ref,attr"`
+				} `xml:"Value"`
+			}
+			if err := r.dec.DecodeElement(&attachment, &start); err != nil {
+				return err
+			}
+			ref, err := strconv.Atoi(attachment.Value.Ref)
+			if err != nil {
+				return fmt.Errorf("attachment %q has no valid reference", attachment.Key)
+			}
+			entry.Binaries = append(entry.Binaries, kdbxBinaryRef{Name: attachment.Key, Ref: ref})
+			return nil
+		case "Tags":
+			return r.dec.DecodeElement(&entry.Tags, &start)
+		case "Times":
+			var times struct {
+				Expires    string `xml:"Expires"`
+				ExpiryTime string `xml:"ExpiryTime"`
+			}
+			if err := r.dec.DecodeElement(&times, &start); err != nil {
+				return err
+			}
+			entry.Expires = strings.EqualFold(times.Expires, "true")
+			entry.Expiry = kdbxTime(times.ExpiryTime)
+			return nil
+		case "History":
+			// Older versions are not imported, but their protected values advance the stream
+			return r.children(func(start xml.StartElement) error {
+				if start.Name.Local == "Entry" {
+					_, err := r.readEntry()
+					return err
+				}
+				return r.dec.Skip()
+			})
+		}
+		return r.dec.Skip()
+	})
+	return entry, err
+}
+
+// unprotect decrypts a protected value with the inner stream cipher
+func (r *kdbxXMLReader) unprotect(value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", err
+	}
+	r.stream.XORKeyStream(data, data)
+	return string(data), nil
+}
+
+// kdbxTime parses a KDBX 4 time (base64 seconds since year 1) or an ISO 8601 time.
+// Returns the zero time if the value cannot be parsed.
+func kdbxTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if raw, err := base64.StdEncoding.DecodeString(value); err == nil && len(raw) == 8 {
+		const unixOffset = 62135596800 // Seconds from 0001-01-01 to 1970-01-01
+		seconds := int64(binary.LittleEndian.Uint64(raw))
+		return time.Unix(seconds-unixOffset, 0).UTC()
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// Standard KeePass entry strings; everything else becomes a custom field
+const (
+	keepassTitle    = "Title"
+	keepassUserName = "UserName"
+	keepassPassword = "Password"
+	keepassURL      = "URL"
+	keepassNotes    = "Notes"
+	keepassOTP      = "otp"      // otpauth:// URI written by KeePassXC and KeePass 2.x plugins
+	keepassURLField = "KP2A_URL" // Additional URLs ("KP2A_URL", "KP2A_URL_1", ...)
+)
+
+// ParseKeePass decrypts a KDBX 4 database with its password and, if it uses one,
+// the content of its key file (nil otherwise). Groups become categories (the root
+// group is left out), entries without a password become notes, the otp attribute
+// becomes the TOTP secret and attachments are kept. Entries in the recycle bin and
+// entry history are not imported.
+func ParseKeePass(data, password, keyFile []byte) (*Result, error) {
+	compositeKey, err := kdbxCompositeKey(password, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	db, err := readKDBX(data, compositeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, entry := range db.Root.Entries {
+		convertKeePassEntry(result, db, entry, "")
+	}
+	for _, group := range db.Root.Groups {
+		convertKeePassGroup(result, db, group, "")
+	}
+	return result, nil
+}
+
+// convertKeePassGroup converts the entries of a group and its subgroups
+func convertKeePassGroup(result *Result, db *kdbxDatabase, group kdbxGroup, parent string) {
+	if db.RecycleBin != "" && group.UUID == db.RecycleBin {
+		return
+	}
+
+	// Slashes inside a group name would otherwise create extra folder levels
+	path := strings.ReplaceAll(strings.TrimSpace(group.Name), "/", "-")
+	if parent != "" {
+		path = parent + "/" + path
+	}
+	for _, entry := range group.Entries {
+		convertKeePassEntry(result, db, entry, path)
+	}
+	for _, child := range group.Groups {
+		convertKeePassGroup(result, db, child, path)
+	}
+}
+
+// convertKeePassEntry maps one entry onto a credential
+func convertKeePassEntry(result *Result, db *kdbxDatabase, entry kdbxEntry, category string) {
+	title := ""
+	for _, field := range entry.Strings {
+		if field.Key == keepassTitle {
+			title = field.Value
+		}
+	}
+	credential := vault.Credential{Service: serviceName(title), Category: category}
+
+	for _, field := range entry.Strings {
+		switch {
+		case field.Key == keepassTitle:
+		case field.Key == keepassUserName:
+			credential.Username = field.Value
+		case field.Key == keepassPassword:
+			credential.Password = []byte(field.Value)
+		case field.Key == keepassURL:
+			credential.URL = strings.TrimSpace(field.Value)
+		case field.Key == keepassNotes:
+			credential.Notes = field.Value
+		case field.Key == keepassOTP:
+			setTOTP(result, &credential, field.Value)
+		case strings.HasPrefix(field.Key, keepassURLField) && strings.TrimSpace(field.Value) != "":
+			if !addURL(&credential, strings.TrimSpace(field.Value)) {
+				result.warn(credential.Service, "URL %q is not usable, kept in the field %q", field.Value, field.Key)
+				addField(&credential, field.Key, field.Value, false)
+			}
+		default:
+			addField(&credential, field.Key, field.Value, field.Protected)
+		}
+	}
+
+	// KeePass has no record types: entries without a password are notes
+	credential.Type = vault.RecordTypeLogin
+	if len(credential.Password) == 0 {
+		credential.Type = vault.RecordTypeNote
+	}
+
+	credential.Tags = splitTags(entry.Tags)
+
+	if entry.Expires && !entry.Expiry.IsZero() {
+		expiresAt := entry.Expiry
+		credential.ExpiresAt = &expiresAt
+	}
+
+	for _, binary := range entry.Binaries {
+		if binary.Ref < 0 || binary.Ref >= len(db.Binaries) {
+			result.warn(credential.Service, "attachment %q is missing from the database, skipped", binary.Name)
+			continue
+		}
+		// Attachment names must be plain file names
+		name := strings.TrimSpace(binary.Name[strings.LastIndexAny(binary.Name, `/\`)+1:])
+		if name == "" || name == "." || name == ".." {
+			name = "attachment"
+		}
+		credential.Attachments = append(credential.Attachments, vault.Attachment{Name: name, Data: db.Binaries[binary.Ref]})
+	}
+
+	result.Credentials = append(result.Credentials, credential)
+}
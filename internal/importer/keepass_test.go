@@ -0,0 +1,364 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/twofish"
+
+	"github.com/arimxyer/pass-cli/internal/vault"
+)
+
+// kdbxFixture describes a test database written by writeTestKDBX
+type kdbxFixture struct {
+	kdf      string // kdbxKDFArgon2d, kdbxKDFArgon2id or kdbxKDFAES
+	cipher   string // kdbxCipherAES256, kdbxCipherChaCha20 or kdbxCipherTwofish
+	stream   uint32 // kdbxStreamSalsa20 or kdbxStreamChaCha20
+	gzip     bool
+	password string
+	keyFile  []byte
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("rand.Read failed: %v", err)
+	}
+	return b
+}
+
+// variantDictionary encodes KDF parameters the way KeePass does
+func variantDictionary(items ...interface{}) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(0x0100))
+	for i := 0; i < len(items); i += 2 {
+		key := items[i].(string)
+		var valueType byte
+		var value []byte
+		switch v := items[i+1].(type) {
+		case uint32:
+			valueType, value = 0x04, binary.LittleEndian.AppendUint32(nil, v)
+		case uint64:
+			valueType, value = 0x05, binary.LittleEndian.AppendUint64(nil, v)
+		case []byte:
+			valueType, value = 0x42, v
+		}
+		buf.WriteByte(valueType)
+		_ = binary.Write(&buf, binary.LittleEndian, int32(len(key)))
+		buf.WriteString(key)
+		_ = binary.Write(&buf, binary.LittleEndian, int32(len(value)))
+		buf.Write(value)
+	}
+	buf.WriteByte(0)
+	return buf.Bytes()
+}
+
+// headerField encodes a 1-byte ID, 4-byte length field
+func headerField(buf *bytes.Buffer, id byte, value []byte) {
+	buf.WriteByte(id)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(value)))
+	buf.Write(value)
+}
+
+// kdbxTestTime encodes a time as KDBX 4 does
+func kdbxTestTime(tm time.Time) string {
+	return base64.StdEncoding.EncodeToString(binary.LittleEndian.AppendUint64(nil, uint64(tm.Unix()+62135596800)))
+}
+
+// testKeePassXML builds the database XML. protect encrypts protected values and
+// must be called in document order.
+func testKeePassXML(protect func(string) string) string {
+	str := func(key, value string) string {
+		return fmt.Sprintf("<String><Key>%s</Key><Value>%s</Value></String>", key, value)
+	}
+	protected := func(key, value string) string {
+		return fmt.Sprintf(`<String><Key>%s</Key><Value Protected="True">%s</Value></String>`, key, protect(value))
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8" standalone="yes"?><KeePassFile><Meta>`)
+	b.WriteString(`<Generator>KeePassXC</Generator><RecycleBinEnabled>True</RecycleBinEnabled><RecycleBinUUID>cmVjeWNsZWJpbjAwMDAwMA==</RecycleBinUUID>`)
+	b.WriteString(`</Meta><Root><Group><UUID>cm9vdDAwMDAwMDAwMDAwMA==</UUID><Name>Passwords</Name>`)
+
+	// Entry in the root group, with an older version in its history
+	b.WriteString("<Entry>" + str("Title", "Top") + protected("Password", "top-secret"))
+	b.WriteString("<History><Entry>" + str("Title", "Top") + protected("Password", "old-secret") + "</Entry></History></Entry>")
+
+	b.WriteString(`<Group><UUID>d29yazAwMDAwMDAwMDAwMA==</UUID><Name>Work</Name><Group><Name>Servers/DB</Name><Entry>`)
+	b.WriteString(str("Title", "prod-db") + str("UserName", "admin") + protected("Password", "db-pass"))
+	b.WriteString(str("URL", "https://db.example.com") + str("KP2A_URL", "https://db2.example.com"))
+	b.WriteString(protected("otp", "otpauth://totp/db?secret=JBSWY3DPEHPK3PXP&period=30&digits=6"))
+	b.WriteString(protected("API Key", "key-123") + str("Environment", "production") + str("Notes", "line 1\nline 2"))
+	b.WriteString("<Tags>ops; on call</Tags>")
+	b.WriteString("<Times><Expires>True</Expires><ExpiryTime>" + kdbxTestTime(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) + "</ExpiryTime></Times>")
+	b.WriteString(`<Binary><Key>dump.sql</Key><Value Ref="0"/></Binary>`)
+	b.WriteString("</Entry></Group>")
+	b.WriteString("<Entry>" + str("Title", "Wi-Fi") + protected("Password", "") + str("Notes", "ssid home") + "</Entry></Group>")
+
+	b.WriteString(`<Group><UUID>cmVjeWNsZWJpbjAwMDAwMA==</UUID><Name>Recycle Bin</Name><Entry>`)
+	b.WriteString(str("Title", "deleted") + protected("Password", "gone") + "</Entry></Group>")
+	b.WriteString("</Group><DeletedObjects/></Root></KeePassFile>")
+	return b.String()
+}
+
+// writeTestKDBX writes a KDBX 4 database with the test XML and one attachment
+func writeTestKDBX(t *testing.T, f kdbxFixture) []byte {
+	t.Helper()
+
+	var kdfParams []byte
+	switch f.kdf {
+	case kdbxKDFAES:
+		kdfParams = variantDictionary("$UUID", []byte(kdbxKDFAES), "R", uint64(1000), "S", randomBytes(t, 32))
+	default:
+		kdfParams = variantDictionary("$UUID", []byte(f.kdf), "S", randomBytes(t, 32),
+			"P", uint32(2), "M", uint64(64*1024), "I", uint64(2), "V", uint32(argon2Version13))
+	}
+
+	var password []byte
+	if f.password != "" {
+		password = []byte(f.password)
+	}
+	compositeKey, err := kdbxCompositeKey(password, f.keyFile)
+	if err != nil {
+		t.Fatalf("kdbxCompositeKey failed: %v", err)
+	}
+	transformedKey, err := kdbxTransformKey(kdfParams, compositeKey)
+	if err != nil {
+		t.Fatalf("kdbxTransformKey failed: %v", err)
+	}
+
+	masterSeed := randomBytes(t, 32)
+	iv := randomBytes(t, 16)
+	if f.cipher == kdbxCipherChaCha20 {
+		iv = randomBytes(t, 12)
+	}
+	compression := uint32(0)
+	if f.gzip {
+		compression = 1
+	}
+
+	var header bytes.Buffer
+	_ = binary.Write(&header, binary.LittleEndian, []uint32{kdbxSignature1, kdbxSignature2, 0x00040001})
+	headerField(&header, kdbxHeaderCipherID, []byte(f.cipher))
+	headerField(&header, kdbxHeaderCompression, binary.LittleEndian.AppendUint32(nil, compression))
+	headerField(&header, kdbxHeaderMasterSeed, masterSeed)
+	headerField(&header, kdbxHeaderIV, iv)
+	headerField(&header, kdbxHeaderKDF, kdfParams)
+	headerField(&header, kdbxHeaderEnd, []byte("\r\n\r\n"))
+
+	hmacKey := sha512.Sum512(append(append(append([]byte{}, masterSeed...), transformedKey...), 0x01))
+	headerHash := sha256.Sum256(header.Bytes())
+	headerHMAC := kdbxHMAC(hmacKey[:], math.MaxUint64, header.Bytes())
+
+	// Inner header and XML
+	streamKey := randomBytes(t, 64)
+	stream, err := kdbxInnerStream(f.stream, streamKey)
+	if err != nil {
+		t.Fatalf("kdbxInnerStream failed: %v", err)
+	}
+	var payload bytes.Buffer
+	headerField(&payload, kdbxInnerStreamID, binary.LittleEndian.AppendUint32(nil, f.stream))
+	headerField(&payload, kdbxInnerStreamKey, streamKey)
+	headerField(&payload, kdbxInnerBinary, append([]byte{0x01}, []byte("CREATE TABLE users;")...))
+	headerField(&payload, kdbxInnerEnd, nil)
+	payload.WriteString(testKeePassXML(func(value string) string {
+		data := []byte(value)
+		stream.XORKeyStream(data, data)
+		return base64.StdEncoding.EncodeToString(data)
+	}))
+
+	plaintext := payload.Bytes()
+	if f.gzip {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, _ = gz.Write(plaintext)
+		_ = gz.Close()
+		plaintext = compressed.Bytes()
+	}
+
+	encryptionKey := sha256.Sum256(append(append([]byte{}, masterSeed...), transformedKey...))
+	var ciphertext []byte
+	if f.cipher == kdbxCipherChaCha20 {
+		c, _ := chacha20.NewUnauthenticatedCipher(encryptionKey[:], iv)
+		ciphertext = make([]byte, len(plaintext))
+		c.XORKeyStream(ciphertext, plaintext)
+	} else {
+		var block cipher.Block
+		if f.cipher == kdbxCipherTwofish {
+			block, _ = twofish.NewCipher(encryptionKey[:])
+		} else {
+			block, _ = aes.NewCipher(encryptionKey[:])
+		}
+		padding := block.BlockSize() - len(plaintext)%block.BlockSize()
+		padded := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+		ciphertext = make([]byte, len(padded))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+	}
+
+	// HMAC block stream with small blocks, ending with an empty block
+	var out bytes.Buffer
+	out.Write(header.Bytes())
+	out.Write(headerHash[:])
+	out.Write(headerHMAC)
+	for index := uint64(0); ; index++ {
+		size := min(len(ciphertext), 256)
+		block := ciphertext[:size]
+		ciphertext = ciphertext[size:]
+		sizeBytes := binary.LittleEndian.AppendUint32(nil, uint32(size))
+		out.Write(kdbxHMAC(hmacKey[:], index, binary.LittleEndian.AppendUint64(nil, index), sizeBytes, block))
+		out.Write(sizeBytes)
+		out.Write(block)
+		if size == 0 {
+			break
+		}
+	}
+	return out.Bytes()
+}
+
+func TestParseKeePass(t *testing.T) {
+	keyFile := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<KeyFile><Meta><Version>2.0</Version></Meta><Key>
+<Data Hash="HASH">
+  0011223344556677 8899AABBCCDDEEFF
+  0011223344556677 8899AABBCCDDEEFF
+</Data></Key></KeyFile>`)
+	key, _ := hex.DecodeString("00112233445566778899AABBCCDDEEFF00112233445566778899AABBCCDDEEFF")
+	sum := sha256.Sum256(key)
+	keyFile = bytes.Replace(keyFile, []byte("HASH"), []byte(strings.ToUpper(hex.EncodeToString(sum[:4]))), 1)
+
+	fixtures := map[string]kdbxFixture{
+		"argon2d chacha20":        {kdf: kdbxKDFArgon2d, cipher: kdbxCipherChaCha20, stream: kdbxStreamChaCha20, gzip: true, password: "hunter2"},
+		"argon2id aes salsa20":    {kdf: kdbxKDFArgon2id, cipher: kdbxCipherAES256, stream: kdbxStreamSalsa20, password: "hunter2"},
+		"aes-kdf twofish keyfile": {kdf: kdbxKDFAES, cipher: kdbxCipherTwofish, stream: kdbxStreamChaCha20, gzip: true, keyFile: keyFile},
+		"password and keyfile":    {kdf: kdbxKDFAES, cipher: kdbxCipherAES256, stream: kdbxStreamChaCha20, password: "hunter2", keyFile: []byte("any file works as a key file")},
+	}
+
+	for name, fixture := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			data := writeTestKDBX(t, fixture)
+
+			result, err := ParseKeePass(data, []byte(fixture.password), fixture.keyFile)
+			if err != nil {
+				t.Fatalf("ParseKeePass() failed: %v", err)
+			}
+			if len(result.Credentials) != 3 {
+				t.Fatalf("got %d credentials, want 3 (recycle bin and history skipped)", len(result.Credentials))
+			}
+			byName := make(map[string]vault.Credential)
+			for _, credential := range result.Credentials {
+				byName[credential.Service] = credential
+			}
+
+			if top := byName["Top"]; string(top.Password) != "top-secret" || top.Category != "" {
+				t.Errorf("root entry = %+v", top)
+			}
+
+			db := byName["prod-db"]
+			if db.Type != vault.RecordTypeLogin || db.Username != "admin" || string(db.Password) != "db-pass" {
+				t.Errorf("login fields not mapped: %+v", db)
+			}
+			if db.Category != "Work/Servers-DB" {
+				t.Errorf("category = %q, want Work/Servers-DB", db.Category)
+			}
+			if db.URL != "https://db.example.com" || len(db.URLs) != 1 || db.URLs[0].URL != "https://db2.example.com" {
+				t.Errorf("URLs = %q %+v", db.URL, db.URLs)
+			}
+			if db.TOTPSecret != "JBSWY3DPEHPK3PXP" {
+				t.Errorf("TOTP secret = %q", db.TOTPSecret)
+			}
+			if field, _ := db.GetCustomField("API Key"); field.Value != "key-123" || !field.Hidden {
+				t.Errorf("protected custom field = %+v", field)
+			}
+			if field, _ := db.GetCustomField("Environment"); field.Value != "production" || field.Hidden {
+				t.Errorf("custom field = %+v", field)
+			}
+			if db.Notes != "line 1\nline 2" {
+				t.Errorf("notes = %q", db.Notes)
+			}
+			if strings.Join(db.Tags, ",") != "ops,on-call" {
+				t.Errorf("tags = %v", db.Tags)
+			}
+			if db.ExpiresAt == nil || !db.ExpiresAt.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+				t.Errorf("expiry = %v", db.ExpiresAt)
+			}
+			if len(db.Attachments) != 1 || db.Attachments[0].Name != "dump.sql" || string(db.Attachments[0].Data) != "CREATE TABLE users;" {
+				t.Errorf("attachments = %+v", db.Attachments)
+			}
+
+			if wifi := byName["Wi-Fi"]; wifi.Type != vault.RecordTypeNote || wifi.Category != "Work" {
+				t.Errorf("entry without password = %+v, want a note in Work", wifi)
+			}
+		})
+	}
+}
+
+func TestParseKeePassErrors(t *testing.T) {
+	data := writeTestKDBX(t, kdbxFixture{kdf: kdbxKDFAES, cipher: kdbxCipherAES256, stream: kdbxStreamChaCha20, password: "hunter2"})
+
+	if _, err := ParseKeePass(data, []byte("wrong"), nil); !errors.Is(err, ErrInvalidKeePassKey) {
+		t.Errorf("wrong password error = %v, want ErrInvalidKeePassKey", err)
+	}
+
+	kdbx3 := append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(kdbx3[8:], 0x00030001)
+	if _, err := ParseKeePass(kdbx3, []byte("hunter2"), nil); !errors.Is(err, ErrUnsupportedKDBX) {
+		t.Errorf("KDBX 3 error = %v, want ErrUnsupportedKDBX", err)
+	}
+
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-40] ^= 0xFF
+	if _, err := ParseKeePass(corrupted, []byte("hunter2"), nil); err == nil {
+		t.Error("expected error for corrupted data")
+	}
+
+	if _, err := ParseKeePass([]byte("not a database"), []byte("hunter2"), nil); err == nil {
+		t.Error("expected error for a non-KeePass file")
+	}
+}
+
+func TestKDBXTransformKeyLimits(t *testing.T) {
+	salt := make([]byte, 32)
+	argon2 := func(items ...interface{}) []byte {
+		base := []interface{}{"$UUID", []byte(kdbxKDFArgon2d), "S", salt, "V", uint32(argon2Version13), "I", uint64(2), "M", uint64(64 * 1024), "P", uint32(2)}
+		return variantDictionary(append(base, items...)...)
+	}
+
+	tests := []struct {
+		name   string
+		params []byte
+	}{
+		{"parallelism overflowing the sync points", argon2("P", uint32(1<<30))},
+		{"parallelism truncated by uint32", argon2("P", uint64(1<<32+1))},
+		{"too much memory", argon2("M", uint64(kdbxMaxArgon2MemoryKiB+1)*1024)},
+		{"too many iterations", argon2("I", uint64(kdbxMaxArgon2Iterations+1))},
+		{"too many AES rounds", variantDictionary("$UUID", []byte(kdbxKDFAES), "S", salt, "R", uint64(kdbxMaxAESRounds+1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := kdbxTransformKey(tt.params, make([]byte, 32))
+			if err == nil || !strings.Contains(err.Error(), "invalid") {
+				t.Errorf("error = %v, want out-of-range KDF parameters rejected", err)
+			}
+		})
+	}
+
+	if _, err := kdbxTransformKey(argon2(), make([]byte, 32)); err != nil {
+		t.Errorf("valid Argon2 parameters: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package mask hides secret values in a stream of output, such as the output of
+// a command started by 'pass-cli run --mask'.
+package mask
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Replacement is written in place of every secret. It has a fixed length so the
+// length of the secret is not revealed.
+const Replacement = "********"
+
+// MinLength is the shortest value that is masked. Shorter values would hide
+// ordinary output ("y", "123") more often than they protect anything.
+const MinLength = 4
+
+// Writer replaces secrets in everything written to it before passing it on.
+// A secret split across two writes is still replaced: output that could be the
+// start of a secret is held back until the next write shows whether it is, or
+// until Flush. Writer is safe for concurrent use.
+type Writer struct {
+	mu      sync.Mutex
+	out     io.Writer
+	secrets [][]byte
+	pending []byte
+}
+
+// NewWriter returns a Writer that masks secrets in the output written to out.
+// Empty and duplicate secrets and those shorter than MinLength are ignored.
+func NewWriter(out io.Writer, secrets []string) *Writer {
+	w := &Writer{out: out}
+	seen := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) < MinLength || seen[secret] {
+			continue
+		}
+		seen[secret] = true
+		w.secrets = append(w.secrets, []byte(secret))
+	}
+	return w
+}
+
+// Write masks p and writes what is known not to be part of a secret. It always
+// reports len(p) bytes written unless the underlying writer fails.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.secrets) == 0 {
+		return w.out.Write(p)
+	}
+
+	w.pending = append(w.pending, p...)
+	var out []byte
+	for {
+		i, length := w.nextSecret()
+		if i < 0 {
+			break
+		}
+		out = append(out, w.pending[:i]...)
+		out = append(out, Replacement...)
+		w.pending = w.pending[i+length:]
+	}
+
+	// Hold back the longest tail that may still grow into a secret
+	keep := w.partialSecret()
+	out = append(out, w.pending[:len(w.pending)-keep]...)
+	w.pending = append([]byte(nil), w.pending[len(w.pending)-keep:]...)
+
+	if len(out) > 0 {
+		if _, err := w.out.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes output held back as a possible secret prefix. Call it once the
+// stream has ended.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+	_, err := w.out.Write(w.pending)
+	w.pending = nil
+	return err
+}
+
+// nextSecret returns the position and length of the leftmost secret in the
+// pending output, preferring the longest at the same position, or -1
+func (w *Writer) nextSecret() (int, int) {
+	best, length := -1, 0
+	for _, secret := range w.secrets {
+		i := bytes.Index(w.pending, secret)
+		if i < 0 {
+			continue
+		}
+		if best < 0 || i < best || (i == best && len(secret) > length) {
+			best, length = i, len(secret)
+		}
+	}
+	return best, length
+}
+
+// partialSecret returns the length of the longest tail of the pending output
+// that is the beginning of a secret
+func (w *Writer) partialSecret() int {
+	longest := 0
+	for _, secret := range w.secrets {
+		longest = max(longest, len(secret))
+	}
+	for k := min(len(w.pending), longest-1); k > 0; k-- {
+		tail := w.pending[len(w.pending)-k:]
+		for _, secret := range w.secrets {
+			if len(secret) > k && bytes.HasPrefix(secret, tail) {
+				return k
+			}
+		}
+	}
+	return 0
+}
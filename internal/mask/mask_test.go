@@ -0,0 +1,55 @@
+package mask
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		writes  []string
+		want    string
+	}{
+		{"single write", []string{"hunter2"}, []string{"password is hunter2!\n"}, "password is ********!\n"},
+		{"split across writes", []string{"hunter2"}, []string{"pass hun", "ter2 end"}, "pass ******** end"},
+		{"byte by byte", []string{"s3cret"}, strings.Split("xs3cretx", ""), "x********x"},
+		{"repeated", []string{"abcd"}, []string{"abcdabcd"}, "****************"},
+		{"longest at same position", []string{"token", "token-extended"}, []string{"[token-extended]"}, "[********]"},
+		{"prefix that does not complete", []string{"hunter2"}, []string{"hunt", "ing"}, "hunting"},
+		{"prefix at end is flushed", []string{"hunter2"}, []string{"ends with hunt"}, "ends with hunt"},
+		{"short secrets ignored", []string{"y", "", "abc"}, []string{"y abc"}, "y abc"},
+		{"no secrets", nil, []string{"plain"}, "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewWriter(&out, tt.secrets)
+			for _, write := range tt.writes {
+				n, err := w.Write([]byte(write))
+				if err != nil || n != len(write) {
+					t.Fatalf("Write(%q) = %d, %v", write, n, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() failed: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriterHoldsBackOnlyPossibleSecrets(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, []string{"hunter2"})
+	if _, err := w.Write([]byte("Enter: hun")); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Enter: " {
+		t.Errorf("output before the secret completes = %q, want %q", out.String(), "Enter: ")
+	}
+}
@@ -0,0 +1,153 @@
+// Package project reads .pass-cli.yml, the manifest a repository commits to
+// declare which secrets it needs:
+//
+//	# .pass-cli.yml
+//	vault: work                         # optional, a name from the vault registry
+//	env:
+//	  DB_PASSWORD: postgres-prod:password
+//	  DB_USER: postgres-prod:username
+//	  STRIPE_KEY: stripe                # the record type's main field
+//
+// The manifest holds references only, never values, so it is safe to commit.
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestName is the file name of a project manifest
+const ManifestName = ".pass-cli.yml"
+
+// ErrNoManifest is returned when no manifest is found
+var ErrNoManifest = errors.New("no " + ManifestName + " found")
+
+// envNamePattern matches portable environment variable names
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Manifest is a parsed .pass-cli.yml
+type Manifest struct {
+	Path  string   // File the manifest was read from
+	Vault string   // Vault registry name, empty for the default vault
+	Env   []EnvVar // In file order
+}
+
+// EnvVar maps an environment variable to a credential field
+type EnvVar struct {
+	Name    string
+	Service string
+	Field   string // Empty for the record type's main field
+}
+
+// Reference renders the variable's credential reference as written in the manifest
+func (e EnvVar) Reference() string {
+	if e.Field == "" {
+		return e.Service
+	}
+	return e.Service + ":" + e.Field
+}
+
+// ParseReference splits a "service[:field]" reference. The field is whatever
+// follows the last ':', so service names containing ':' need an explicit field.
+func ParseReference(reference string) (service, field string, err error) {
+	service = strings.TrimSpace(reference)
+	if i := strings.LastIndex(reference, ":"); i >= 0 {
+		service = strings.TrimSpace(reference[:i])
+		field = strings.TrimSpace(reference[i+1:])
+		if field == "" {
+			return "", "", fmt.Errorf("invalid reference %q: expected service[:field]", reference)
+		}
+	}
+	if service == "" {
+		return "", "", fmt.Errorf("invalid reference %q: expected service[:field]", reference)
+	}
+	return service, field, nil
+}
+
+// Find looks for a manifest in dir and its parents, stopping at the root of the
+// git repository dir belongs to. It returns ErrNoManifest if there is none.
+func Find(dir string) (*Manifest, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, ManifestName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			// Don't pick up a manifest from outside the repository
+			return nil, ErrNoManifest
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrNoManifest
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates a manifest file
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- manifest path found by Find or given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	manifest, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	manifest.Path = path
+	return manifest, nil
+}
+
+// Parse parses manifest content
+func Parse(data []byte) (*Manifest, error) {
+	var raw struct {
+		Vault string    `yaml:"vault"`
+		Env   yaml.Node `yaml:"env"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("manifest is empty")
+		}
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	manifest := &Manifest{Vault: strings.TrimSpace(raw.Vault)}
+	if raw.Env.Kind == 0 {
+		return nil, fmt.Errorf("manifest has no env section")
+	}
+	if raw.Env.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: env must map variable names to credential references", raw.Env.Line)
+	}
+	for i := 0; i+1 < len(raw.Env.Content); i += 2 {
+		key, value := raw.Env.Content[i], raw.Env.Content[i+1]
+		if !envNamePattern.MatchString(key.Value) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", key.Line, key.Value)
+		}
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: %s must be a credential reference (service or service:field)", value.Line, key.Value)
+		}
+		service, field, err := ParseReference(value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", value.Line, key.Value, err)
+		}
+		manifest.Env = append(manifest.Env, EnvVar{Name: key.Value, Service: service, Field: field})
+	}
+	if len(manifest.Env) == 0 {
+		return nil, fmt.Errorf("manifest has no env entries")
+	}
+	return manifest, nil
+}
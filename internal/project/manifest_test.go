@@ -0,0 +1,93 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	manifest, err := Parse([]byte(`vault: work
+env:
+  DB_PASSWORD: postgres-prod:password
+  API_KEY: stripe
+  PG_HOST: "db:5432:url"
+`))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if manifest.Vault != "work" {
+		t.Errorf("Vault = %q, want work", manifest.Vault)
+	}
+	want := []EnvVar{
+		{Name: "DB_PASSWORD", Service: "postgres-prod", Field: "password"},
+		{Name: "API_KEY", Service: "stripe"},
+		{Name: "PG_HOST", Service: "db:5432", Field: "url"},
+	}
+	if !reflect.DeepEqual(manifest.Env, want) {
+		t.Errorf("Env = %+v, want %+v", manifest.Env, want)
+	}
+	if ref := manifest.Env[1].Reference(); ref != "stripe" {
+		t.Errorf("Reference() = %q, want stripe", ref)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", "", "empty"},
+		{"no env", "vault: work\n", "no env section"},
+		{"empty env", "env: {}\n", "no env entries"},
+		{"env list", "env:\n  - A\n", "must map"},
+		{"unknown key", "env:\n  A: b\nsecrets: x\n", "invalid manifest"},
+		{"bad name", "env:\n  1A: b\n", "invalid variable name"},
+		{"nested value", "env:\n  A:\n    b: c\n", "must be a credential reference"},
+		{"empty field", "env:\n  A: 'b:'\n", "invalid reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A manifest above the repository root is not picked up
+	if err := os.WriteFile(filepath.Join(root, ManifestName), []byte("env:\n  A: b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Find(nested); !errors.Is(err, ErrNoManifest) {
+		t.Fatalf("Find() error = %v, want ErrNoManifest", err)
+	}
+
+	path := filepath.Join(repo, ManifestName)
+	if err := os.WriteFile(path, []byte("env:\n  TOKEN: github\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := Find(nested)
+	if err != nil {
+		t.Fatalf("Find() failed: %v", err)
+	}
+	if manifest.Path != path || len(manifest.Env) != 1 || manifest.Env[0].Name != "TOKEN" {
+		t.Errorf("Find() = %+v", manifest)
+	}
+}
@@ -55,6 +55,32 @@ const (
 	EventTOTPAdd    = "totp_add"    // TOTP secret added to credential
 	EventTOTPUpdate = "totp_update" // TOTP secret updated
 	EventTOTPClear  = "totp_clear"  // TOTP secret removed from credential
+
+	// Credential history operations (feature/credential-history)
+	EventHistoryAccess     = "history_access"     // Revision history (including old passwords) viewed
+	EventCredentialRestore = "credential_restore" // Credential restored from a previous revision
+
+	// Attachment operations (feature/attachments)
+	EventAttachmentAdd    = "attachment_add"    // File attached to a credential
+	EventAttachmentAccess = "attachment_access" // Attachment content read
+	EventAttachmentRemove = "attachment_remove" // Attachment deleted
+
+	// Trash operations (feature/trash)
+	EventTrashRestore = "trash_restore" // Deleted credential restored from the trash
+	EventTrashPurge   = "trash_purge"   // Deleted credential permanently removed
+
+	// #nosec G101 -- False positive: event type name, not actual credentials
+	EventCredentialRename = "credential_rename" // Service name changed (logged as "old -> new")
+
+	// Recipient key slots (feature/recipients)
+	EventRecipientAdd    = "recipient_add"    // DEK wrapped to a team member's public key
+	EventRecipientRemove = "recipient_remove" // Recipient removed and DEK rotated
+
+	// #nosec G101 -- False positive: event type name, not actual credentials
+	EventCredentialExport = "credential_export" // Credential written to an export file
+
+	// SSH agent events
+	EventSSHSign = "ssh_sign" // Signature requested from 'pass-cli ssh-agent' (failure: refused or failed)
 )
 
 // Outcome constants
@@ -0,0 +1,203 @@
+// Package sshagent implements an ssh-agent that serves SSH keys stored in the
+// vault, asking for confirmation before a key is used if required and
+// reporting every signature request.
+package sshagent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// ErrReadOnly is returned to clients that try to add keys: the agent only
+// serves keys from the vault
+var ErrReadOnly = errors.New("agent: keys are loaded from the pass-cli vault and cannot be added with ssh-add")
+
+// ErrRefused is returned when the user declines a signature request
+var ErrRefused = errors.New("agent: signing refused by user")
+
+// Key is a vault SSH key served by the agent
+type Key struct {
+	Service    string      // Credential the key was loaded from
+	PrivateKey interface{} // As returned by ssh.ParseRawPrivateKey
+	Comment    string
+	Confirm    bool          // Ask before every signature
+	Lifetime   time.Duration // Removed from the agent after this long; 0 keeps it
+}
+
+// Request describes a signature request, for confirmation and auditing
+type Request struct {
+	Service     string
+	Comment     string
+	Fingerprint string // SHA256 fingerprint of the public key
+}
+
+// ConfirmFunc asks the user whether a key may sign. It is called for one
+// request at a time.
+type ConfirmFunc func(req Request) bool
+
+// AuditFunc reports the outcome of a signature request; err is nil when a
+// signature was returned
+type AuditFunc func(req Request, err error)
+
+// Agent serves vault keys over the ssh-agent protocol. Keys are held in an
+// in-memory keyring, which handles lifetimes and locking; Agent adds
+// confirmation and auditing around signing. It is safe for concurrent use.
+type Agent struct {
+	keyring agent.ExtendedAgent
+	confirm ConfirmFunc
+	audit   AuditFunc
+
+	mu        sync.Mutex
+	keys      map[string]Key // By marshaled public key
+	confirmMu sync.Mutex     // Serializes confirmation prompts
+}
+
+// New returns an agent without keys. confirm and audit may be nil: keys that
+// require confirmation are then refused, and requests are not reported.
+func New(confirm ConfirmFunc, audit AuditFunc) *Agent {
+	return &Agent{
+		keyring: agent.NewKeyring().(agent.ExtendedAgent),
+		confirm: confirm,
+		audit:   audit,
+		keys:    make(map[string]Key),
+	}
+}
+
+// AddKey loads a vault key into the agent, replacing a key with the same public key
+func (a *Agent) AddKey(key Key) (ssh.PublicKey, error) {
+	signer, err := ssh.NewSignerFromKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key.Service, err)
+	}
+	added := agent.AddedKey{PrivateKey: key.PrivateKey, Comment: key.Comment}
+	if key.Lifetime > 0 {
+		added.LifetimeSecs = uint32(key.Lifetime.Round(time.Second) / time.Second)
+	}
+	if err := a.keyring.Add(added); err != nil {
+		return nil, fmt.Errorf("%s: %w", key.Service, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key.PrivateKey = nil // Only the keyring signs
+	a.keys[string(signer.PublicKey().Marshal())] = key
+	return signer.PublicKey(), nil
+}
+
+// Serve accepts connections on listener and serves the agent protocol on each,
+// until the listener is closed
+func (a *Agent) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer func() { _ = conn.Close() }()
+			_ = agent.ServeAgent(a, conn)
+		}()
+	}
+}
+
+// List returns the keys that have not expired
+func (a *Agent) List() ([]*agent.Key, error) {
+	return a.keyring.List()
+}
+
+// Sign signs data with the default algorithm of the key
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+// SignWithFlags asks for confirmation if the key requires it, signs and
+// reports the request
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	a.mu.Lock()
+	info, ok := a.keys[string(key.Marshal())]
+	a.mu.Unlock()
+	if !ok {
+		return nil, errors.New("agent: key not found")
+	}
+
+	req := Request{Service: info.Service, Comment: info.Comment, Fingerprint: ssh.FingerprintSHA256(key)}
+	signature, err := a.sign(info, req, key, data, flags)
+	if a.audit != nil {
+		a.audit(req, err)
+	}
+	return signature, err
+}
+
+func (a *Agent) sign(info Key, req Request, key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	if !a.hasKey(key) {
+		// Expired, or removed with ssh-add -d: don't ask about a key that can't sign
+		return nil, errors.New("agent: key not found")
+	}
+	if info.Confirm {
+		a.confirmMu.Lock()
+		allowed := a.confirm != nil && a.confirm(req)
+		a.confirmMu.Unlock()
+		if !allowed {
+			return nil, ErrRefused
+		}
+	}
+	return a.keyring.SignWithFlags(key, data, flags)
+}
+
+// hasKey reports whether the keyring still holds key
+func (a *Agent) hasKey(key ssh.PublicKey) bool {
+	keys, err := a.keyring.List()
+	if err != nil {
+		return false
+	}
+	for _, k := range keys {
+		if bytes.Equal(k.Blob, key.Marshal()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add refuses keys from clients: only vault keys are served
+func (a *Agent) Add(key agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+// Remove removes a key from the agent (not from the vault)
+func (a *Agent) Remove(key ssh.PublicKey) error {
+	return a.keyring.Remove(key)
+}
+
+// RemoveAll removes every key from the agent (not from the vault)
+func (a *Agent) RemoveAll() error {
+	return a.keyring.RemoveAll()
+}
+
+// Lock refuses all requests until Unlock is called with the same passphrase
+func (a *Agent) Lock(passphrase []byte) error {
+	return a.keyring.Lock(passphrase)
+}
+
+// Unlock undoes Lock
+func (a *Agent) Unlock(passphrase []byte) error {
+	return a.keyring.Unlock(passphrase)
+}
+
+// Signers is not supported: signing must go through confirmation and auditing
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	return nil, errors.New("agent: signers are not exported")
+}
+
+// Extension reports that no extensions are supported
+func (a *Agent) Extension(extensionType string, contents []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
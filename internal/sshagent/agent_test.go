@@ -0,0 +1,134 @@
+package sshagent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// newClient serves a on one end of a pipe and returns a client for the other
+func newClient(t *testing.T, a *Agent) agent.ExtendedAgent {
+	t.Helper()
+	server, client := net.Pipe()
+	go func() { _ = agent.ServeAgent(a, server) }()
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	return agent.NewClient(client)
+}
+
+func generateKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestAgentSign(t *testing.T) {
+	var audited []string
+	confirmAnswer := false
+	a := New(
+		func(req Request) bool { return confirmAnswer },
+		func(req Request, err error) {
+			outcome := "ok"
+			if err != nil {
+				outcome = "refused"
+			}
+			audited = append(audited, req.Service+":"+outcome)
+		},
+	)
+
+	plain, err := a.AddKey(Key{Service: "deploy", PrivateKey: generateKey(t), Comment: "deploy@ci"})
+	if err != nil {
+		t.Fatalf("AddKey() failed: %v", err)
+	}
+	guarded, err := a.AddKey(Key{Service: "prod", PrivateKey: generateKey(t), Confirm: true})
+	if err != nil {
+		t.Fatalf("AddKey() failed: %v", err)
+	}
+
+	client := newClient(t, a)
+	keys, err := client.List()
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("List() = %v, %v; want 2 keys", keys, err)
+	}
+
+	data := []byte("session data")
+	signature, err := client.Sign(plain, data)
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if err := plain.Verify(data, signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+
+	if _, err := client.Sign(guarded, data); err == nil {
+		t.Error("Sign() with a refused confirmation should fail")
+	}
+	confirmAnswer = true
+	if _, err := client.Sign(guarded, data); err != nil {
+		t.Errorf("Sign() after confirmation failed: %v", err)
+	}
+
+	want := []string{"deploy:ok", "prod:refused", "prod:ok"}
+	if len(audited) != len(want) {
+		t.Fatalf("audited = %v, want %v", audited, want)
+	}
+	for i := range want {
+		if audited[i] != want[i] {
+			t.Errorf("audited = %v, want %v", audited, want)
+		}
+	}
+
+	// Removing a key only affects the agent
+	if err := client.Remove(plain); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if _, err := client.Sign(plain, data); err == nil {
+		t.Error("Sign() with a removed key should fail")
+	}
+}
+
+func TestAgentRefusesAdd(t *testing.T) {
+	client := newClient(t, New(nil, nil))
+	if err := client.Add(agent.AddedKey{PrivateKey: generateKey(t)}); err == nil {
+		t.Error("Add() should be refused")
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := generateKey(t)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(key, "comment", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := pem.EncodeToMemory(block)
+
+	if _, _, err := ParsePrivateKey(encrypted, nil); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("ParsePrivateKey() without passphrase: got %v, want ErrPassphraseRequired", err)
+	}
+	if _, _, err := ParsePrivateKey(encrypted, []byte("wrong")); err == nil {
+		t.Error("ParsePrivateKey() with a wrong passphrase should fail")
+	}
+	_, publicKey, err := ParsePrivateKey(encrypted, []byte("secret"))
+	if err != nil {
+		t.Fatalf("ParsePrivateKey() failed: %v", err)
+	}
+	want, _ := ssh.NewPublicKey(key.Public())
+	if ssh.FingerprintSHA256(publicKey) != ssh.FingerprintSHA256(want) {
+		t.Error("ParsePrivateKey() returned the wrong public key")
+	}
+
+	if _, _, err := ParsePrivateKey([]byte("not a key"), nil); err == nil {
+		t.Error("ParsePrivateKey() should reject garbage")
+	}
+}
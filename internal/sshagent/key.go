@@ -0,0 +1,39 @@
+package sshagent
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrPassphraseRequired is returned for an encrypted private key without a passphrase
+var ErrPassphraseRequired = errors.New("private key is encrypted: a passphrase is required")
+
+// ParsePrivateKey parses a private key in OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM
+// form, decrypting it with passphrase if it is encrypted. It returns the key
+// for Key.PrivateKey and its public key.
+func ParsePrivateKey(pemBytes, passphrase []byte) (interface{}, ssh.PublicKey, error) {
+	key, err := ssh.ParseRawPrivateKey(pemBytes)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if len(passphrase) == 0 {
+			return nil, nil, ErrPassphraseRequired
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, passphrase)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	publicKey, err := ssh.NewPublicKey(signer.Public())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unsupported private key: %w", err)
+	}
+	return key, publicKey, nil
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+)
+
+// attachments.go stores large credential attachments as sidecar blobs.
+// Blobs live in a directory next to the vault file (vault.enc.attachments/),
+// are encrypted with the vault's DEK and are never modified once written, so
+// backups can share them via hard links.
+
+// AttachmentDirSuffix is appended to a vault or backup path to get its blob directory
+const AttachmentDirSuffix = ".attachments"
+
+// attachmentBlobExt is the file extension of a sidecar blob
+const attachmentBlobExt = ".blob"
+
+var (
+	// ErrAttachmentBlobNotFound indicates a sidecar blob referenced by the vault is missing
+	ErrAttachmentBlobNotFound = errors.New("attachment blob not found")
+	// ErrNoDataKey indicates the vault has no DEK (v1 vaults), so sidecar blobs cannot be used
+	ErrNoDataKey = errors.New("vault has no data encryption key (v1 format)")
+)
+
+// AttachmentDirFor returns the blob directory belonging to a vault or backup file
+func AttachmentDirFor(vaultPath string) string {
+	return vaultPath + AttachmentDirSuffix
+}
+
+// AttachmentDir returns the blob directory of this vault
+func (s *StorageService) AttachmentDir() string {
+	return AttachmentDirFor(s.vaultPath)
+}
+
+// blobPath returns the path of a blob, rejecting IDs that could escape the blob directory
+func (s *StorageService) blobPath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid attachment id: %q", id)
+	}
+	return filepath.Join(s.AttachmentDir(), id+attachmentBlobExt), nil
+}
+
+// DataKey unwraps and returns the vault's DEK. The caller must clear it after use.
+// Returns ErrNoDataKey for v1 vaults, which encrypt directly with the password key.
+func (s *StorageService) DataKey(password string) ([]byte, error) {
+	encryptedVault, err := s.loadEncryptedVault()
+	if err != nil {
+		return nil, err
+	}
+	if encryptedVault.Metadata.Version != 2 {
+		return nil, ErrNoDataKey
+	}
+
+	passwordKEK, err := s.cryptoService.DeriveKey([]byte(password), encryptedVault.Metadata.Salt, encryptedVault.Metadata.Iterations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer s.cryptoService.ClearKey(passwordKEK)
+
+	wrappedKey := crypto.WrappedKey{
+		Ciphertext: encryptedVault.Metadata.WrappedDEK,
+		Nonce:      encryptedVault.Metadata.WrappedDEKNonce,
+	}
+	dek, err := crypto.UnwrapKey(wrappedKey, passwordKEK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap DEK: %w", err)
+	}
+	return dek, nil
+}
+
+// WriteAttachmentBlob encrypts data with the DEK and writes it as a sidecar blob
+func (s *StorageService) WriteAttachmentBlob(id string, data, dek []byte) error {
+	path, err := s.blobPath(id)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := s.cryptoService.Encrypt(data, dek)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt attachment: %w", err)
+	}
+
+	if err := s.fs.MkdirAll(s.AttachmentDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	return s.atomicWrite(path, encrypted)
+}
+
+// ReadAttachmentBlob reads and decrypts a sidecar blob
+func (s *StorageService) ReadAttachmentBlob(id string, dek []byte) ([]byte, error) {
+	path, err := s.blobPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.fs.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrAttachmentBlobNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	data, err := s.cryptoService.Decrypt(encrypted, dek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+	return data, nil
+}
+
+// HasAttachmentBlob reports whether a sidecar blob exists (used by backup preview)
+func (s *StorageService) HasAttachmentBlob(id string) bool {
+	path, err := s.blobPath(id)
+	if err != nil {
+		return false
+	}
+	_, err = s.fs.Stat(path)
+	return err == nil
+}
+
+// RemoveAttachmentBlob deletes a sidecar blob. A missing blob is not an error.
+func (s *StorageService) RemoveAttachmentBlob(id string) error {
+	path, err := s.blobPath(id)
+	if err != nil {
+		return err
+	}
+	if err := s.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove attachment: %w", err)
+	}
+	return nil
+}
+
+// mirrorAttachments copies srcPath's blobs into dstPath's blob directory.
+// Blobs are immutable, so existing files are kept and new ones are hard-linked
+// (or copied when linking is not possible). With prune, blobs missing from the
+// source are removed so the destination matches exactly.
+func (s *StorageService) mirrorAttachments(srcPath, dstPath string, prune bool) error {
+	srcDir := AttachmentDirFor(srcPath)
+	dstDir := AttachmentDirFor(dstPath)
+
+	srcBlobs, err := s.fs.Glob(filepath.Join(srcDir, "*"+attachmentBlobExt))
+	if err != nil {
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+	if len(srcBlobs) == 0 {
+		if !prune {
+			return nil
+		}
+		if err := os.RemoveAll(dstDir); err != nil {
+			return fmt.Errorf("failed to remove stale attachments: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.fs.MkdirAll(dstDir, 0700); err != nil {
+		return fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(srcBlobs))
+	for _, src := range srcBlobs {
+		name := filepath.Base(src)
+		wanted[name] = true
+
+		dst := filepath.Join(dstDir, name)
+		if _, err := s.fs.Stat(dst); err == nil {
+			continue // Already present (blobs never change)
+		}
+		if err := os.Link(src, dst); err != nil {
+			if err := s.copyFile(src, dst); err != nil {
+				return fmt.Errorf("failed to copy attachment %s: %w", name, err)
+			}
+		}
+	}
+
+	if !prune {
+		return nil
+	}
+
+	dstBlobs, err := s.fs.Glob(filepath.Join(dstDir, "*"+attachmentBlobExt))
+	if err != nil {
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+	for _, dst := range dstBlobs {
+		if !wanted[filepath.Base(dst)] {
+			_ = s.fs.Remove(dst)
+		}
+	}
+
+	return nil
+}
+
+// backupAttachments mirrors the vault's blobs next to a backup file (best-effort).
+// A backup without its blobs can still be restored; only sidecar attachments are lost.
+func (s *StorageService) backupAttachments(backupPath string) {
+	if err := s.mirrorAttachments(s.vaultPath, backupPath, true); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to back up attachments: %v\n", err)
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+)
+
+func newAttachmentTestStorage(t *testing.T) *StorageService {
+	t.Helper()
+
+	vaultPath := filepath.Join(t.TempDir(), "vault.enc")
+	storage, err := NewStorageService(crypto.NewCryptoService(), vaultPath)
+	if err != nil {
+		t.Fatalf("NewStorageService failed: %v", err)
+	}
+	if err := storage.InitializeVault("test-password"); err != nil {
+		t.Fatalf("InitializeVault failed: %v", err)
+	}
+	return storage
+}
+
+func TestAttachmentBlobRoundTrip(t *testing.T) {
+	storage := newAttachmentTestStorage(t)
+	dek := bytes.Repeat([]byte{7}, 32)
+	data := []byte("attachment content")
+
+	if err := storage.WriteAttachmentBlob("abc123", data, dek); err != nil {
+		t.Fatalf("WriteAttachmentBlob failed: %v", err)
+	}
+	if !storage.HasAttachmentBlob("abc123") {
+		t.Error("HasAttachmentBlob should report the written blob")
+	}
+
+	got, err := storage.ReadAttachmentBlob("abc123", dek)
+	if err != nil {
+		t.Fatalf("ReadAttachmentBlob failed: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("ReadAttachmentBlob = %q, want %q", got, data)
+	}
+
+	if _, err := storage.ReadAttachmentBlob("abc123", bytes.Repeat([]byte{8}, 32)); err == nil {
+		t.Error("expected error reading a blob with the wrong key")
+	}
+
+	if err := storage.RemoveAttachmentBlob("abc123"); err != nil {
+		t.Fatalf("RemoveAttachmentBlob failed: %v", err)
+	}
+	if _, err := storage.ReadAttachmentBlob("abc123", dek); !errors.Is(err, ErrAttachmentBlobNotFound) {
+		t.Errorf("ReadAttachmentBlob after remove error = %v, want ErrAttachmentBlobNotFound", err)
+	}
+
+	// IDs cannot escape the blob directory
+	for _, id := range []string{"", "../vault", "a/b", "a.b"} {
+		if err := storage.WriteAttachmentBlob(id, data, dek); err == nil {
+			t.Errorf("WriteAttachmentBlob(%q) should fail", id)
+		}
+	}
+}
+
+func TestDataKeyRequiresV2(t *testing.T) {
+	storage := newAttachmentTestStorage(t)
+
+	if _, err := storage.DataKey("test-password"); !errors.Is(err, ErrNoDataKey) {
+		t.Errorf("DataKey on v1 vault error = %v, want ErrNoDataKey", err)
+	}
+}
+
+func TestBackupAndRestoreCarryAttachments(t *testing.T) {
+	storage := newAttachmentTestStorage(t)
+	dek := bytes.Repeat([]byte{7}, 32)
+
+	if err := storage.WriteAttachmentBlob("kept", []byte("kept"), dek); err != nil {
+		t.Fatalf("WriteAttachmentBlob failed: %v", err)
+	}
+
+	backupPath, err := storage.CreateManualBackup()
+	if err != nil {
+		t.Fatalf("CreateManualBackup failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(AttachmentDirFor(backupPath), "kept.blob")); err != nil {
+		t.Fatalf("manual backup is missing the attachment blob: %v", err)
+	}
+
+	// Lose the blob, then restore it from the backup
+	if err := storage.RemoveAttachmentBlob("kept"); err != nil {
+		t.Fatalf("RemoveAttachmentBlob failed: %v", err)
+	}
+	if err := storage.RestoreFromBackup(backupPath); err != nil {
+		t.Fatalf("RestoreFromBackup failed: %v", err)
+	}
+
+	got, err := storage.ReadAttachmentBlob("kept", dek)
+	if err != nil {
+		t.Fatalf("blob not restored: %v", err)
+	}
+	if string(got) != "kept" {
+		t.Errorf("restored blob = %q, want %q", got, "kept")
+	}
+}
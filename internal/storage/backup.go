@@ -76,6 +76,11 @@ func (s *StorageService) CreateManualBackup() (string, error) {
 		return "", fmt.Errorf("failed to create manual backup: %w", err)
 	}
 
+	// Keep sidecar attachments with the backup
+	if err := s.mirrorAttachments(s.vaultPath, backupPath, true); err != nil {
+		return "", fmt.Errorf("failed to back up attachments: %w", err)
+	}
+
 	return backupPath, nil
 }
 
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+)
+
+// recipients.go keeps recipient key slots: copies of the v2 DEK wrapped to the
+// X25519 public keys of team members, so each member can open a shared vault
+// with their own secret key instead of the master password.
+
+// ErrNotRecipient indicates no recipient slot matches the given secret key
+var ErrNotRecipient = errors.New("identity is not a recipient of this vault")
+
+// RecipientSlot is the DEK wrapped to one recipient's public key
+type RecipientSlot struct {
+	Name               string    `json:"name"`
+	PublicKey          []byte    `json:"public_key"`           // Recipient's X25519 public key
+	EphemeralPublicKey []byte    `json:"ephemeral_public_key"` // One-time key used for this wrap
+	WrappedDEK         []byte    `json:"wrapped_dek"`
+	WrappedDEKNonce    []byte    `json:"wrapped_dek_nonce"`
+	AddedAt            time.Time `json:"added_at"`
+}
+
+// NewRecipientSlot wraps the DEK to a recipient's public key
+func NewRecipientSlot(name string, publicKey, dek []byte) (RecipientSlot, error) {
+	wrapped, err := crypto.WrapKeyToRecipient(dek, publicKey)
+	if err != nil {
+		return RecipientSlot{}, fmt.Errorf("failed to wrap DEK for %s: %w", name, err)
+	}
+	return RecipientSlot{
+		Name:               name,
+		PublicKey:          append([]byte{}, publicKey...),
+		EphemeralPublicKey: wrapped.EphemeralPublicKey,
+		WrappedDEK:         wrapped.Wrapped.Ciphertext,
+		WrappedDEKNonce:    wrapped.Wrapped.Nonce,
+		AddedAt:            time.Now(),
+	}, nil
+}
+
+// Recipients returns the vault's recipient slots
+func (s *StorageService) Recipients() ([]RecipientSlot, error) {
+	encryptedVault, err := s.loadEncryptedVault()
+	if err != nil {
+		return nil, err
+	}
+	return encryptedVault.Metadata.Recipients, nil
+}
+
+// RecipientDataKey unwraps the DEK from the slot belonging to privateKey.
+// Returns the recipient name and the DEK; the caller must clear the DEK after use.
+func (s *StorageService) RecipientDataKey(privateKey []byte) (string, []byte, error) {
+	encryptedVault, err := s.loadEncryptedVault()
+	if err != nil {
+		return "", nil, err
+	}
+	if encryptedVault.Metadata.Version != 2 {
+		return "", nil, ErrNoDataKey
+	}
+
+	publicKey, err := crypto.RecipientPublicKey(privateKey)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, slot := range encryptedVault.Metadata.Recipients {
+		if !bytes.Equal(slot.PublicKey, publicKey) {
+			continue
+		}
+		dek, err := crypto.UnwrapKeyFromRecipient(crypto.RecipientWrappedKey{
+			EphemeralPublicKey: slot.EphemeralPublicKey,
+			Wrapped:            crypto.WrappedKey{Ciphertext: slot.WrappedDEK, Nonce: slot.WrappedDEKNonce},
+		}, privateKey)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to unwrap DEK for %s: %w", slot.Name, err)
+		}
+		return slot.Name, dek, nil
+	}
+	return "", nil, ErrNotRecipient
+}
+
+// SaveVaultWithRecipients saves vault data with the DEK and replaces the recipient slots
+func (s *StorageService) SaveVaultWithRecipients(data, dek []byte, recipients []RecipientSlot, callback ProgressCallback) error {
+	return s.saveVaultWithDEK(data, dek, func(metadata *VaultMetadata) error {
+		if metadata.Version != 2 {
+			return ErrNoDataKey
+		}
+		metadata.Recipients = recipients
+		return nil
+	}, callback)
+}
+
+// RotateDEK re-encrypts the vault with a new DEK. The new DEK is wrapped with the
+// password KEK (same salt and iterations) and the given recipient slots, which
+// must already be wrapped to newDEK. Slots for the old DEK are dropped.
+func (s *StorageService) RotateDEK(data, newDEK []byte, password string, recipients []RecipientSlot, callback ProgressCallback) error {
+	return s.saveVaultWithDEK(data, newDEK, func(metadata *VaultMetadata) error {
+		if metadata.Version != 2 {
+			return ErrNoDataKey
+		}
+
+		passwordKEK, err := s.cryptoService.DeriveKey([]byte(password), metadata.Salt, metadata.Iterations)
+		if err != nil {
+			return fmt.Errorf("failed to derive key: %w", err)
+		}
+		defer s.cryptoService.ClearKey(passwordKEK)
+
+		// Make sure the password is right before the old wrapped DEK is replaced
+		oldDEK, err := crypto.UnwrapKey(crypto.WrappedKey{
+			Ciphertext: metadata.WrappedDEK,
+			Nonce:      metadata.WrappedDEKNonce,
+		}, passwordKEK)
+		if err != nil {
+			return fmt.Errorf("failed to unwrap DEK (invalid password?): %w", err)
+		}
+		crypto.ClearBytes(oldDEK)
+
+		wrapped, err := crypto.WrapKey(newDEK, passwordKEK)
+		if err != nil {
+			return fmt.Errorf("failed to wrap new DEK: %w", err)
+		}
+		metadata.WrappedDEK = wrapped.Ciphertext
+		metadata.WrappedDEKNonce = wrapped.Nonce
+		metadata.Recipients = recipients
+		return nil
+	}, callback)
+}
@@ -40,6 +40,8 @@ type VaultMetadata struct {
 	Iterations      int       `json:"iterations"`                  // PBKDF2 iteration count (FR-007)
 	WrappedDEK      []byte    `json:"wrapped_dek,omitempty"`       // T018: DEK wrapped by password KEK (v2 only)
 	WrappedDEKNonce []byte    `json:"wrapped_dek_nonce,omitempty"` // T018: GCM nonce for DEK wrapping (v2 only)
+
+	Recipients []RecipientSlot `json:"recipients,omitempty"` // DEK wrapped to team members' public keys (v2 only)
 }
 
 type EncryptedVault struct {
@@ -360,6 +362,7 @@ func (s *StorageService) SaveVault(data []byte, password string, callback Progre
 	if err := s.atomicRename(s.vaultPath, backupPath); err != nil {
 		return actionableErrorMessage(err)
 	}
+	s.backupAttachments(backupPath)
 
 	// Step 5: Atomic rename (temp → vault)
 	if callback != nil {
@@ -393,6 +396,12 @@ func (s *StorageService) SaveVault(data []byte, password string, callback Progre
 //   - dek: 32-byte Data Encryption Key
 //   - callback: optional progress callback for audit logging
 func (s *StorageService) SaveVaultWithDEK(data, dek []byte, callback ProgressCallback) error {
+	return s.saveVaultWithDEK(data, dek, nil, callback)
+}
+
+// saveVaultWithDEK is SaveVaultWithDEK with an optional hook that changes the
+// key material in the metadata (recipient slots, DEK rotation) before writing.
+func (s *StorageService) saveVaultWithDEK(data, dek []byte, updateMetadata func(*VaultMetadata) error, callback ProgressCallback) error {
 	// Notify audit logger of save operation start
 	if callback != nil {
 		callback("atomic_save_started", s.vaultPath)
@@ -406,6 +415,11 @@ func (s *StorageService) SaveVaultWithDEK(data, dek []byte, callback ProgressCal
 
 	// Update metadata timestamp
 	encryptedVault.Metadata.UpdatedAt = time.Now()
+	if updateMetadata != nil {
+		if err := updateMetadata(&encryptedVault.Metadata); err != nil {
+			return err
+		}
+	}
 
 	// Encrypt vault data with DEK
 	encryptedData, err := s.cryptoService.Encrypt(data, dek)
@@ -472,6 +486,7 @@ func (s *StorageService) SaveVaultWithDEK(data, dek []byte, callback ProgressCal
 	if err := s.atomicRename(s.vaultPath, backupPath); err != nil {
 		return actionableErrorMessage(err)
 	}
+	s.backupAttachments(backupPath)
 
 	// Atomic rename (temp → vault)
 	if callback != nil {
@@ -637,6 +652,7 @@ func (s *StorageService) ChangePasswordV2(data []byte, oldPassword, newPassword
 	if err := s.atomicRename(s.vaultPath, backupPath); err != nil {
 		return actionableErrorMessage(err)
 	}
+	s.backupAttachments(backupPath)
 
 	// Atomic rename (temp → vault)
 	if callback != nil {
@@ -780,6 +796,7 @@ func (s *StorageService) SetPasswordAfterRecoveryV2(data []byte, newPassword str
 	if err := s.atomicRename(s.vaultPath, backupPath); err != nil {
 		return actionableErrorMessage(err)
 	}
+	s.backupAttachments(backupPath)
 
 	// Atomic rename (temp → vault)
 	if callback != nil {
@@ -1109,6 +1126,7 @@ func (s *StorageService) MigrateToV2(data, dek, wrappedDEK, wrappedDEKNonce, sal
 	if err := s.atomicRename(s.vaultPath, backupPath); err != nil {
 		return actionableErrorMessage(err)
 	}
+	s.backupAttachments(backupPath)
 
 	// Atomic rename (temp → vault)
 	if callback != nil {
@@ -1442,6 +1460,8 @@ func (s *StorageService) createBackup() error {
 		return fmt.Errorf("failed to sync backup file: %w", err)
 	}
 
+	s.backupAttachments(backupPath)
+
 	return nil
 }
 
@@ -1472,5 +1492,10 @@ func (s *StorageService) restoreFromBackup(backupPath string) error {
 		return fmt.Errorf("failed to sync restored vault: %w", err)
 	}
 
+	// Bring back sidecar attachments the backup references; blobs added since are left in place
+	if err := s.mirrorAttachments(backupPath, s.vaultPath, false); err != nil {
+		return fmt.Errorf("failed to restore attachments: %w", err)
+	}
+
 	return nil
 }
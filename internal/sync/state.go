@@ -7,7 +7,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
+
+	"github.com/arimxyer/pass-cli/internal/storage"
 )
 
 const syncStateFile = ".sync-state"
@@ -68,6 +71,33 @@ func HashFile(path string) (string, error) {
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
+// HashVault computes a digest of the vault file together with its sidecar
+// attachment blobs, so a missing or extra blob counts as a local change.
+func HashVault(vaultPath string) (string, error) {
+	vaultHash, err := HashFile(vaultPath)
+	if err != nil {
+		return "", err
+	}
+
+	blobs, err := filepath.Glob(filepath.Join(storage.AttachmentDirFor(vaultPath), "*.blob"))
+	if err != nil || len(blobs) == 0 {
+		// No attachments: keep the plain file hash so existing sync state stays valid
+		return vaultHash, nil
+	}
+	sort.Strings(blobs)
+
+	h := sha256.New()
+	_, _ = io.WriteString(h, vaultHash)
+	for _, blob := range blobs {
+		blobHash, err := HashFile(blob)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "\n%s %s", filepath.Base(blob), blobHash)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
 // StatePath returns the full path to the sync state file in a vault directory.
 func StatePath(vaultDir string) string {
 	return filepath.Join(vaultDir, syncStateFile)
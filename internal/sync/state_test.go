@@ -108,3 +108,31 @@ func TestStatePath(t *testing.T) {
 		t.Errorf("StatePath = %q, want %q", got, expected)
 	}
 }
+
+func TestHashVault_IncludesAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+	vaultPath := filepath.Join(tmpDir, "vault.enc")
+	_ = os.WriteFile(vaultPath, []byte("vault"), 0600)
+
+	// Without attachments the hash matches the plain file hash
+	plain, _ := HashFile(vaultPath)
+	hash1, err := HashVault(vaultPath)
+	if err != nil {
+		t.Fatalf("HashVault failed: %v", err)
+	}
+	if hash1 != plain {
+		t.Errorf("HashVault without attachments = %q, want %q", hash1, plain)
+	}
+
+	blobDir := vaultPath + ".attachments"
+	_ = os.MkdirAll(blobDir, 0700)
+	_ = os.WriteFile(filepath.Join(blobDir, "abc.blob"), []byte("blob"), 0600)
+
+	hash2, err := HashVault(vaultPath)
+	if err != nil {
+		t.Fatalf("HashVault failed: %v", err)
+	}
+	if hash2 == hash1 {
+		t.Error("adding an attachment blob should change the vault hash")
+	}
+}
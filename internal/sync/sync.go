@@ -197,7 +197,7 @@ func (s *Service) SmartPull(vaultPath string) error {
 
 	// 4. Check for local changes (conflict detection)
 	if _, statErr := os.Stat(vaultPath); statErr == nil {
-		localHash, hashErr := HashFile(vaultPath)
+		localHash, hashErr := HashVault(vaultPath)
 		if hashErr == nil && state.LastPushHash != "" && localHash != state.LastPushHash {
 			// Local has unpushed changes AND remote has changed = conflict
 			return ErrSyncConflict
@@ -219,7 +219,7 @@ func (s *Service) SmartPull(vaultPath string) error {
 	state.RemoteSize = remoteVault.Size
 
 	// Update last push hash to match what we just pulled
-	if newHash, err := HashFile(vaultPath); err == nil {
+	if newHash, err := HashVault(vaultPath); err == nil {
 		state.LastPushHash = newHash
 	}
 
@@ -245,7 +245,7 @@ func (s *Service) SmartPush(vaultPath string) (bool, error) {
 	vaultDir := filepath.Dir(vaultPath)
 
 	// 1. Compute local vault hash
-	localHash, err := HashFile(vaultPath)
+	localHash, err := HashVault(vaultPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to hash vault for sync: %v\n", err)
 		return false, nil
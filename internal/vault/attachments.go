@@ -0,0 +1,292 @@
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/security"
+	"github.com/arimxyer/pass-cli/internal/storage"
+)
+
+const (
+	// InlineAttachmentLimit is the largest attachment stored inside the vault file itself.
+	// Larger attachments go to DEK-encrypted sidecar blobs so every save stays fast.
+	InlineAttachmentLimit = 64 * 1024
+	// MaxAttachmentSize is the largest file that can be attached
+	MaxAttachmentSize = 50 * 1024 * 1024
+)
+
+var (
+	// ErrAttachmentNotFound indicates the credential has no attachment with that name
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentExists indicates the credential already has an attachment with that name
+	ErrAttachmentExists = errors.New("attachment already exists")
+
+	// errSidecarNeedsV2 is returned for large attachments on v1 vaults, which have no DEK
+	errSidecarNeedsV2 = fmt.Errorf("attachments larger than %d KiB need a v2 vault: run 'pass-cli vault migrate' first", InlineAttachmentLimit/1024)
+)
+
+// Attachment is a file stored with a credential. Small files are kept inline in the
+// (encrypted) vault data; larger ones are stored as sidecar blobs next to vault.enc,
+// encrypted with the vault's DEK.
+type Attachment struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"` // Hex digest of the plaintext, checked on read
+	AddedAt time.Time `json:"added_at"`
+	Sidecar bool      `json:"sidecar,omitempty"` // Content lives in a blob file instead of Data
+	Data    []byte    `json:"data,omitempty"`    // Inline content
+}
+
+// ValidateAttachmentName checks that an attachment name is a plain file name
+func ValidateAttachmentName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: attachment name cannot be empty", ErrInvalidCredential)
+	}
+	if name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("%w: attachment name %q must be a file name without directories", ErrInvalidCredential, name)
+	}
+	return nil
+}
+
+// findAttachment returns the index of the named attachment (case-sensitive, like file names), or -1
+func (c *Credential) findAttachment(name string) int {
+	for i, attachment := range c.Attachments {
+		if attachment.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// newAttachmentID returns a random hex identifier used as the blob file name
+func newAttachmentID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate attachment id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// dataKey returns the vault's DEK for sidecar blobs. The caller must clear it.
+func (v *VaultService) dataKey() ([]byte, error) {
+	if v.identityDEK != nil {
+		return append([]byte{}, v.identityDEK...), nil
+	}
+	dek, err := v.storageService.DataKey(string(v.masterPassword))
+	if errors.Is(err, storage.ErrNoDataKey) {
+		return nil, errSidecarNeedsV2
+	}
+	return dek, err
+}
+
+// newAttachment validates an attachment and builds its metadata. Small files keep
+// their content inline; for larger ones Sidecar is set and the caller writes the blob.
+func newAttachment(name string, data []byte) (Attachment, error) {
+	if err := ValidateAttachmentName(name); err != nil {
+		return Attachment{}, err
+	}
+	if len(data) > MaxAttachmentSize {
+		return Attachment{}, fmt.Errorf("%w: attachment is larger than %d MiB", ErrInvalidCredential, MaxAttachmentSize/(1024*1024))
+	}
+
+	id, err := newAttachmentID()
+	if err != nil {
+		return Attachment{}, err
+	}
+	digest := sha256.Sum256(data)
+	attachment := Attachment{
+		ID:      id,
+		Name:    name,
+		Size:    int64(len(data)),
+		SHA256:  hex.EncodeToString(digest[:]),
+		AddedAt: time.Now(),
+	}
+	if len(data) > InlineAttachmentLimit {
+		attachment.Sidecar = true
+	} else {
+		attachment.Data = append([]byte{}, data...)
+	}
+	return attachment, nil
+}
+
+// AddAttachment stores data as a new attachment on a credential.
+// Attachments are not recorded in the credential's revision history.
+func (v *VaultService) AddAttachment(service, name string, data []byte) (Attachment, error) {
+	if !v.unlocked {
+		return Attachment{}, ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return Attachment{}, fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+	attachment, err := newAttachment(name, data)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if credential.findAttachment(name) >= 0 {
+		return Attachment{}, fmt.Errorf("%w: %s", ErrAttachmentExists, name)
+	}
+
+	if attachment.Sidecar {
+		// Write the blob before saving so the vault never references a missing file
+		dek, err := v.dataKey()
+		if err != nil {
+			return Attachment{}, err
+		}
+		defer crypto.ClearBytes(dek)
+
+		if err := v.storageService.WriteAttachmentBlob(attachment.ID, data, dek); err != nil {
+			return Attachment{}, err
+		}
+	}
+
+	credential.Attachments = append(append([]Attachment(nil), credential.Attachments...), attachment)
+	credential.UpdatedAt = time.Now()
+	v.vaultData.Credentials[service] = credential
+
+	if err := v.save(); err != nil {
+		if attachment.Sidecar {
+			_ = v.storageService.RemoveAttachmentBlob(attachment.ID)
+		}
+		return Attachment{}, err
+	}
+
+	v.LogAudit(security.EventAttachmentAdd, security.OutcomeSuccess, service)
+
+	attachment.Data = nil
+	return attachment, nil
+}
+
+// ListAttachments returns a credential's attachments without their content
+func (v *VaultService) ListAttachments(service string) ([]Attachment, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+
+	attachments := make([]Attachment, 0, len(credential.Attachments))
+	for _, attachment := range credential.Attachments {
+		attachment.Data = nil
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// GetAttachment returns an attachment's metadata and decrypted content.
+// The content is checked against the digest recorded when it was added.
+func (v *VaultService) GetAttachment(service, name string) (Attachment, []byte, error) {
+	if !v.unlocked {
+		return Attachment{}, nil, ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return Attachment{}, nil, fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+	i := credential.findAttachment(name)
+	if i < 0 {
+		return Attachment{}, nil, fmt.Errorf("%w: %s", ErrAttachmentNotFound, name)
+	}
+	attachment := credential.Attachments[i]
+
+	var dek []byte
+	if attachment.Sidecar {
+		var err error
+		if dek, err = v.dataKey(); err != nil {
+			return Attachment{}, nil, err
+		}
+		defer crypto.ClearBytes(dek)
+	}
+	data, err := v.readAttachment(attachment, dek)
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+
+	v.LogAudit(security.EventAttachmentAccess, security.OutcomeSuccess, service)
+
+	attachment.Data = nil
+	return attachment, data, nil
+}
+
+// readAttachment returns the content of an attachment, checked against its digest.
+// dek is only needed for sidecar attachments.
+func (v *VaultService) readAttachment(attachment Attachment, dek []byte) ([]byte, error) {
+	var data []byte
+	if attachment.Sidecar {
+		var err error
+		if data, err = v.storageService.ReadAttachmentBlob(attachment.ID, dek); err != nil {
+			return nil, err
+		}
+	} else {
+		data = append([]byte{}, attachment.Data...)
+	}
+
+	digest := sha256.Sum256(data)
+	if hex.EncodeToString(digest[:]) != attachment.SHA256 {
+		return nil, fmt.Errorf("attachment %s is corrupted (checksum mismatch)", attachment.Name)
+	}
+	return data, nil
+}
+
+// RemoveAttachment deletes an attachment. Sidecar blobs are removed after the vault
+// is saved; the automatic backup keeps its own copy.
+func (v *VaultService) RemoveAttachment(service, name string) error {
+	if !v.unlocked {
+		return ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+	i := credential.findAttachment(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrAttachmentNotFound, name)
+	}
+	removed := credential.Attachments[i]
+
+	attachments := make([]Attachment, 0, len(credential.Attachments)-1)
+	attachments = append(attachments, credential.Attachments[:i]...)
+	attachments = append(attachments, credential.Attachments[i+1:]...)
+	if len(attachments) == 0 {
+		attachments = nil
+	}
+	credential.Attachments = attachments
+	credential.UpdatedAt = time.Now()
+	v.vaultData.Credentials[service] = credential
+
+	if err := v.save(); err != nil {
+		return err
+	}
+
+	if removed.Sidecar {
+		_ = v.storageService.RemoveAttachmentBlob(removed.ID)
+	}
+
+	v.LogAudit(security.EventAttachmentRemove, security.OutcomeSuccess, service)
+	return nil
+}
+
+// removeAttachmentBlobs deletes the sidecar blobs of attachments that are no longer
+// referenced (best-effort, called after the vault has been saved)
+func (v *VaultService) removeAttachmentBlobs(attachments []Attachment) {
+	for _, attachment := range attachments {
+		if attachment.Sidecar {
+			_ = v.storageService.RemoveAttachmentBlob(attachment.ID)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/storage"
+)
+
+func TestAddAttachmentInlineAndSidecar(t *testing.T) {
+	vault, vaultPath, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	small := []byte("recovery codes")
+	large := bytes.Repeat([]byte("x"), InlineAttachmentLimit+1)
+
+	inline, err := vault.AddAttachment("github", "codes.txt", small)
+	if err != nil {
+		t.Fatalf("AddAttachment(small) failed: %v", err)
+	}
+	if inline.Sidecar {
+		t.Error("small attachment should be stored inline")
+	}
+
+	sidecar, err := vault.AddAttachment("github", "backup.bin", large)
+	if err != nil {
+		t.Fatalf("AddAttachment(large) failed: %v", err)
+	}
+	if !sidecar.Sidecar {
+		t.Error("large attachment should be stored as a sidecar blob")
+	}
+	blob := filepath.Join(storage.AttachmentDirFor(vaultPath), sidecar.ID+".blob")
+	encrypted, err := os.ReadFile(blob)
+	if err != nil {
+		t.Fatalf("sidecar blob not written: %v", err)
+	}
+	if bytes.Contains(encrypted, large[:64]) {
+		t.Error("sidecar blob is not encrypted")
+	}
+
+	if _, err := vault.AddAttachment("github", "codes.txt", small); !errors.Is(err, ErrAttachmentExists) {
+		t.Errorf("duplicate name error = %v, want ErrAttachmentExists", err)
+	}
+	if _, err := vault.AddAttachment("github", "../escape", small); err == nil {
+		t.Error("expected error for attachment name with a path")
+	}
+
+	// Content survives lock/unlock
+	vault.Lock()
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+
+	for name, want := range map[string][]byte{"codes.txt": small, "backup.bin": large} {
+		_, got, err := vault.GetAttachment("github", name)
+		if err != nil {
+			t.Fatalf("GetAttachment(%s) failed: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("GetAttachment(%s) returned different content", name)
+		}
+	}
+
+	list, err := vault.ListAttachments("github")
+	if err != nil {
+		t.Fatalf("ListAttachments() failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Data != nil {
+		t.Errorf("ListAttachments() = %+v, want 2 entries without content", list)
+	}
+
+	// Removing deletes the blob
+	if err := vault.RemoveAttachment("github", "backup.bin"); err != nil {
+		t.Fatalf("RemoveAttachment() failed: %v", err)
+	}
+	if _, err := os.Stat(blob); !os.IsNotExist(err) {
+		t.Error("sidecar blob should be deleted with its attachment")
+	}
+	if _, _, err := vault.GetAttachment("github", "backup.bin"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("GetAttachment() after remove error = %v, want ErrAttachmentNotFound", err)
+	}
+}
+
+func TestSidecarAttachmentRequiresV2(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := []byte("TestPassword123!")
+	if err := vault.Initialize(password, false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("github", "user", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	// Inline attachments work on v1 vaults
+	if _, err := vault.AddAttachment("github", "small.txt", []byte("ok")); err != nil {
+		t.Fatalf("AddAttachment(small) failed: %v", err)
+	}
+
+	large := bytes.Repeat([]byte("x"), InlineAttachmentLimit+1)
+	if _, err := vault.AddAttachment("github", "large.bin", large); err == nil {
+		t.Error("expected error adding a sidecar attachment to a v1 vault")
+	}
+}
+
+func TestDeleteCredentialRemovesAttachmentBlobs(t *testing.T) {
+	vault, vaultPath, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	large := bytes.Repeat([]byte("x"), InlineAttachmentLimit+1)
+	attachment, err := vault.AddAttachment("github", "backup.bin", large)
+	if err != nil {
+		t.Fatalf("AddAttachment() failed: %v", err)
+	}
+
+	// Delete permanently (trash disabled); trash_test.go covers blobs kept in the trash
+	vault.trashRetentionDays = 0
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+
+	blob := filepath.Join(storage.AttachmentDirFor(vaultPath), attachment.ID+".blob")
+	if _, err := os.Stat(blob); !os.IsNotExist(err) {
+		t.Error("sidecar blob should be deleted with its credential")
+	}
+
+	// The automatic backup keeps its own copy
+	backupBlob := filepath.Join(storage.AttachmentDirFor(vaultPath+".backup"), attachment.ID+".blob")
+	if _, err := os.Stat(backupBlob); err != nil {
+		t.Errorf("backup should keep the sidecar blob: %v", err)
+	}
+}
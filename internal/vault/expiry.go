@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// expiryDateLayout is the accepted format for absolute expiry dates
+const expiryDateLayout = "2006-01-02"
+
+// ParseDays parses a day count such as "30", "30d", "6w" or "1y" (365 days)
+func ParseDays(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "d"):
+		value = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		value = strings.TrimSuffix(value, "w")
+		multiplier = 7
+	case strings.HasSuffix(value, "y"):
+		value = strings.TrimSuffix(value, "y")
+		multiplier = 365
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30d, 6w or 1y)", value)
+	}
+	return n * multiplier, nil
+}
+
+// ParseExpiry parses an expiry given either as a date (YYYY-MM-DD, local time)
+// or as a duration from now (e.g. "90d")
+func ParseExpiry(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.ParseInLocation(expiryDateLayout, value, time.Local); err == nil {
+		return date, nil
+	}
+
+	days, err := ParseDays(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry %q (use YYYY-MM-DD or a duration such as 90d)", value)
+	}
+	return now.AddDate(0, 0, days), nil
+}
+
+// RotationDue returns when the credential's secret should next be rotated.
+// Rotation is counted from the last secret change, or creation for credentials
+// that were never rotated.
+func (c *Credential) RotationDue() (time.Time, bool) {
+	if c.RotationDays <= 0 {
+		return time.Time{}, false
+	}
+	last := c.CreatedAt
+	if c.RotatedAt != nil {
+		last = *c.RotatedAt
+	}
+	return last.AddDate(0, 0, c.RotationDays), true
+}
+
+// ExpiryDate returns the earlier of the explicit expiry date and the rotation due date
+func (c *Credential) ExpiryDate() (time.Time, bool) {
+	due, hasDue := c.RotationDue()
+	if c.ExpiresAt == nil {
+		return due, hasDue
+	}
+	if hasDue && due.Before(*c.ExpiresAt) {
+		return due, true
+	}
+	return *c.ExpiresAt, true
+}
+
+// IsExpired reports whether the credential's expiry date has passed
+func (c *Credential) IsExpired(now time.Time) bool {
+	expiry, ok := c.ExpiryDate()
+	return ok && !now.Before(expiry)
+}
+
+// IsExpired reports whether the listed credential's expiry date has passed
+func (m *CredentialMetadata) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// secretChanged reports whether an update replaced the password or a hidden field value,
+// which counts as a rotation
+func secretChanged(before, after *Credential) bool {
+	if !bytes.Equal(before.Password, after.Password) {
+		return true
+	}
+	for _, field := range after.CustomFields {
+		if !field.Hidden {
+			continue
+		}
+		previous, found := before.GetCustomField(field.Name)
+		if !found || previous.Value != field.Value {
+			return true
+		}
+	}
+	return false
+}
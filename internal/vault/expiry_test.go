@@ -0,0 +1,169 @@
+package vault
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"30", 30, false},
+		{"30d", 30, false},
+		{"6w", 42, false},
+		{"1y", 365, false},
+		{" 90D ", 90, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"-5d", 0, true},
+		{"3m", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDays(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDays(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDays(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.Local)
+
+	date, err := ParseExpiry("2025-03-01", now)
+	if err != nil {
+		t.Fatalf("ParseExpiry(date) failed: %v", err)
+	}
+	if want := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local); !date.Equal(want) {
+		t.Errorf("ParseExpiry(date) = %v, want %v", date, want)
+	}
+
+	relative, err := ParseExpiry("90d", now)
+	if err != nil {
+		t.Fatalf("ParseExpiry(duration) failed: %v", err)
+	}
+	if want := now.AddDate(0, 0, 90); !relative.Equal(want) {
+		t.Errorf("ParseExpiry(duration) = %v, want %v", relative, want)
+	}
+
+	if _, err := ParseExpiry("next week", now); err == nil {
+		t.Error("ParseExpiry should reject unrecognized input")
+	}
+}
+
+func TestCredentialExpiryDate(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixed := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rotated := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("no expiry", func(t *testing.T) {
+		cred := Credential{CreatedAt: created}
+		if _, ok := cred.ExpiryDate(); ok {
+			t.Error("credential without expiry should have no expiry date")
+		}
+		if cred.IsExpired(fixed) {
+			t.Error("credential without expiry should never be expired")
+		}
+	})
+
+	t.Run("rotation counts from creation", func(t *testing.T) {
+		cred := Credential{CreatedAt: created, RotationDays: 30}
+		expiry, ok := cred.ExpiryDate()
+		if !ok || !expiry.Equal(created.AddDate(0, 0, 30)) {
+			t.Errorf("ExpiryDate() = %v, %v; want %v", expiry, ok, created.AddDate(0, 0, 30))
+		}
+	})
+
+	t.Run("rotation counts from last rotation", func(t *testing.T) {
+		cred := Credential{CreatedAt: created, RotationDays: 30, RotatedAt: &rotated}
+		expiry, _ := cred.ExpiryDate()
+		if !expiry.Equal(rotated.AddDate(0, 0, 30)) {
+			t.Errorf("ExpiryDate() = %v, want %v", expiry, rotated.AddDate(0, 0, 30))
+		}
+	})
+
+	t.Run("earlier of fixed date and rotation", func(t *testing.T) {
+		cred := Credential{CreatedAt: created, RotationDays: 365, ExpiresAt: &fixed}
+		expiry, _ := cred.ExpiryDate()
+		if !expiry.Equal(fixed) {
+			t.Errorf("ExpiryDate() = %v, want fixed date %v", expiry, fixed)
+		}
+		if !cred.IsExpired(fixed) {
+			t.Error("credential should be expired on its expiry date")
+		}
+		if cred.IsExpired(fixed.Add(-time.Second)) {
+			t.Error("credential should not be expired before its expiry date")
+		}
+	})
+}
+
+func TestUpdateCredentialExpiryAndRotation(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := []byte("TestPassword123!")
+	if err := vault.Initialize(password, false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("api", "bot", []byte("token-1"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	past := time.Now().AddDate(0, 0, -1)
+	rotation := 30
+	if err := vault.UpdateCredential("api", UpdateOpts{ExpiresAt: &past, RotationDays: &rotation}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	cred, err := vault.GetCredential("api", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if cred.ExpiresAt == nil || !cred.ExpiresAt.Equal(past) {
+		t.Errorf("ExpiresAt = %v, want %v", cred.ExpiresAt, past)
+	}
+	if cred.RotationDays != 30 {
+		t.Errorf("RotationDays = %d, want 30", cred.RotationDays)
+	}
+	if cred.RotatedAt != nil {
+		t.Error("RotatedAt should not be set when the secret did not change")
+	}
+
+	metadata, err := vault.ListCredentialsWithMetadata()
+	if err != nil {
+		t.Fatalf("ListCredentialsWithMetadata() failed: %v", err)
+	}
+	if len(metadata) != 1 || !metadata[0].IsExpired(time.Now()) {
+		t.Errorf("metadata should report the credential as expired: %+v", metadata)
+	}
+
+	newPassword := []byte("token-2")
+	if err := vault.UpdateCredential("api", UpdateOpts{Password: &newPassword, ClearExpiry: true}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("api", false)
+	if cred.ExpiresAt != nil {
+		t.Error("ClearExpiry should remove the fixed expiry date")
+	}
+	if cred.RotatedAt == nil {
+		t.Fatal("RotatedAt should be set after a password change")
+	}
+	if cred.IsExpired(time.Now()) {
+		t.Error("credential should not be expired right after rotation")
+	}
+
+	negative := -1
+	if err := vault.UpdateCredential("api", UpdateOpts{RotationDays: &negative}); err == nil {
+		t.Error("UpdateCredential should reject a negative rotation interval")
+	}
+}
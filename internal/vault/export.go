@@ -0,0 +1,64 @@
+package vault
+
+import (
+	"sort"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+)
+
+// ExportCredentials returns copies of the named credentials for writing to an
+// export file, sorted by name. References are resolved, since the export may not
+// contain their targets. Usage records and revisions are left out. With
+// withAttachments set, attachments carry their content in Data (sidecar blobs are
+// read and checked); otherwise they are dropped. Nothing is audited here: the
+// caller logs the exports once the file is written.
+func (v *VaultService) ExportCredentials(services []string, withAttachments bool) ([]Credential, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	sorted := append([]string(nil), services...)
+	sort.Strings(sorted)
+
+	var dek []byte
+	defer func() { crypto.ClearBytes(dek) }()
+
+	credentials := make([]Credential, 0, len(sorted))
+	fail := func(err error) ([]Credential, error) {
+		for i := range credentials {
+			crypto.ClearBytes(credentials[i].Password)
+		}
+		return nil, err
+	}
+
+	for _, service := range sorted {
+		credential, err := v.GetCredential(service, false)
+		if err != nil {
+			return fail(err)
+		}
+		credential.UsageRecord = nil
+		credential.RotatedAt = nil
+
+		stored := v.vaultData.Credentials[service].Attachments
+		credential.Attachments = nil
+		for _, attachment := range stored {
+			if !withAttachments {
+				break
+			}
+			if attachment.Sidecar && dek == nil {
+				if dek, err = v.dataKey(); err != nil {
+					crypto.ClearBytes(credential.Password)
+					return fail(err)
+				}
+			}
+			if attachment.Data, err = v.readAttachment(attachment, dek); err != nil {
+				crypto.ClearBytes(credential.Password)
+				return fail(err)
+			}
+			credential.Attachments = append(credential.Attachments, attachment)
+		}
+
+		credentials = append(credentials, *credential)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestExportCredentials(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	large := bytes.Repeat([]byte("x"), InlineAttachmentLimit+1)
+	if _, err := vault.AddAttachment("github", "codes.txt", []byte("recovery codes")); err != nil {
+		t.Fatalf("AddAttachment() failed: %v", err)
+	}
+	if _, err := vault.AddAttachment("github", "backup.bin", large); err != nil {
+		t.Fatalf("AddAttachment() failed: %v", err)
+	}
+	if err := vault.AddRecord(Credential{Service: "ci", Username: "{ref:github.username}", Password: []byte("{ref:github.password}")}); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+	if _, err := vault.GetCredential("github", true); err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+
+	exported, err := vault.ExportCredentials([]string{"github", "ci"}, true)
+	if err != nil {
+		t.Fatalf("ExportCredentials() failed: %v", err)
+	}
+	if len(exported) != 2 || exported[0].Service != "ci" || exported[1].Service != "github" {
+		t.Fatalf("exported = %+v, want ci and github sorted", exported)
+	}
+	if ci := exported[0]; ci.Username != "user" || string(ci.Password) != "pass" {
+		t.Errorf("references not resolved: %q %q", ci.Username, ci.Password)
+	}
+
+	github := exported[1]
+	if len(github.UsageRecord) != 0 || len(github.Revisions) != 0 {
+		t.Error("usage and history should not be exported")
+	}
+	if len(github.Attachments) != 2 || string(github.Attachments[0].Data) != "recovery codes" || !bytes.Equal(github.Attachments[1].Data, large) {
+		t.Errorf("attachment content not exported: %d attachments", len(github.Attachments))
+	}
+
+	withoutFiles, err := vault.ExportCredentials([]string{"github"}, false)
+	if err != nil || len(withoutFiles[0].Attachments) != 0 {
+		t.Errorf("ExportCredentials(no attachments) = %+v, %v", withoutFiles, err)
+	}
+	if _, err := vault.ExportCredentials([]string{"github", "missing"}, false); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("missing credential error = %v, want ErrCredentialNotFound", err)
+	}
+}
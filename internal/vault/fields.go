@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCustomFieldNotFound is returned when a named custom field does not exist on a credential
+var ErrCustomFieldNotFound = errors.New("custom field not found")
+
+// reservedFieldNames are the built-in field names (and their aliases) accepted by
+// 'pass-cli get --field'. Custom fields may not use them so lookups stay unambiguous.
+var reservedFieldNames = map[string]bool{
+	"service": true, "s": true,
+	"username": true, "user": true, "u": true,
+	"password": true, "pass": true, "p": true,
+	"category": true, "cat": true, "c": true,
+	"url":   true,
+	"notes": true, "note": true, "n": true,
+	"totp": true,
+	"type": true,
+	"tags": true, "tag": true,
+}
+
+// CustomField is a user-defined name/value pair stored on a credential.
+// Hidden fields are treated like passwords: masked on display and copied
+// to the clipboard instead of printed when retrieved.
+type CustomField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// ValidateCustomFieldName checks that a custom field name is usable
+func ValidateCustomFieldName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: custom field name cannot be empty", ErrInvalidCredential)
+	}
+	if strings.ContainsAny(name, "=\n") {
+		return fmt.Errorf("%w: custom field name %q cannot contain '=' or newlines", ErrInvalidCredential, name)
+	}
+	if reservedFieldNames[strings.ToLower(name)] {
+		return fmt.Errorf("%w: %q is a built-in field name", ErrInvalidCredential, name)
+	}
+	return nil
+}
+
+// ParseCustomField parses a "name=value" assignment into a CustomField.
+// The value may be empty and may itself contain '=' characters.
+func ParseCustomField(assignment string, hidden bool) (CustomField, error) {
+	name, value, found := strings.Cut(assignment, "=")
+	if !found {
+		return CustomField{}, fmt.Errorf("invalid field %q: expected name=value", assignment)
+	}
+
+	name = strings.TrimSpace(name)
+	if err := ValidateCustomFieldName(name); err != nil {
+		return CustomField{}, err
+	}
+
+	return CustomField{Name: name, Value: value, Hidden: hidden}, nil
+}
+
+// GetCustomField returns the custom field with the given name (case-insensitive)
+func (c *Credential) GetCustomField(name string) (CustomField, bool) {
+	for _, field := range c.CustomFields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return CustomField{}, false
+}
+
+// SetCustomField adds a custom field or replaces an existing one with the same name
+func (c *Credential) SetCustomField(field CustomField) {
+	for i, existing := range c.CustomFields {
+		if strings.EqualFold(existing.Name, field.Name) {
+			c.CustomFields[i] = field
+			return
+		}
+	}
+	c.CustomFields = append(c.CustomFields, field)
+}
+
+// RemoveCustomField deletes the named custom field, reporting whether it existed
+func (c *Credential) RemoveCustomField(name string) bool {
+	for i, existing := range c.CustomFields {
+		if strings.EqualFold(existing.Name, name) {
+			c.CustomFields = append(c.CustomFields[:i], c.CustomFields[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// CustomFieldNames returns the names of all custom fields in insertion order
+func (c *Credential) CustomFieldNames() []string {
+	names := make([]string, 0, len(c.CustomFields))
+	for _, field := range c.CustomFields {
+		names = append(names, field.Name)
+	}
+	return names
+}
@@ -0,0 +1,146 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCustomField(t *testing.T) {
+	tests := []struct {
+		name       string
+		assignment string
+		wantName   string
+		wantValue  string
+		wantErr    bool
+	}{
+		{"simple", "account-id=1234", "account-id", "1234", false},
+		{"value with equals", "token=abc=def==", "token", "abc=def==", false},
+		{"empty value", "pin=", "pin", "", false},
+		{"trims name", "  region =us-east-1", "region", "us-east-1", false},
+		{"missing equals", "account-id", "", "", true},
+		{"empty name", "=value", "", "", true},
+		{"reserved name", "password=secret", "", "", true},
+		{"reserved alias", "U=someone", "", "", true},
+		{"reserved type", "Type=note", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := ParseCustomField(tt.assignment, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCustomField(%q) error = %v, wantErr %v", tt.assignment, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if field.Name != tt.wantName || field.Value != tt.wantValue || !field.Hidden {
+				t.Errorf("ParseCustomField(%q) = %+v, want {%s %s true}", tt.assignment, field, tt.wantName, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCredentialCustomFieldHelpers(t *testing.T) {
+	cred := &Credential{}
+
+	cred.SetCustomField(CustomField{Name: "Region", Value: "us-east-1"})
+	cred.SetCustomField(CustomField{Name: "secret-key", Value: "abc", Hidden: true})
+	cred.SetCustomField(CustomField{Name: "region", Value: "eu-west-1"}) // Replaces case-insensitively
+
+	if len(cred.CustomFields) != 2 {
+		t.Fatalf("expected 2 custom fields, got %d", len(cred.CustomFields))
+	}
+
+	field, found := cred.GetCustomField("REGION")
+	if !found || field.Value != "eu-west-1" {
+		t.Errorf("GetCustomField(REGION) = %+v, %v; want eu-west-1", field, found)
+	}
+
+	names := cred.CustomFieldNames()
+	if len(names) != 2 || names[0] != "region" || names[1] != "secret-key" {
+		t.Errorf("CustomFieldNames() = %v, want [region secret-key]", names)
+	}
+
+	if !cred.RemoveCustomField("secret-key") {
+		t.Error("RemoveCustomField(secret-key) = false, want true")
+	}
+	if cred.RemoveCustomField("secret-key") {
+		t.Error("RemoveCustomField(secret-key) second call = true, want false")
+	}
+}
+
+func TestUpdateCredentialCustomFields(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("aws", "admin", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	// Add fields
+	err := vault.UpdateCredential("aws", UpdateOpts{
+		SetCustomFields: []CustomField{
+			{Name: "account-id", Value: "123456789012"},
+			{Name: "secret-key", Value: "wJalrXUtn", Hidden: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	// Metadata exposes names only
+	metadata, err := vault.ListCredentialsWithMetadata()
+	if err != nil {
+		t.Fatalf("ListCredentialsWithMetadata() failed: %v", err)
+	}
+	if len(metadata) != 1 || len(metadata[0].CustomFieldNames) != 2 {
+		t.Fatalf("expected 2 custom field names in metadata, got %+v", metadata)
+	}
+
+	// Remove one, replace the other
+	err = vault.UpdateCredential("aws", UpdateOpts{
+		RemoveCustomFields: []string{"account-id"},
+		SetCustomFields:    []CustomField{{Name: "secret-key", Value: "rotated", Hidden: true}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	cred, err := vault.GetCredential("aws", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if len(cred.CustomFields) != 1 {
+		t.Fatalf("expected 1 custom field, got %d", len(cred.CustomFields))
+	}
+	if field := cred.CustomFields[0]; field.Name != "secret-key" || field.Value != "rotated" || !field.Hidden {
+		t.Errorf("custom field = %+v, want hidden secret-key=rotated", field)
+	}
+
+	// Returned credential is a copy
+	cred.CustomFields[0].Value = "tampered"
+	again, _ := vault.GetCredential("aws", false)
+	if again.CustomFields[0].Value != "rotated" {
+		t.Error("GetCredential() returned custom fields sharing vault storage")
+	}
+
+	// Removing an unknown field fails without modifying the credential
+	err = vault.UpdateCredential("aws", UpdateOpts{RemoveCustomFields: []string{"missing"}})
+	if !errors.Is(err, ErrCustomFieldNotFound) {
+		t.Errorf("UpdateCredential() error = %v, want ErrCustomFieldNotFound", err)
+	}
+
+	// Reserved names are rejected
+	err = vault.UpdateCredential("aws", UpdateOpts{SetCustomFields: []CustomField{{Name: "password", Value: "x"}}})
+	if !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("UpdateCredential() error = %v, want ErrInvalidCredential", err)
+	}
+}
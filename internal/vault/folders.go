@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// Folders are slash-separated paths stored in a credential's Category field
+// (e.g. "Clients/Acme/Prod"). A plain category such as "Cloud" is a top-level folder,
+// so vaults created before folders existed need no migration.
+
+// FolderSeparator separates the segments of a folder path
+const FolderSeparator = "/"
+
+// ErrFolderNotFound indicates no credential is stored in or below a folder
+var ErrFolderNotFound = errors.New("folder not found")
+
+// NormalizeFolder cleans a folder path: segments are trimmed and empty segments
+// dropped, so " Clients//Acme/ " becomes "Clients/Acme". Returns "" for no folder.
+func NormalizeFolder(path string) string {
+	segments := strings.Split(path, FolderSeparator)
+	cleaned := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			cleaned = append(cleaned, segment)
+		}
+	}
+	return strings.Join(cleaned, FolderSeparator)
+}
+
+// InFolder reports whether a credential's folder is the given folder or, with
+// recursive, one of its subfolders. Both paths must already be normalized.
+func InFolder(credentialFolder, folder string, recursive bool) bool {
+	if credentialFolder == folder {
+		return true
+	}
+	return recursive && strings.HasPrefix(credentialFolder, folder+FolderSeparator)
+}
+
+// FolderName returns the last segment of a folder path
+func FolderName(path string) string {
+	return path[strings.LastIndex(path, FolderSeparator)+1:]
+}
+
+// FolderParent returns the folder containing path, or "" for a top-level folder
+func FolderParent(path string) string {
+	i := strings.LastIndex(path, FolderSeparator)
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+// FolderPaths returns every folder used by the given credential folders, including
+// intermediate folders that hold no credentials directly, sorted so that each folder
+// directly precedes its subfolders
+func FolderPaths(folders []string) []string {
+	seen := make(map[string]bool)
+	for _, folder := range folders {
+		for folder = NormalizeFolder(folder); folder != ""; folder = FolderParent(folder) {
+			seen[folder] = true
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return lessFolder(paths[i], paths[j])
+	})
+	return paths
+}
+
+// lessFolder orders folder paths segment by segment, so "a/b" sorts before "a-b"
+// and a folder comes before its subfolders
+func lessFolder(a, b string) bool {
+	as := strings.Split(a, FolderSeparator)
+	bs := strings.Split(b, FolderSeparator)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
+
+// MoveFolder moves a folder with all its credentials and subfolders to a new path.
+// Renaming is a move within the same parent. Moving onto an existing folder merges
+// the two. Each moved credential gets a history revision for its old folder.
+// Returns the number of credentials moved.
+func (v *VaultService) MoveFolder(from, to string) (int, error) {
+	if !v.unlocked {
+		return 0, ErrVaultLocked
+	}
+
+	from = NormalizeFolder(from)
+	to = NormalizeFolder(to)
+	if from == "" || to == "" {
+		return 0, fmt.Errorf("%w: folder path cannot be empty", ErrInvalidCredential)
+	}
+	if InFolder(to, from, true) {
+		return 0, fmt.Errorf("%w: cannot move folder %s into itself", ErrInvalidCredential, from)
+	}
+
+	now := time.Now()
+	var moved []string
+	for service, credential := range v.vaultData.Credentials {
+		folder := NormalizeFolder(credential.Category)
+		if !InFolder(folder, from, true) {
+			continue
+		}
+
+		before := credential
+		credential.Category = to + strings.TrimPrefix(folder, from)
+		if rev := snapshotChanges(&before, &credential); rev != nil {
+			rev.Timestamp = now
+			credential.appendRevision(*rev, v.maxRevisions)
+		}
+		credential.ModifiedCount++
+		credential.UpdatedAt = now
+		v.vaultData.Credentials[service] = credential
+		moved = append(moved, service)
+	}
+	if len(moved) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrFolderNotFound, from)
+	}
+
+	if err := v.save(); err != nil {
+		return 0, err
+	}
+
+	for _, service := range moved {
+		v.LogAudit(security.EventCredentialUpdate, security.OutcomeSuccess, service)
+	}
+	return len(moved), nil
+}
@@ -0,0 +1,112 @@
+package vault
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeFolder(t *testing.T) {
+	tests := map[string]string{
+		"":                     "",
+		"Cloud":                "Cloud",
+		" Clients//Acme/ ":     "Clients/Acme",
+		"/Clients / Acme/Prod": "Clients/Acme/Prod",
+		" / ":                  "",
+	}
+	for input, want := range tests {
+		if got := NormalizeFolder(input); got != want {
+			t.Errorf("NormalizeFolder(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestInFolder(t *testing.T) {
+	tests := []struct {
+		credentialFolder string
+		folder           string
+		recursive        bool
+		want             bool
+	}{
+		{"Clients/Acme", "Clients/Acme", false, true},
+		{"Clients/Acme/Prod", "Clients/Acme", false, false},
+		{"Clients/Acme/Prod", "Clients/Acme", true, true},
+		{"Clients/Acme Corp", "Clients/Acme", true, false},
+		{"Clients", "Clients/Acme", true, false},
+	}
+	for _, tt := range tests {
+		if got := InFolder(tt.credentialFolder, tt.folder, tt.recursive); got != tt.want {
+			t.Errorf("InFolder(%q, %q, %v) = %v, want %v", tt.credentialFolder, tt.folder, tt.recursive, got, tt.want)
+		}
+	}
+}
+
+func TestFolderPaths(t *testing.T) {
+	got := FolderPaths([]string{"Clients/Acme/Prod", "Cloud", "Clients-Old", "Clients/Acme", ""})
+	want := []string{"Clients", "Clients/Acme", "Clients/Acme/Prod", "Clients-Old", "Cloud"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FolderPaths() = %v, want %v", got, want)
+	}
+
+	if FolderName("Clients/Acme/Prod") != "Prod" || FolderParent("Clients/Acme/Prod") != "Clients/Acme" {
+		t.Error("FolderName/FolderParent should split off the last segment")
+	}
+	if FolderName("Cloud") != "Cloud" || FolderParent("Cloud") != "" {
+		t.Error("a top-level folder has no parent")
+	}
+}
+
+func TestMoveFolder(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	credentials := map[string]string{
+		"acme-db":     "Clients/Acme/Prod",
+		"acme-portal": " Clients / Acme ", // Normalized on add
+		"acme-corp":   "Clients/Acme Corp",
+	}
+	for service, folder := range credentials {
+		if err := vault.AddCredential(service, "user", []byte("pass"), folder, "", ""); err != nil {
+			t.Fatalf("AddCredential(%s) failed: %v", service, err)
+		}
+	}
+
+	moved, err := vault.MoveFolder("Clients/Acme", "Archive/Acme")
+	if err != nil {
+		t.Fatalf("MoveFolder() failed: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("MoveFolder() moved %d credentials, want 2", moved)
+	}
+
+	want := map[string]string{
+		"acme-db":     "Archive/Acme/Prod",
+		"acme-portal": "Archive/Acme",
+		"acme-corp":   "Clients/Acme Corp", // Sibling with a common prefix stays put
+	}
+	for service, folder := range want {
+		cred, err := vault.GetCredential(service, false)
+		if err != nil {
+			t.Fatalf("GetCredential(%s) failed: %v", service, err)
+		}
+		if cred.Category != folder {
+			t.Errorf("%s folder = %q, want %q", service, cred.Category, folder)
+		}
+	}
+
+	// The old folder is recorded in history
+	history, _ := vault.GetHistory("acme-db")
+	if len(history) != 1 || history[0].Category == nil || *history[0].Category != "Clients/Acme/Prod" {
+		t.Errorf("expected a revision with the previous folder, got %+v", history)
+	}
+
+	if _, err := vault.MoveFolder("Clients/Acme", "Elsewhere"); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("MoveFolder() of a missing folder: got %v, want ErrFolderNotFound", err)
+	}
+	if _, err := vault.MoveFolder("Archive", "Archive/Old"); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("MoveFolder() into itself: got %v, want ErrInvalidCredential", err)
+	}
+	if _, err := vault.MoveFolder("Archive", " / "); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("MoveFolder() to an empty path: got %v, want ErrInvalidCredential", err)
+	}
+}
@@ -0,0 +1,252 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// DefaultMaxRevisions is the number of revisions kept per credential when not configured
+const DefaultMaxRevisions = 10
+
+// ErrRevisionNotFound is returned when a requested revision does not exist
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Revision is a snapshot of credential fields taken just before an update replaced them.
+// Only fields changed by that update are recorded; nil means the field was left untouched.
+// Revisions live inside the Credential and are therefore encrypted with the rest of the vault.
+type Revision struct {
+	Number       int            `json:"number"`    // Monotonically increasing per credential
+	Timestamp    time.Time      `json:"timestamp"` // When these values were superseded
+	Username     *string        `json:"username,omitempty"`
+	Password     []byte         `json:"password,omitempty"`
+	Category     *string        `json:"category,omitempty"`
+	URL          *string        `json:"url,omitempty"`
+	Notes        *string        `json:"notes,omitempty"`
+	CustomFields *[]CustomField `json:"custom_fields,omitempty"`
+	Tags         *[]string      `json:"tags,omitempty"`
+	URLs         *[]URLRule     `json:"urls,omitempty"`
+}
+
+// ChangedFields returns the names of the fields captured in this revision
+func (r *Revision) ChangedFields() []string {
+	var fields []string
+	if r.Username != nil {
+		fields = append(fields, "username")
+	}
+	if r.Password != nil {
+		fields = append(fields, "password")
+	}
+	if r.Category != nil {
+		fields = append(fields, "category")
+	}
+	if r.URL != nil {
+		fields = append(fields, "url")
+	}
+	if r.Notes != nil {
+		fields = append(fields, "notes")
+	}
+	if r.CustomFields != nil {
+		fields = append(fields, "fields")
+	}
+	if r.Tags != nil {
+		fields = append(fields, "tags")
+	}
+	if r.URLs != nil {
+		fields = append(fields, "urls")
+	}
+	return fields
+}
+
+// snapshotChanges records the previous values of every field that differs between
+// before and after. Returns nil if nothing tracked by history changed.
+func snapshotChanges(before, after *Credential) *Revision {
+	rev := &Revision{}
+	changed := false
+
+	if before.Username != after.Username {
+		username := before.Username
+		rev.Username = &username
+		changed = true
+	}
+	if !bytes.Equal(before.Password, after.Password) {
+		rev.Password = make([]byte, len(before.Password))
+		copy(rev.Password, before.Password)
+		changed = true
+	}
+	if before.Category != after.Category {
+		category := before.Category
+		rev.Category = &category
+		changed = true
+	}
+	if before.URL != after.URL {
+		url := before.URL
+		rev.URL = &url
+		changed = true
+	}
+	if before.Notes != after.Notes {
+		notes := before.Notes
+		rev.Notes = &notes
+		changed = true
+	}
+	if !customFieldsEqual(before.CustomFields, after.CustomFields) {
+		fields := append([]CustomField{}, before.CustomFields...)
+		rev.CustomFields = &fields
+		changed = true
+	}
+	if strings.Join(before.Tags, ",") != strings.Join(after.Tags, ",") {
+		tags := append([]string{}, before.Tags...)
+		rev.Tags = &tags
+		changed = true
+	}
+	if !urlRulesEqual(before.URLs, after.URLs) {
+		urls := append([]URLRule{}, before.URLs...)
+		rev.URLs = &urls
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return rev
+}
+
+// customFieldsEqual compares two custom field lists, including order
+func customFieldsEqual(a, b []CustomField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// urlRulesEqual compares two URL lists, including order
+func urlRulesEqual(a, b []URLRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// appendRevision numbers rev, appends it and drops the oldest revisions beyond maxRevisions.
+// A maxRevisions of zero or less disables history and clears any existing revisions.
+func (c *Credential) appendRevision(rev Revision, maxRevisions int) {
+	if maxRevisions <= 0 {
+		c.Revisions = nil
+		return
+	}
+
+	rev.Number = 1
+	if len(c.Revisions) > 0 {
+		rev.Number = c.Revisions[len(c.Revisions)-1].Number + 1
+	}
+
+	revisions := append(append([]Revision{}, c.Revisions...), rev)
+	if len(revisions) > maxRevisions {
+		revisions = revisions[len(revisions)-maxRevisions:]
+	}
+	c.Revisions = revisions
+}
+
+// GetRevision returns the revision with the given number
+func (c *Credential) GetRevision(number int) (Revision, bool) {
+	for _, rev := range c.Revisions {
+		if rev.Number == number {
+			return rev, true
+		}
+	}
+	return Revision{}, false
+}
+
+// GetHistory returns the revision history of a credential, oldest first.
+// Revisions contain previous passwords, so access is audited.
+func (v *VaultService) GetHistory(service string) ([]Revision, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+
+	v.LogAudit(security.EventHistoryAccess, security.OutcomeSuccess, service)
+
+	// Deep copy so callers can zero passwords without touching vault state
+	history := make([]Revision, len(credential.Revisions))
+	for i, rev := range credential.Revisions {
+		history[i] = rev
+		if rev.Password != nil {
+			history[i].Password = make([]byte, len(rev.Password))
+			copy(history[i].Password, rev.Password)
+		}
+	}
+	return history, nil
+}
+
+// RestoreRevision applies the values captured in a revision back onto the credential.
+// The restore goes through UpdateCredential, so the replaced values become a new revision
+// and a restore can itself be undone.
+func (v *VaultService) RestoreRevision(service string, number int) error {
+	if !v.unlocked {
+		return ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
+	}
+
+	rev, found := credential.GetRevision(number)
+	if !found {
+		return fmt.Errorf("%w: %s has no revision %d", ErrRevisionNotFound, service, number)
+	}
+
+	opts := UpdateOpts{
+		Username: rev.Username,
+		Category: rev.Category,
+		URL:      rev.URL,
+		Notes:    rev.Notes,
+	}
+	if rev.Password != nil {
+		// UpdateCredential clears the password it is given, so hand it a copy
+		password := make([]byte, len(rev.Password))
+		copy(password, rev.Password)
+		opts.Password = &password
+	}
+	if rev.CustomFields != nil {
+		// Replace the whole set: drop current fields, then set the revision's fields
+		opts.RemoveCustomFields = credential.CustomFieldNames()
+		opts.SetCustomFields = *rev.CustomFields
+	}
+	if rev.Tags != nil {
+		opts.RemoveTags = append([]string(nil), credential.Tags...)
+		opts.AddTags = *rev.Tags
+	}
+	if rev.URLs != nil {
+		for _, rule := range credential.URLs {
+			opts.RemoveURLs = append(opts.RemoveURLs, rule.URL)
+		}
+		opts.AddURLs = *rev.URLs
+	}
+
+	if err := v.UpdateCredential(service, opts); err != nil {
+		return err
+	}
+
+	v.LogAudit(security.EventCredentialRestore, security.OutcomeSuccess, service)
+	return nil
+}
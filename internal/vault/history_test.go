@@ -0,0 +1,161 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnapshotChanges(t *testing.T) {
+	before := &Credential{Username: "alice", Password: []byte("old"), URL: "https://a.example"}
+	after := &Credential{Username: "alice", Password: []byte("new"), URL: "https://a.example"}
+
+	rev := snapshotChanges(before, after)
+	if rev == nil {
+		t.Fatal("snapshotChanges() = nil, want revision")
+	}
+	if string(rev.Password) != "old" {
+		t.Errorf("revision password = %q, want old", rev.Password)
+	}
+	if rev.Username != nil || rev.URL != nil {
+		t.Errorf("unchanged fields recorded: %+v", rev)
+	}
+	if fields := rev.ChangedFields(); len(fields) != 1 || fields[0] != "password" {
+		t.Errorf("ChangedFields() = %v, want [password]", fields)
+	}
+
+	if rev := snapshotChanges(before, before); rev != nil {
+		t.Errorf("snapshotChanges() with no changes = %+v, want nil", rev)
+	}
+}
+
+func TestAppendRevisionTrimsOldest(t *testing.T) {
+	cred := &Credential{}
+	for i := 0; i < 5; i++ {
+		cred.appendRevision(Revision{}, 3)
+	}
+
+	if len(cred.Revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(cred.Revisions))
+	}
+	if cred.Revisions[0].Number != 3 || cred.Revisions[2].Number != 5 {
+		t.Errorf("revision numbers = %d..%d, want 3..5", cred.Revisions[0].Number, cred.Revisions[2].Number)
+	}
+
+	// Zero disables history and clears existing revisions
+	cred.appendRevision(Revision{}, 0)
+	if cred.Revisions != nil {
+		t.Errorf("expected revisions cleared, got %d", len(cred.Revisions))
+	}
+}
+
+func TestCredentialHistoryAndRestore(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("github", "alice", []byte("first"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	second := []byte("second")
+	if err := vault.UpdateCredential("github", UpdateOpts{Password: &second}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	username := "bob"
+	if err := vault.UpdateCredential("github", UpdateOpts{Username: &username}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	history, err := vault.GetHistory("github")
+	if err != nil {
+		t.Fatalf("GetHistory() failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(history))
+	}
+	if string(history[0].Password) != "first" || history[0].Username != nil {
+		t.Errorf("revision 1 = %+v, want password-only change", history[0])
+	}
+	if history[1].Username == nil || *history[1].Username != "alice" {
+		t.Errorf("revision 2 = %+v, want previous username alice", history[1])
+	}
+
+	// GetCredential must not leak previous passwords
+	cred, err := vault.GetCredential("github", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if cred.Revisions != nil {
+		t.Error("GetCredential() returned revisions")
+	}
+
+	metadata, err := vault.ListCredentialsWithMetadata()
+	if err != nil {
+		t.Fatalf("ListCredentialsWithMetadata() failed: %v", err)
+	}
+	if metadata[0].RevisionCount != 2 {
+		t.Errorf("RevisionCount = %d, want 2", metadata[0].RevisionCount)
+	}
+
+	// Restore only touches the recorded fields and is itself recorded
+	if err := vault.RestoreRevision("github", 1); err != nil {
+		t.Fatalf("RestoreRevision() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("github", false)
+	if string(cred.Password) != "first" || cred.Username != "bob" {
+		t.Errorf("after restore: username=%s password=%s, want bob/first", cred.Username, cred.Password)
+	}
+
+	history, _ = vault.GetHistory("github")
+	if len(history) != 3 || string(history[2].Password) != "second" {
+		t.Errorf("expected restore to record previous password, got %+v", history)
+	}
+
+	if err := vault.RestoreRevision("github", 99); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("RestoreRevision(99) error = %v, want ErrRevisionNotFound", err)
+	}
+}
+
+func TestRestoreRevisionCustomFields(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("aws", "admin", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	// Revision 1 records the empty field set, revision 2 records region=us
+	if err := vault.UpdateCredential("aws", UpdateOpts{SetCustomFields: []CustomField{{Name: "region", Value: "us"}}}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	if err := vault.UpdateCredential("aws", UpdateOpts{
+		RemoveCustomFields: []string{"region"},
+		SetCustomFields:    []CustomField{{Name: "zone", Value: "b"}},
+	}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	if err := vault.RestoreRevision("aws", 2); err != nil {
+		t.Fatalf("RestoreRevision() failed: %v", err)
+	}
+
+	cred, _ := vault.GetCredential("aws", false)
+	if len(cred.CustomFields) != 1 || cred.CustomFields[0].Name != "region" {
+		t.Errorf("custom fields after restore = %+v, want [region]", cred.CustomFields)
+	}
+}
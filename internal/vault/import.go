@@ -0,0 +1,322 @@
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// Conflict strategies for ImportCredentials, applied when an imported credential
+// has the same service name as one already in the vault
+const (
+	ImportSkip      = "skip"      // Keep the existing credential
+	ImportOverwrite = "overwrite" // Replace its fields, keeping history and usage
+	ImportRename    = "rename"    // Import under a new name such as "github (2)"
+)
+
+// ImportStrategies returns the supported conflict strategies
+func ImportStrategies() []string {
+	return []string{ImportSkip, ImportOverwrite, ImportRename}
+}
+
+// ImportRenamed records a credential imported under a different name
+type ImportRenamed struct {
+	From string
+	To   string
+}
+
+// ImportDuplicate records a credential whose login is already in the vault
+type ImportDuplicate struct {
+	Service  string
+	Existing string // Name of the credential holding the same login
+}
+
+// ImportFailure records a credential that could not be imported
+type ImportFailure struct {
+	Service string
+	Err     error
+}
+
+// ImportResult summarizes an import. With a dry run nothing is saved, but the
+// result describes what the import would have done.
+type ImportResult struct {
+	DryRun      bool
+	Added       []string
+	Overwritten []string
+	Renamed     []ImportRenamed
+	Skipped     []string
+	Duplicates  []ImportDuplicate
+	Failed      []ImportFailure
+}
+
+// Imported returns the number of credentials written to the vault
+func (r *ImportResult) Imported() int {
+	return len(r.Added) + len(r.Overwritten) + len(r.Renamed)
+}
+
+// ImportCredentials adds records in bulk with a single save, so either every
+// importable record is written or none is. Records are validated like AddRecord;
+// invalid ones are reported as failures without stopping the import. Names that
+// repeat within the import are always renamed, whatever the strategy.
+// Logins already in the vault (or earlier in the import) under any name, with
+// the same username, password and URL host, are reported as duplicates and not
+// imported again; overwrite still refreshes a duplicate under its own name.
+// Record attachments only need Name and Data; large ones are written as sidecar
+// blobs (v2 vaults) just before the save. Record passwords are cleared once stored.
+func (v *VaultService) ImportCredentials(records []Credential, strategy string, dryRun bool) (*ImportResult, error) {
+	defer func() {
+		for i := range records {
+			crypto.ClearBytes(records[i].Password)
+		}
+	}()
+
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+	switch strategy {
+	case ImportSkip, ImportOverwrite, ImportRename:
+	default:
+		return nil, fmt.Errorf("unknown conflict strategy %q (expected skip, overwrite or rename)", strategy)
+	}
+
+	result := &ImportResult{DryRun: dryRun}
+	pending := make(map[string]Credential)
+	imported := make(map[string]bool) // Names taken by earlier records of this import
+	blobs := make(map[string][]byte)  // Sidecar attachment content by attachment ID
+
+	for _, record := range records {
+		credential, err := v.newRecord(record)
+		if err == nil {
+			credential.Attachments, err = v.importAttachments(record.Attachments, blobs)
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, ImportFailure{Service: record.Service, Err: err})
+			continue
+		}
+
+		existing, exists := v.vaultData.Credentials[record.Service]
+		if duplicate := v.findDuplicateLogin(credential, pending); duplicate != "" &&
+			!(strategy == ImportOverwrite && duplicate == record.Service && !imported[record.Service]) {
+			crypto.ClearBytes(credential.Password)
+			for _, attachment := range credential.Attachments {
+				delete(blobs, attachment.ID)
+			}
+			result.Duplicates = append(result.Duplicates, ImportDuplicate{Service: record.Service, Existing: duplicate})
+			continue
+		}
+
+		switch {
+		case imported[record.Service] || (exists && strategy == ImportRename):
+			credential.Service = v.importName(record.Service, imported)
+			result.Renamed = append(result.Renamed, ImportRenamed{From: record.Service, To: credential.Service})
+		case exists && strategy == ImportSkip:
+			crypto.ClearBytes(credential.Password)
+			for _, attachment := range credential.Attachments {
+				delete(blobs, attachment.ID)
+			}
+			result.Skipped = append(result.Skipped, record.Service)
+			continue
+		case exists:
+			credential = v.overwriteCredential(existing, credential)
+			result.Overwritten = append(result.Overwritten, record.Service)
+		default:
+			result.Added = append(result.Added, record.Service)
+		}
+
+		imported[credential.Service] = true
+		pending[credential.Service] = credential
+	}
+
+	if dryRun || len(pending) == 0 {
+		for _, credential := range pending {
+			crypto.ClearBytes(credential.Password)
+		}
+		return result, nil
+	}
+
+	// Write blobs before saving so the vault never references a missing file
+	written, err := v.writeImportBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]Credential, len(pending))
+	for service, credential := range pending {
+		if existing, exists := v.vaultData.Credentials[service]; exists {
+			previous[service] = existing
+		}
+		v.vaultData.Credentials[service] = credential
+	}
+
+	if err := v.save(); err != nil {
+		// Put the vault back the way it was so memory matches the file
+		for service := range pending {
+			if existing, exists := previous[service]; exists {
+				v.vaultData.Credentials[service] = existing
+			} else {
+				delete(v.vaultData.Credentials, service)
+			}
+		}
+		for _, id := range written {
+			_ = v.storageService.RemoveAttachmentBlob(id)
+		}
+		return nil, err
+	}
+
+	for _, service := range result.Added {
+		v.LogAudit(security.EventCredentialAdd, security.OutcomeSuccess, service)
+	}
+	for _, renamed := range result.Renamed {
+		v.LogAudit(security.EventCredentialAdd, security.OutcomeSuccess, renamed.To)
+	}
+	for _, service := range result.Overwritten {
+		v.LogAudit(security.EventCredentialUpdate, security.OutcomeSuccess, service)
+	}
+	return result, nil
+}
+
+// findDuplicateLogin returns the name of a credential in the vault or in pending
+// with the same username, password and URL host as credential, or "" if there is
+// none. Records without a password are never duplicates.
+func (v *VaultService) findDuplicateLogin(credential Credential, pending map[string]Credential) string {
+	if len(credential.Password) == 0 {
+		return ""
+	}
+	host := urlHost(credential.URL)
+	same := func(other Credential) bool {
+		return other.Username == credential.Username && bytes.Equal(other.Password, credential.Password) &&
+			urlHost(other.URL) == host
+	}
+
+	// Check the credential's own name first so overwrite can recognize it
+	if existing, exists := v.vaultData.Credentials[credential.Service]; exists && same(existing) {
+		return credential.Service
+	}
+	duplicate := ""
+	for _, candidates := range []map[string]Credential{v.vaultData.Credentials, pending} {
+		for service, other := range candidates {
+			// Lowest name wins so repeated runs report the same credential
+			if same(other) && (duplicate == "" || service < duplicate) {
+				duplicate = service
+			}
+		}
+	}
+	return duplicate
+}
+
+// urlHost returns the host name of a URL, or the trimmed URL if it has none
+func urlHost(raw string) string {
+	if u, err := parsePageURL(raw); err == nil {
+		return strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	return strings.TrimSpace(raw)
+}
+
+// importName returns the first free "<service> (n)" name, checking both the vault
+// and the names already taken by the import
+func (v *VaultService) importName(service string, imported map[string]bool) string {
+	for n := 2; ; n++ {
+		name := fmt.Sprintf("%s (%d)", service, n)
+		if _, exists := v.vaultData.Credentials[name]; !exists && !imported[name] {
+			return name
+		}
+	}
+}
+
+// overwriteCredential replaces existing's fields with the imported ones. Creation
+// time, usage, attachments and history are kept (imported attachments are added),
+// and the replaced values are recorded as a revision so the overwrite can be undone.
+func (v *VaultService) overwriteCredential(existing, imported Credential) Credential {
+	credential := imported
+	credential.CreatedAt = existing.CreatedAt
+	credential.UsageRecord = existing.UsageRecord
+	credential.Attachments = append([]Attachment(nil), existing.Attachments...)
+	for _, attachment := range imported.Attachments {
+		attachment.Name = uniqueAttachmentName(credential.Attachments, attachment.Name)
+		credential.Attachments = append(credential.Attachments, attachment)
+	}
+	credential.Revisions = existing.Revisions
+	credential.ModifiedCount = existing.ModifiedCount + 1
+	credential.RotatedAt = existing.RotatedAt
+	if secretChanged(&existing, &credential) {
+		rotatedAt := time.Now()
+		credential.RotatedAt = &rotatedAt
+	}
+
+	if rev := snapshotChanges(&existing, &credential); rev != nil {
+		rev.Timestamp = time.Now()
+		credential.appendRevision(*rev, v.maxRevisions)
+	}
+	return credential
+}
+
+// importAttachments builds the attachments of an imported record. Content of
+// sidecar attachments is added to blobs, to be written when the import is saved.
+func (v *VaultService) importAttachments(files []Attachment, blobs map[string][]byte) ([]Attachment, error) {
+	var attachments []Attachment
+	for _, file := range files {
+		attachment, err := newAttachment(uniqueAttachmentName(attachments, file.Name), file.Data)
+		if err != nil {
+			return nil, err
+		}
+		if attachment.Sidecar {
+			// Fail the record now (and in dry runs) if the vault cannot hold sidecar blobs
+			if v.identityDEK == nil && v.storageService.GetVersion() != 2 {
+				return nil, errSidecarNeedsV2
+			}
+			blobs[attachment.ID] = file.Data
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// writeImportBlobs writes the sidecar blobs of an import, returning the IDs written.
+// On error the blobs already written are removed again.
+func (v *VaultService) writeImportBlobs(blobs map[string][]byte) ([]string, error) {
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	dek, err := v.dataKey()
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.ClearBytes(dek)
+
+	written := make([]string, 0, len(blobs))
+	for id, data := range blobs {
+		if err := v.storageService.WriteAttachmentBlob(id, data, dek); err != nil {
+			for _, id := range written {
+				_ = v.storageService.RemoveAttachmentBlob(id)
+			}
+			return nil, err
+		}
+		written = append(written, id)
+	}
+	return written, nil
+}
+
+// uniqueAttachmentName returns name, or "name (n).ext" if attachments already has it
+func uniqueAttachmentName(attachments []Attachment, name string) string {
+	taken := func(candidate string) bool {
+		for _, attachment := range attachments {
+			if attachment.Name == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	unique := name
+	for n := 2; taken(unique); n++ {
+		unique = fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+	return unique
+}
@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// importRecords returns a fresh batch to import: a conflicting login, a name
+// repeated within the batch, a new note and an invalid card
+func importRecords() []Credential {
+	return []Credential{
+		{Service: "github", Username: "new-user", Password: []byte("new-pass"), Category: "Work"},
+		{Service: "aws", Username: "root", Password: []byte("aws-pass")},
+		{Service: "aws", Username: "admin", Password: []byte("aws-pass-2")},
+		{Service: "wifi", Type: RecordTypeNote, Notes: "ssid: home"},
+		{Service: "visa", Type: RecordTypeCard},
+	}
+}
+
+func TestImportCredentials(t *testing.T) {
+	tests := []struct {
+		strategy    string
+		added       int
+		overwritten int
+		renamed     int
+		skipped     int
+		githubUser  string
+	}{
+		{ImportSkip, 2, 0, 1, 1, "user"},
+		{ImportOverwrite, 2, 1, 1, 0, "new-user"},
+		{ImportRename, 2, 0, 2, 0, "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			vault, vaultPath, cleanup := setupUnlockedVault(t, false)
+			defer cleanup()
+
+			result, err := vault.ImportCredentials(importRecords(), tt.strategy, false)
+			if err != nil {
+				t.Fatalf("ImportCredentials() failed: %v", err)
+			}
+			if len(result.Added) != tt.added || len(result.Overwritten) != tt.overwritten ||
+				len(result.Renamed) != tt.renamed || len(result.Skipped) != tt.skipped {
+				t.Errorf("result = %+v", result)
+			}
+			if len(result.Failed) != 1 || result.Failed[0].Service != "visa" || !errors.Is(result.Failed[0].Err, ErrInvalidCredential) {
+				t.Errorf("Failed = %+v, want the invalid card", result.Failed)
+			}
+
+			// Everything was written by the single save
+			vault.Lock()
+			reopened, _ := New(vaultPath)
+			if err := reopened.Unlock([]byte("TestPassword123!")); err != nil {
+				t.Fatalf("Unlock() failed: %v", err)
+			}
+			defer reopened.Lock()
+
+			github, err := reopened.GetCredential("github", false)
+			if err != nil || github.Username != tt.githubUser {
+				t.Errorf("github username = %q, %v; want %q", github.Username, err, tt.githubUser)
+			}
+			if second, err := reopened.GetCredential("aws (2)", false); err != nil || second.Username != "admin" {
+				t.Errorf("repeated name not renamed: %+v, %v", second, err)
+			}
+			if _, err := reopened.GetCredential("wifi", false); err != nil {
+				t.Errorf("note not imported: %v", err)
+			}
+			if tt.strategy == ImportRename {
+				if renamed, err := reopened.GetCredential("github (2)", false); err != nil || renamed.Username != "new-user" {
+					t.Errorf("conflict not renamed: %+v, %v", renamed, err)
+				}
+			}
+		})
+	}
+}
+
+func TestImportOverwriteKeepsHistory(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	if _, err := vault.ImportCredentials(importRecords()[:1], ImportOverwrite, false); err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+
+	github, err := vault.GetCredential("github", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if string(github.Password) != "new-pass" || github.Category != "Work" || github.ModifiedCount != 1 {
+		t.Errorf("overwrite not applied: %+v", github)
+	}
+	revisions, err := vault.GetHistory("github")
+	if err != nil || len(revisions) != 1 || string(revisions[0].Password) != "pass" {
+		t.Fatalf("GetHistory() = %+v, %v; want the old password", revisions, err)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	result, err := vault.ImportCredentials(importRecords(), ImportOverwrite, true)
+	if err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+	if !result.DryRun || result.Imported() != 4 {
+		t.Errorf("dry run result = %+v, want 4 importable", result)
+	}
+
+	if services, _ := vault.ListCredentials(); len(services) != 1 {
+		t.Errorf("dry run changed the vault: %v", services)
+	}
+	github, _ := vault.GetCredential("github", false)
+	if github.Username != "user" {
+		t.Errorf("dry run overwrote github: %q", github.Username)
+	}
+
+	if _, err := vault.ImportCredentials(nil, "merge", false); err == nil {
+		t.Error("expected error for an unknown strategy")
+	}
+}
+
+func TestImportDuplicates(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	if err := vault.AddCredential("GitHub Login", "alice", []byte("s3cret"), "", "https://github.com/login", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+	records := func() []Credential {
+		return []Credential{
+			// Same login as "GitHub Login" under another name
+			{Service: "github.com", Username: "alice", Password: []byte("s3cret"), URL: "https://www.github.com"},
+			// Same login as "github", which overwrite still refreshes
+			{Service: "github", Username: "user", Password: []byte("pass"), Notes: "refreshed"},
+			// Repeated within the import
+			{Service: "example", Username: "bob", Password: []byte("pw"), URL: "example.com"},
+			{Service: "example.com", Username: "bob", Password: []byte("pw"), URL: "https://example.com/"},
+			// Different password: not a duplicate
+			{Service: "github.com", Username: "alice", Password: []byte("other"), URL: "https://github.com"},
+		}
+	}
+
+	result, err := vault.ImportCredentials(records(), ImportRename, true)
+	if err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+	want := []ImportDuplicate{{"github.com", "GitHub Login"}, {"github", "github"}, {"example.com", "example"}}
+	if len(result.Duplicates) != len(want) {
+		t.Fatalf("Duplicates = %+v, want %+v", result.Duplicates, want)
+	}
+	for i := range want {
+		if result.Duplicates[i] != want[i] {
+			t.Errorf("Duplicates[%d] = %+v, want %+v", i, result.Duplicates[i], want[i])
+		}
+	}
+	if len(result.Added) != 2 {
+		t.Errorf("Added = %v, want example and github.com", result.Added)
+	}
+
+	result, err = vault.ImportCredentials(records(), ImportOverwrite, false)
+	if err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+	if len(result.Overwritten) != 1 || len(result.Duplicates) != 2 {
+		t.Errorf("result = %+v, want github overwritten and 2 duplicates", result)
+	}
+	if github, _ := vault.GetCredential("github", false); github.Notes != "refreshed" {
+		t.Errorf("github notes = %q, want the overwrite applied", github.Notes)
+	}
+}
+
+func TestImportAttachments(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	small := []byte("recovery codes")
+	large := bytes.Repeat([]byte("z"), InlineAttachmentLimit+1)
+	records := []Credential{
+		{Service: "server", Type: RecordTypeNote, Attachments: []Attachment{
+			{Name: "key.txt", Data: small},
+			{Name: "key.txt", Data: large},
+		}},
+		{Service: "github", Password: []byte("new-pass"), Attachments: []Attachment{{Name: "codes.txt", Data: small}}},
+	}
+	if _, err := vault.ImportCredentials(records, ImportOverwrite, false); err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+
+	for _, tt := range []struct {
+		service, name string
+		want          []byte
+	}{
+		{"server", "key.txt", small},
+		{"server", "key (2).txt", large},
+		{"github", "codes.txt", small},
+	} {
+		if _, got, err := vault.GetAttachment(tt.service, tt.name); err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("GetAttachment(%s, %s) failed: %v", tt.service, tt.name, err)
+		}
+	}
+
+	// v1 vaults cannot hold sidecar blobs, so the record fails instead
+	v1, _, cleanupV1 := setupUnlockedVault(t, false)
+	defer cleanupV1()
+	result, err := v1.ImportCredentials(records[:1], ImportSkip, false)
+	if err != nil {
+		t.Fatalf("ImportCredentials() failed: %v", err)
+	}
+	if len(result.Failed) != 1 {
+		t.Errorf("Failed = %+v, want the record with a large attachment", result.Failed)
+	}
+}
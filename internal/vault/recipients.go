@@ -0,0 +1,427 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/config"
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/recovery"
+	"github.com/arimxyer/pass-cli/internal/security"
+	"github.com/arimxyer/pass-cli/internal/storage"
+)
+
+// IdentityFileName is the identity file kept next to the config file
+const IdentityFileName = "identity"
+
+var (
+	// ErrNoIdentity is returned when no identity file exists
+	ErrNoIdentity = errors.New("no identity file")
+	// ErrRecipientNotFound is returned when no recipient has the given name
+	ErrRecipientNotFound = errors.New("recipient not found")
+	// ErrRecipientExists is returned when the name or public key is already a recipient
+	ErrRecipientExists = errors.New("recipient already exists")
+	// ErrPasswordRequired is returned for operations that re-wrap the DEK with the
+	// password KEK while the vault was unlocked with an identity
+	ErrPasswordRequired = errors.New("this operation needs the master password (vault was unlocked with an identity)")
+)
+
+// Recipient describes a team member who can open the vault with their own identity
+type Recipient struct {
+	Name      string
+	PublicKey string // Encoded public key ("pass-cli-pub-...")
+	AddedAt   time.Time
+}
+
+// IdentityPath returns the identity file location: PASS_CLI_IDENTITY if set,
+// otherwise "identity" in the config directory (~/.pass-cli/identity).
+func IdentityPath() (string, error) {
+	if envPath := os.Getenv("PASS_CLI_IDENTITY"); envPath != "" {
+		return envPath, nil
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), IdentityFileName), nil
+}
+
+// GenerateIdentity creates a new identity file at path and returns its encoded
+// public key. An existing identity is never overwritten.
+func GenerateIdentity(path string) (string, error) {
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("identity already exists: %s", path)
+	}
+
+	publicKey, privateKey, err := crypto.GenerateRecipientKey()
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(privateKey)
+
+	encoded := crypto.EncodeRecipientKey(publicKey)
+	content := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n",
+		time.Now().Format(time.RFC3339), encoded, crypto.EncodeIdentityKey(privateKey))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create identity directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write identity: %w", err)
+	}
+	return encoded, nil
+}
+
+// LoadIdentity reads the secret key from an identity file. Lines starting with
+// '#' are comments. The caller must clear the returned key.
+func LoadIdentity(path string) ([]byte, error) {
+	// #nosec G304 -- identity path is user-controlled by design for CLI tool
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNoIdentity
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity: %w", err)
+	}
+	defer crypto.ClearBytes(data)
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, err := crypto.ParseIdentityKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity file %s: %w", path, err)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("invalid identity file %s: no secret key", path)
+}
+
+// IdentityPublicKey returns the encoded public key of the identity file at path
+func IdentityPublicKey(path string) (string, error) {
+	privateKey, err := LoadIdentity(path)
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(privateKey)
+
+	publicKey, err := crypto.RecipientPublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.EncodeRecipientKey(publicKey), nil
+}
+
+// UnlockWithIdentity unlocks the vault with the local identity file, if it
+// belongs to one of the vault's recipients. Returns ErrNoIdentity when there is
+// no identity file and storage.ErrNotRecipient when it has no recipient slot.
+func (v *VaultService) UnlockWithIdentity() error {
+	if v.unlocked {
+		return nil // Already unlocked
+	}
+
+	path, err := IdentityPath()
+	if err != nil {
+		return err
+	}
+	privateKey, err := LoadIdentity(path)
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(privateKey)
+
+	name, dek, err := v.storageService.RecipientDataKey(privateKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotRecipient) {
+			v.LogAudit(security.EventVaultUnlock, security.OutcomeFailure, "identity")
+		}
+		return err
+	}
+
+	data, err := v.storageService.LoadVaultWithKey(dek)
+	if err != nil {
+		crypto.ClearBytes(dek)
+		v.LogAudit(security.EventVaultUnlock, security.OutcomeFailure, "identity")
+		return fmt.Errorf("failed to unlock vault with identity: %w", err)
+	}
+
+	var vaultData VaultData
+	if err := json.Unmarshal(data, &vaultData); err != nil {
+		crypto.ClearBytes(dek)
+		return fmt.Errorf("failed to parse vault data: %w", err)
+	}
+
+	v.unlocked = true
+	v.masterPassword = nil // Identity unlock doesn't have a password
+	v.vaultData = &vaultData
+	v.identityDEK = dek
+	v.identityName = name
+
+	v.finishKeyUnlock(&vaultData)
+
+	v.LogAudit(security.EventVaultUnlock, security.OutcomeSuccess, "identity:"+name)
+
+	return nil
+}
+
+// UnlockedIdentity returns the recipient name when the vault was unlocked with an identity
+func (v *VaultService) UnlockedIdentity() string {
+	return v.identityName
+}
+
+// ListRecipients returns the vault's recipients. It reads the vault header, so
+// the vault does not need to be unlocked.
+func (v *VaultService) ListRecipients() ([]Recipient, error) {
+	slots, err := v.storageService.Recipients()
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]Recipient, 0, len(slots))
+	for _, slot := range slots {
+		recipients = append(recipients, Recipient{
+			Name:      slot.Name,
+			PublicKey: crypto.EncodeRecipientKey(slot.PublicKey),
+			AddedAt:   slot.AddedAt,
+		})
+	}
+	return recipients, nil
+}
+
+// AddRecipient wraps the vault's DEK to a team member's public key, so they can
+// unlock the vault with their identity file.
+func (v *VaultService) AddRecipient(name, publicKey string) error {
+	if !v.unlocked {
+		return ErrVaultLocked
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: recipient name cannot be empty", ErrInvalidCredential)
+	}
+	key, err := crypto.ParseRecipientKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("%w: expected a public key starting with %s", err, crypto.RecipientKeyPrefix)
+	}
+
+	slots, err := v.storageService.Recipients()
+	if err != nil {
+		return err
+	}
+	for _, slot := range slots {
+		if slot.Name == name {
+			return fmt.Errorf("%w: %s", ErrRecipientExists, name)
+		}
+		if bytes.Equal(slot.PublicKey, key) {
+			return fmt.Errorf("%w: key already belongs to %s", ErrRecipientExists, slot.Name)
+		}
+	}
+
+	if v.storageService.GetVersion() != 2 {
+		return errors.New("recipients need a v2 vault: run 'pass-cli vault migrate' first")
+	}
+	dek, err := v.dataKey()
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(dek)
+
+	slot, err := storage.NewRecipientSlot(name, key, dek)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v.vaultData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault data: %w", err)
+	}
+	updated := append(append([]storage.RecipientSlot(nil), slots...), slot)
+	if err := v.storageService.SaveVaultWithRecipients(data, dek, updated, v.createAuditCallback()); err != nil {
+		return fmt.Errorf("failed to save vault: %w", err)
+	}
+
+	v.LogAudit(security.EventRecipientAdd, security.OutcomeSuccess, name)
+	return nil
+}
+
+// RemoveRecipient removes a recipient and rotates the DEK, so a copy of the old
+// key kept by the removed member opens nothing written from now on. The vault and
+// its sidecar attachments are re-encrypted, and the new DEK is wrapped with the
+// master password and the remaining recipients' public keys.
+//
+// The recovery phrase wraps the old DEK and cannot be updated without it, so when
+// recovery is enabled a new phrase is generated (protected with passphrase, if
+// given) and returned; it is empty when recovery is not set up.
+func (v *VaultService) RemoveRecipient(name string, passphrase []byte) (string, error) {
+	if passphrase != nil {
+		defer crypto.ClearBytes(passphrase)
+	}
+
+	if !v.unlocked {
+		return "", ErrVaultLocked
+	}
+	if v.masterPassword == nil {
+		return "", ErrPasswordRequired
+	}
+
+	slots, err := v.storageService.Recipients()
+	if err != nil {
+		return "", err
+	}
+	found := false
+	for _, slot := range slots {
+		if slot.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("%w: %s", ErrRecipientNotFound, name)
+	}
+
+	oldDEK, err := v.dataKey()
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(oldDEK)
+
+	newDEK, err := crypto.GenerateDEK()
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(newDEK)
+
+	// Re-wrap the new DEK for everyone who stays
+	remaining := make([]storage.RecipientSlot, 0, len(slots)-1)
+	for _, slot := range slots {
+		if slot.Name == name {
+			continue
+		}
+		rewrapped, err := storage.NewRecipientSlot(slot.Name, slot.PublicKey, newDEK)
+		if err != nil {
+			return "", err
+		}
+		rewrapped.AddedAt = slot.AddedAt
+		remaining = append(remaining, rewrapped)
+	}
+
+	// Set up a new recovery phrase before anything is written
+	meta, err := LoadMetadata(v.vaultPath)
+	if err != nil {
+		return "", err
+	}
+	var challengeSetup *recovery.ChallengeSetupResult
+	var recoveryWrapped crypto.WrappedKey
+	if meta.Recovery != nil && meta.Recovery.Enabled {
+		challengeSetup, err = recovery.SetupChallengeRecovery(&recovery.ChallengeSetupConfig{
+			Passphrase: passphrase,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to setup recovery: %w", err)
+		}
+		defer crypto.ClearBytes(challengeSetup.RecoveryKEK)
+
+		recoveryWrapped, err = crypto.WrapKey(newDEK, challengeSetup.RecoveryKEK)
+		if err != nil {
+			return "", fmt.Errorf("failed to wrap DEK for recovery: %w", err)
+		}
+	}
+
+	// Re-encrypt sidecar blobs under new IDs; blobs are immutable and the
+	// backup written by the save below still needs the old ones
+	rotated, oldBlobs, newBlobs, err := v.rotateAttachmentBlobs(oldDEK, newDEK)
+	if err != nil {
+		v.removeAttachmentBlobs(newBlobs)
+		return "", err
+	}
+
+	data, err := json.Marshal(rotated)
+	if err != nil {
+		v.removeAttachmentBlobs(newBlobs)
+		return "", fmt.Errorf("failed to marshal vault data: %w", err)
+	}
+	if err := v.storageService.RotateDEK(data, newDEK, string(v.masterPassword), remaining, v.createAuditCallback()); err != nil {
+		v.removeAttachmentBlobs(newBlobs)
+		return "", fmt.Errorf("failed to rotate vault key: %w", err)
+	}
+	v.vaultData = rotated
+	v.removeAttachmentBlobs(oldBlobs)
+
+	mnemonic := ""
+	if challengeSetup != nil {
+		recoveryMetadata := challengeSetup.Metadata
+		recoveryMetadata.EncryptedRecoveryKey = recoveryWrapped.Ciphertext
+		recoveryMetadata.NonceRecovery = recoveryWrapped.Nonce
+		meta.Recovery = recoveryMetadata
+		if err := SaveMetadata(v.vaultPath, meta); err != nil {
+			return "", fmt.Errorf("vault key rotated, but failed to save the new recovery phrase: %w", err)
+		}
+		mnemonic = challengeSetup.Mnemonic
+	}
+
+	v.LogAudit(security.EventRecipientRemove, security.OutcomeSuccess, name)
+	return mnemonic, nil
+}
+
+// rotateAttachmentBlobs returns a copy of the vault data whose sidecar blobs are
+// re-encrypted with newDEK under new IDs, plus the old and new blobs
+func (v *VaultService) rotateAttachmentBlobs(oldDEK, newDEK []byte) (*VaultData, []Attachment, []Attachment, error) {
+	// Deep copy, so a failed rotation leaves the unlocked vault untouched
+	raw, err := json.Marshal(v.vaultData)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal vault data: %w", err)
+	}
+	var rotated VaultData
+	if err := json.Unmarshal(raw, &rotated); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to copy vault data: %w", err)
+	}
+
+	var oldBlobs, newBlobs []Attachment
+	rotate := func(attachments []Attachment) error {
+		for i, attachment := range attachments {
+			if !attachment.Sidecar {
+				continue
+			}
+			content, err := v.storageService.ReadAttachmentBlob(attachment.ID, oldDEK)
+			if err != nil {
+				return err
+			}
+			id, err := newAttachmentID()
+			if err != nil {
+				crypto.ClearBytes(content)
+				return err
+			}
+			err = v.storageService.WriteAttachmentBlob(id, content, newDEK)
+			crypto.ClearBytes(content)
+			if err != nil {
+				return err
+			}
+			oldBlobs = append(oldBlobs, attachment)
+			attachments[i].ID = id
+			newBlobs = append(newBlobs, attachments[i])
+		}
+		return nil
+	}
+
+	for service, credential := range rotated.Credentials {
+		if err := rotate(credential.Attachments); err != nil {
+			return nil, oldBlobs, newBlobs, err
+		}
+		rotated.Credentials[service] = credential
+	}
+	for i := range rotated.Trash {
+		if err := rotate(rotated.Trash[i].Credential.Attachments); err != nil {
+			return nil, oldBlobs, newBlobs, err
+		}
+	}
+
+	return &rotated, oldBlobs, newBlobs, nil
+}
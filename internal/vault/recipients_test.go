@@ -0,0 +1,184 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arimxyer/pass-cli/internal/storage"
+)
+
+// newTestIdentity writes an identity file and returns its path and public key
+func newTestIdentity(t *testing.T) (string, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "identity")
+	publicKey, err := GenerateIdentity(path)
+	if err != nil {
+		t.Fatalf("GenerateIdentity() failed: %v", err)
+	}
+	return path, publicKey
+}
+
+func TestIdentityFile(t *testing.T) {
+	path, publicKey := newTestIdentity(t)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("identity not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("identity permissions = %o, want 600", info.Mode().Perm())
+	}
+	if got, err := IdentityPublicKey(path); err != nil || got != publicKey {
+		t.Errorf("IdentityPublicKey() = %q, %v; want %q", got, err, publicKey)
+	}
+	if _, err := GenerateIdentity(path); err == nil {
+		t.Error("expected GenerateIdentity to refuse overwriting an identity")
+	}
+	if _, err := LoadIdentity(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, ErrNoIdentity) {
+		t.Errorf("missing identity error = %v, want ErrNoIdentity", err)
+	}
+}
+
+func TestRecipientUnlock(t *testing.T) {
+	vault, vaultPath, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	aliceIdentity, alice := newTestIdentity(t)
+	_, bob := newTestIdentity(t)
+
+	if err := vault.AddRecipient("alice", alice); err != nil {
+		t.Fatalf("AddRecipient(alice) failed: %v", err)
+	}
+	if err := vault.AddRecipient("bob", bob); err != nil {
+		t.Fatalf("AddRecipient(bob) failed: %v", err)
+	}
+	if err := vault.AddRecipient("alice", bob); !errors.Is(err, ErrRecipientExists) {
+		t.Errorf("duplicate name error = %v, want ErrRecipientExists", err)
+	}
+	if err := vault.AddRecipient("carol", alice); !errors.Is(err, ErrRecipientExists) {
+		t.Errorf("duplicate key error = %v, want ErrRecipientExists", err)
+	}
+	if err := vault.AddRecipient("carol", "not-a-key"); err == nil {
+		t.Error("expected error for an invalid public key")
+	}
+
+	recipients, err := vault.ListRecipients()
+	if err != nil || len(recipients) != 2 || recipients[0].Name != "alice" || recipients[1].PublicKey != bob {
+		t.Fatalf("ListRecipients() = %+v, %v", recipients, err)
+	}
+	vault.Lock()
+
+	// Alice opens the vault with her identity, and can write to it
+	t.Setenv("PASS_CLI_IDENTITY", aliceIdentity)
+	member, err := New(vaultPath)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := member.UnlockWithIdentity(); err != nil {
+		t.Fatalf("UnlockWithIdentity() failed: %v", err)
+	}
+	if member.UnlockedIdentity() != "alice" {
+		t.Errorf("UnlockedIdentity() = %q, want alice", member.UnlockedIdentity())
+	}
+	if err := member.AddCredential("aws", "alice", []byte("secret"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() as recipient failed: %v", err)
+	}
+	if err := member.ChangePassword([]byte("NewPassword123!")); !errors.Is(err, ErrPasswordRequired) {
+		t.Errorf("ChangePassword() error = %v, want ErrPasswordRequired", err)
+	}
+	member.Lock()
+
+	// The owner still opens it with the password and sees the change
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if _, err := vault.GetCredential("aws", false); err != nil {
+		t.Errorf("credential written by recipient not readable: %v", err)
+	}
+
+	// An identity without a slot is rejected
+	otherIdentity, _ := newTestIdentity(t)
+	t.Setenv("PASS_CLI_IDENTITY", otherIdentity)
+	outsider, _ := New(vaultPath)
+	if err := outsider.UnlockWithIdentity(); !errors.Is(err, storage.ErrNotRecipient) {
+		t.Errorf("UnlockWithIdentity() error = %v, want ErrNotRecipient", err)
+	}
+}
+
+func TestRemoveRecipientRotatesKey(t *testing.T) {
+	vault, vaultPath, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	aliceIdentity, alice := newTestIdentity(t)
+	bobIdentity, bob := newTestIdentity(t)
+	for name, key := range map[string]string{"alice": alice, "bob": bob} {
+		if err := vault.AddRecipient(name, key); err != nil {
+			t.Fatalf("AddRecipient(%s) failed: %v", name, err)
+		}
+	}
+
+	large := bytes.Repeat([]byte("y"), InlineAttachmentLimit+1)
+	before, err := vault.AddAttachment("github", "dump.bin", large)
+	if err != nil {
+		t.Fatalf("AddAttachment() failed: %v", err)
+	}
+
+	// Keep the DEK Bob could have copied
+	oldDEK, err := vault.dataKey()
+	if err != nil {
+		t.Fatalf("dataKey() failed: %v", err)
+	}
+
+	mnemonic, err := vault.RemoveRecipient("bob", nil)
+	if err != nil {
+		t.Fatalf("RemoveRecipient() failed: %v", err)
+	}
+	if len(strings.Fields(mnemonic)) != 24 {
+		t.Errorf("expected a new 24-word recovery phrase, got %q", mnemonic)
+	}
+	if _, err := vault.RemoveRecipient("bob", nil); !errors.Is(err, ErrRecipientNotFound) {
+		t.Errorf("second RemoveRecipient() error = %v, want ErrRecipientNotFound", err)
+	}
+
+	if _, err := vault.storageService.LoadVaultWithKey(oldDEK); err == nil {
+		t.Error("the old DEK should no longer decrypt the vault")
+	}
+	if _, err := os.Stat(filepath.Join(storage.AttachmentDirFor(vaultPath), before.ID+".blob")); !os.IsNotExist(err) {
+		t.Error("the blob encrypted with the old DEK should be removed")
+	}
+	if _, got, err := vault.GetAttachment("github", "dump.bin"); err != nil || !bytes.Equal(got, large) {
+		t.Errorf("sidecar attachment not readable after rotation: %v", err)
+	}
+	vault.Lock()
+
+	// Password, remaining recipient and new recovery phrase open the rotated vault
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() after rotation failed: %v", err)
+	}
+	vault.Lock()
+
+	t.Setenv("PASS_CLI_IDENTITY", aliceIdentity)
+	member, _ := New(vaultPath)
+	if err := member.UnlockWithIdentity(); err != nil {
+		t.Errorf("remaining recipient cannot unlock: %v", err)
+	}
+	if _, got, err := member.GetAttachment("github", "dump.bin"); err != nil || !bytes.Equal(got, large) {
+		t.Errorf("remaining recipient cannot read the attachment: %v", err)
+	}
+	member.Lock()
+
+	t.Setenv("PASS_CLI_IDENTITY", bobIdentity)
+	removed, _ := New(vaultPath)
+	if err := removed.UnlockWithIdentity(); !errors.Is(err, storage.ErrNotRecipient) {
+		t.Errorf("removed recipient unlock error = %v, want ErrNotRecipient", err)
+	}
+
+	recovered, _ := New(vaultPath)
+	if err := recovered.RecoverWithMnemonic(mnemonic, nil); err != nil {
+		t.Errorf("new recovery phrase does not work: %v", err)
+	}
+}
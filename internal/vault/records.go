@@ -0,0 +1,348 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// Record types. Credentials saved before record types existed have an empty
+// Type and are treated as logins.
+const (
+	RecordTypeLogin    = "login"
+	RecordTypeNote     = "note"
+	RecordTypeAPIToken = "api-token"
+	RecordTypeSSHKey   = "ssh-key"
+	RecordTypeCard     = "card"
+	RecordTypeIdentity = "identity"
+	RecordTypeDatabase = "database"
+)
+
+// SchemaField describes a type-specific field. Values are stored as custom fields
+// so they work with 'get --field', history and the TUI field editor unchanged.
+type SchemaField struct {
+	Name      string
+	Hidden    bool // Masked on display and copied to clipboard instead of printed
+	Required  bool
+	Multiline bool // Usually read from a file (e.g. private keys)
+}
+
+// RecordSchema describes which built-in fields a record type uses and which
+// type-specific fields it expects.
+type RecordSchema struct {
+	Type             string
+	Description      string
+	UsernameLabel    string // Empty when the type has no username
+	PasswordLabel    string // Empty when the type has no password
+	PasswordRequired bool
+	DefaultField     string // Field returned by 'get --quiet' when --field is not given
+	Fields           []SchemaField
+}
+
+// recordSchemas lists the supported record types in display order
+var recordSchemas = []RecordSchema{
+	{
+		Type:             RecordTypeLogin,
+		Description:      "Username and password for a website or service",
+		UsernameLabel:    "Username",
+		PasswordLabel:    "Password",
+		PasswordRequired: true,
+		DefaultField:     "password",
+	},
+	{
+		Type:         RecordTypeNote,
+		Description:  "Free-form secure note (stored in notes)",
+		DefaultField: "notes",
+	},
+	{
+		Type:             RecordTypeAPIToken,
+		Description:      "API token or access key",
+		UsernameLabel:    "Account",
+		PasswordLabel:    "Token",
+		PasswordRequired: true,
+		DefaultField:     "password",
+		Fields: []SchemaField{
+			{Name: "key-id"},
+			{Name: "expires"},
+		},
+	},
+	{
+		Type:          RecordTypeSSHKey,
+		Description:   "SSH key pair (password holds the key passphrase)",
+		UsernameLabel: "User",
+		PasswordLabel: "Passphrase",
+		DefaultField:  "private-key",
+		Fields: []SchemaField{
+			{Name: "private-key", Hidden: true, Required: true, Multiline: true},
+			{Name: "public-key", Multiline: true},
+			{Name: "comment"},
+			{Name: "fingerprint"},
+			{Name: "agent-confirm"},  // "yes" to confirm every use in 'pass-cli ssh-agent'
+			{Name: "agent-lifetime"}, // How long 'pass-cli ssh-agent' keeps the key (e.g. 1h)
+		},
+	},
+	{
+		Type:         RecordTypeCard,
+		Description:  "Payment card",
+		DefaultField: "number",
+		Fields: []SchemaField{
+			{Name: "cardholder"},
+			{Name: "number", Hidden: true, Required: true},
+			{Name: "expiry"},
+			{Name: "cvv", Hidden: true},
+			{Name: "pin", Hidden: true},
+		},
+	},
+	{
+		Type:         RecordTypeIdentity,
+		Description:  "Personal identity details",
+		DefaultField: "full-name",
+		Fields: []SchemaField{
+			{Name: "full-name", Required: true},
+			{Name: "email"},
+			{Name: "phone"},
+			{Name: "address", Multiline: true},
+			{Name: "birth-date"},
+			{Name: "id-number", Hidden: true},
+		},
+	},
+	{
+		Type:             RecordTypeDatabase,
+		Description:      "Database connection",
+		UsernameLabel:    "Username",
+		PasswordLabel:    "Password",
+		PasswordRequired: true,
+		DefaultField:     "password",
+		Fields: []SchemaField{
+			{Name: "engine"},
+			{Name: "host", Required: true},
+			{Name: "port"},
+			{Name: "database"},
+		},
+	},
+}
+
+// recordTypeAliases maps shorthand type names to their canonical form
+var recordTypeAliases = map[string]string{
+	"":            RecordTypeLogin,
+	"secure-note": RecordTypeNote,
+	"api":         RecordTypeAPIToken,
+	"token":       RecordTypeAPIToken,
+	"ssh":         RecordTypeSSHKey,
+	"db":          RecordTypeDatabase,
+}
+
+// RecordTypes returns the names of all supported record types in display order
+func RecordTypes() []string {
+	types := make([]string, 0, len(recordSchemas))
+	for _, schema := range recordSchemas {
+		types = append(types, schema.Type)
+	}
+	return types
+}
+
+// NormalizeRecordType resolves aliases and validates a record type name.
+// An empty type resolves to login.
+func NormalizeRecordType(recordType string) (string, error) {
+	recordType = strings.ToLower(strings.TrimSpace(recordType))
+	if canonical, ok := recordTypeAliases[recordType]; ok {
+		recordType = canonical
+	}
+	if _, ok := GetRecordSchema(recordType); !ok {
+		return "", fmt.Errorf("%w: unknown record type %q (valid: %s)", ErrInvalidCredential, recordType, strings.Join(RecordTypes(), ", "))
+	}
+	return recordType, nil
+}
+
+// GetRecordSchema returns the schema for a record type. An empty type returns the login schema.
+func GetRecordSchema(recordType string) (RecordSchema, bool) {
+	if recordType == "" {
+		recordType = RecordTypeLogin
+	}
+	for _, schema := range recordSchemas {
+		if schema.Type == recordType {
+			return schema, true
+		}
+	}
+	return RecordSchema{}, false
+}
+
+// Field returns the schema field with the given name (case-insensitive)
+func (s RecordSchema) Field(name string) (SchemaField, bool) {
+	for _, field := range s.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return SchemaField{}, false
+}
+
+// applyFieldFlags forces schema-defined fields to the schema's hidden setting and canonical name
+func (s RecordSchema) applyFieldFlags(fields []CustomField) {
+	for i := range fields {
+		if schemaField, ok := s.Field(fields[i].Name); ok {
+			fields[i].Name = schemaField.Name
+			fields[i].Hidden = schemaField.Hidden
+		}
+	}
+}
+
+// validateFields checks that every required schema field has a value
+func (s RecordSchema) validateFields(c *Credential) error {
+	for _, schemaField := range s.Fields {
+		if !schemaField.Required {
+			continue
+		}
+		field, found := c.GetCustomField(schemaField.Name)
+		if !found || strings.TrimSpace(field.Value) == "" {
+			return fmt.Errorf("%w: %s records require the %q field", ErrInvalidCredential, s.Type, schemaField.Name)
+		}
+	}
+	return nil
+}
+
+// RecordType returns the credential's record type, treating an empty type as login
+func (c *Credential) RecordType() string {
+	if c.Type == "" {
+		return RecordTypeLogin
+	}
+	return c.Type
+}
+
+// AddRecord adds a new credential of any record type. Type-specific values are passed
+// as custom fields and checked against the type's schema; tags are normalized.
+// The record's password is cleared once stored, as with AddCredential.
+func (v *VaultService) AddRecord(record Credential) error {
+	defer crypto.ClearBytes(record.Password)
+
+	if !v.unlocked {
+		return ErrVaultLocked
+	}
+
+	credential, err := v.newRecord(record)
+	if err != nil {
+		return err
+	}
+
+	// Check for duplicates
+	if _, exists := v.vaultData.Credentials[record.Service]; exists {
+		crypto.ClearBytes(credential.Password)
+		return fmt.Errorf("%w: %s", ErrCredentialExists, record.Service)
+	}
+
+	v.vaultData.Credentials[record.Service] = credential
+
+	if err := v.save(); err != nil {
+		return err
+	}
+
+	// T071: Log credential add (FR-020)
+	v.LogAudit(security.EventCredentialAdd, security.OutcomeSuccess, record.Service)
+	return nil
+}
+
+// newRecord validates record and builds the credential to store, with a copy of
+// its password and fresh timestamps. Shared by AddRecord and ImportCredentials.
+func (v *VaultService) newRecord(record Credential) (Credential, error) {
+	if record.Service == "" {
+		return Credential{}, fmt.Errorf("%w: service name cannot be empty", ErrInvalidCredential)
+	}
+
+	recordType, err := NormalizeRecordType(record.Type)
+	if err != nil {
+		return Credential{}, err
+	}
+	schema, _ := GetRecordSchema(recordType)
+
+	if record.RotationDays < 0 {
+		return Credential{}, fmt.Errorf("%w: rotation interval cannot be negative", ErrInvalidCredential)
+	}
+
+	if schema.PasswordRequired && len(record.Password) == 0 {
+		return Credential{}, fmt.Errorf("%w: %s cannot be empty", ErrInvalidCredential, strings.ToLower(schema.PasswordLabel))
+	}
+
+	fields := append([]CustomField(nil), record.CustomFields...)
+	for i, field := range fields {
+		if err := ValidateCustomFieldName(field.Name); err != nil {
+			return Credential{}, err
+		}
+		if containsFieldBefore(fields, i) {
+			return Credential{}, fmt.Errorf("%w: duplicate custom field %q", ErrInvalidCredential, field.Name)
+		}
+	}
+	schema.applyFieldFlags(fields)
+
+	credential := Credential{
+		Service:       record.Service,
+		Type:          recordType,
+		Username:      record.Username,
+		Category:      NormalizeFolder(record.Category),
+		URL:           record.URL,
+		Notes:         record.Notes,
+		ModifiedCount: 0, // Initialize modification counter
+		UsageRecord:   make(map[string]UsageRecord),
+		TOTPSecret:    record.TOTPSecret,
+		TOTPAlgorithm: record.TOTPAlgorithm,
+		TOTPDigits:    record.TOTPDigits,
+		TOTPPeriod:    record.TOTPPeriod,
+		TOTPIssuer:    record.TOTPIssuer,
+		RotationDays:  record.RotationDays,
+	}
+	if record.ExpiresAt != nil {
+		expiresAt := *record.ExpiresAt
+		credential.ExpiresAt = &expiresAt
+	}
+	if len(fields) > 0 {
+		credential.CustomFields = fields
+	}
+	for _, tag := range record.Tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return Credential{}, err
+		}
+		credential.AddTag(normalized)
+	}
+	for _, rule := range record.URLs {
+		if err := rule.validate(); err != nil {
+			return Credential{}, err
+		}
+		credential.AddURL(rule)
+	}
+
+	if err := schema.validateFields(&credential); err != nil {
+		return Credential{}, err
+	}
+	credential.Password = record.Password
+	err = v.validateReferences(&credential)
+	credential.Password = nil
+	if err != nil {
+		return Credential{}, err
+	}
+
+	// Store a copy of the password; the caller's slice is cleared on return
+	if len(record.Password) > 0 {
+		credential.Password = make([]byte, len(record.Password))
+		copy(credential.Password, record.Password)
+	} else {
+		credential.Password = []byte{}
+	}
+
+	now := time.Now()
+	credential.CreatedAt = now
+	credential.UpdatedAt = now
+	return credential, nil
+}
+
+// containsFieldBefore reports whether fields[i]'s name already appears earlier in fields
+func containsFieldBefore(fields []CustomField, i int) bool {
+	for _, earlier := range fields[:i] {
+		if strings.EqualFold(earlier.Name, fields[i].Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeRecordType(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", RecordTypeLogin, false},
+		{"login", RecordTypeLogin, false},
+		{" Card ", RecordTypeCard, false},
+		{"secure-note", RecordTypeNote, false},
+		{"ssh", RecordTypeSSHKey, false},
+		{"token", RecordTypeAPIToken, false},
+		{"db", RecordTypeDatabase, false},
+		{"passport", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeRecordType(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeRecordType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRecordType(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLegacyCredentialIsLogin(t *testing.T) {
+	var cred Credential
+	if err := json.Unmarshal([]byte(`{"service":"github","username":"alice","password":"c2VjcmV0"}`), &cred); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if cred.Type != "" || cred.RecordType() != RecordTypeLogin {
+		t.Errorf("Type = %q, RecordType() = %q, want empty/login", cred.Type, cred.RecordType())
+	}
+
+	// Logins keep the field out of the saved JSON so old and new vaults look the same
+	cred.Type = ""
+	data, _ := json.Marshal(cred)
+	var raw map[string]interface{}
+	_ = json.Unmarshal(data, &raw)
+	if _, found := raw["type"]; found {
+		t.Error("empty type was serialized")
+	}
+}
+
+func TestAddRecord(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+
+	// Cards have no password; schema fields take the schema's hidden setting
+	card := Credential{
+		Service: "visa",
+		Type:    "card",
+		CustomFields: []CustomField{
+			{Name: "Number", Value: "4111111111111111"},
+			{Name: "cardholder", Value: "Jane Doe", Hidden: true},
+		},
+		Tags: []string{"Personal"},
+	}
+	if err := vault.AddRecord(card); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+
+	cred, err := vault.GetCredential("visa", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if cred.Type != RecordTypeCard || len(cred.Password) != 0 {
+		t.Errorf("type = %q, password = %q, want card with no password", cred.Type, cred.Password)
+	}
+	number, _ := cred.GetCustomField("number")
+	holder, _ := cred.GetCustomField("cardholder")
+	if number.Name != "number" || !number.Hidden || holder.Hidden {
+		t.Errorf("schema flags not applied: number=%+v cardholder=%+v", number, holder)
+	}
+	if len(cred.Tags) != 1 || cred.Tags[0] != "personal" {
+		t.Errorf("tags = %v, want [personal]", cred.Tags)
+	}
+	if cred.ModifiedCount != 0 || len(cred.Revisions) != 0 {
+		t.Error("AddRecord() should not record an update")
+	}
+
+	// Required schema fields and passwords are enforced
+	invalid := map[string]Credential{
+		"missing required field": {Service: "mc", Type: RecordTypeCard},
+		"missing token":          {Service: "api", Type: RecordTypeAPIToken},
+		"unknown type":           {Service: "x", Type: "passport", Password: []byte("p")},
+		"duplicate field": {Service: "db", Type: RecordTypeDatabase, Password: []byte("p"), CustomFields: []CustomField{
+			{Name: "host", Value: "a"}, {Name: "HOST", Value: "b"},
+		}},
+	}
+	for name, record := range invalid {
+		if err := vault.AddRecord(record); !errors.Is(err, ErrInvalidCredential) {
+			t.Errorf("%s: AddRecord() error = %v, want ErrInvalidCredential", name, err)
+		}
+	}
+
+	if err := vault.AddRecord(Credential{Service: "visa", Type: RecordTypeNote}); !errors.Is(err, ErrCredentialExists) {
+		t.Errorf("duplicate service error = %v, want ErrCredentialExists", err)
+	}
+
+	metadata, _ := vault.ListCredentialsWithMetadata()
+	if len(metadata) != 1 || metadata[0].Type != RecordTypeCard {
+		t.Errorf("metadata = %+v, want one card", metadata)
+	}
+}
+
+func TestUpdateRecordKeepsSchema(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddRecord(Credential{
+		Service:      "deploy-key",
+		Type:         RecordTypeSSHKey,
+		CustomFields: []CustomField{{Name: "private-key", Value: "-----BEGIN KEY-----"}},
+	}); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+
+	if err := vault.UpdateCredential("deploy-key", UpdateOpts{RemoveCustomFields: []string{"private-key"}}); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("removing required field error = %v, want ErrInvalidCredential", err)
+	}
+	if err := vault.UpdateCredential("deploy-key", UpdateOpts{SetCustomFields: []CustomField{{Name: "private-key", Value: " "}}}); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("emptying required field error = %v, want ErrInvalidCredential", err)
+	}
+
+	// Replacing the key keeps it hidden even if the caller did not ask for that
+	if err := vault.UpdateCredential("deploy-key", UpdateOpts{SetCustomFields: []CustomField{{Name: "private-key", Value: "rotated"}}}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	cred, _ := vault.GetCredential("deploy-key", false)
+	if field, _ := cred.GetCustomField("private-key"); field.Value != "rotated" || !field.Hidden {
+		t.Errorf("private-key = %+v, want hidden rotated value", field)
+	}
+
+	// Notes have no password to set
+	if err := vault.AddRecord(Credential{Service: "wifi", Type: RecordTypeNote, Notes: "ssid"}); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+	secret := []byte("secret")
+	if err := vault.UpdateCredential("wifi", UpdateOpts{Password: &secret}); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("setting note password error = %v, want ErrInvalidCredential", err)
+	}
+}
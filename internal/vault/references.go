@@ -0,0 +1,281 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arimxyer/pass-cli/internal/crypto"
+)
+
+// References let a credential reuse another credential's values: a field containing
+// {ref:service.field} reads that field when the credential is read. Values are stored
+// unresolved, so a reference keeps following its target after updates. References may
+// be embedded in longer values ("{ref:corp.url}/admin") and may point at username,
+// password, url, notes or a custom field. The service name is everything before the
+// last dot, so the field name itself cannot contain one. Only the password and hidden
+// custom fields may reference a password or hidden field, so secrets never surface in
+// the visible fields that listings show.
+
+// referencePrefix opens every reference
+const referencePrefix = "{ref:"
+
+var (
+	// ErrInvalidReference is returned when a reference names a missing credential or field
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrReferenceCycle is returned when references end up pointing back at themselves
+	ErrReferenceCycle = errors.New("reference cycle")
+)
+
+var referencePattern = regexp.MustCompile(`\{ref:([^{}]+)\}`)
+
+// Reference points at one field of another credential
+type Reference struct {
+	Service string
+	Field   string
+}
+
+// String renders the reference in its stored form
+func (r Reference) String() string {
+	return referencePrefix + r.Service + "." + r.Field + "}"
+}
+
+// parseReference splits the inside of a reference ("service.field")
+func parseReference(target string) (Reference, bool) {
+	i := strings.LastIndex(target, ".")
+	if i <= 0 || i == len(target)-1 {
+		return Reference{}, false
+	}
+	return Reference{Service: target[:i], Field: target[i+1:]}, true
+}
+
+// ParseReferences returns the well-formed references in a value, in order
+func ParseReferences(value string) []Reference {
+	if !strings.Contains(value, referencePrefix) {
+		return nil
+	}
+	var refs []Reference
+	for _, match := range referencePattern.FindAllStringSubmatch(value, -1) {
+		if ref, ok := parseReference(match[1]); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// rawFieldValue returns the stored (unresolved) value of a referenceable field
+func rawFieldValue(c *Credential, field string) (string, bool) {
+	switch strings.ToLower(field) {
+	case "username", "user":
+		return c.Username, true
+	case "password", "pass":
+		return string(c.Password), true
+	case "url":
+		return c.URL, true
+	case "notes", "note":
+		return c.Notes, true
+	}
+	if custom, ok := c.GetCustomField(field); ok {
+		return custom.Value, true
+	}
+	return "", false
+}
+
+// secretField reports whether a field holds a secret: the password or a hidden custom field
+func secretField(c *Credential, field string) bool {
+	switch strings.ToLower(field) {
+	case "password", "pass":
+		return true
+	case "username", "user", "url", "notes", "note":
+		return false
+	}
+	custom, ok := c.GetCustomField(field)
+	return ok && custom.Hidden
+}
+
+// referenceResolver expands references, tracking the fields being expanded to detect
+// cycles and remembering resolved fields so shared targets are expanded once
+type referenceResolver struct {
+	credentials map[string]Credential
+	owner       *Credential // Used instead of the stored credential with the same name
+	visiting    map[string]bool
+	resolved    map[string]string
+}
+
+// resolve replaces every reference in value, returning the first error encountered.
+// secret tells whether value belongs to a secret field, which alone may reference one.
+func (r *referenceResolver) resolve(value string, secret bool) (string, error) {
+	if !strings.Contains(value, referencePrefix) {
+		return value, nil
+	}
+
+	var firstErr error
+	resolved := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		ref, ok := parseReference(match[len(referencePrefix) : len(match)-1])
+		if !ok {
+			firstErr = fmt.Errorf("%w: %s (expected {ref:service.field})", ErrInvalidReference, match)
+			return match
+		}
+		target, err := r.field(ref, secret)
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		return target
+	})
+	return resolved, firstErr
+}
+
+// field resolves the value of one referenced field
+func (r *referenceResolver) field(ref Reference, secret bool) (string, error) {
+	key := ref.Service + "." + strings.ToLower(ref.Field)
+	if r.visiting[key] {
+		return "", fmt.Errorf("%w: %s", ErrReferenceCycle, ref)
+	}
+
+	credential, exists := r.credentials[ref.Service]
+	if r.owner != nil && ref.Service == r.owner.Service {
+		credential, exists = *r.owner, true
+	}
+	if !exists {
+		return "", fmt.Errorf("%w: %s (credential %q not found)", ErrInvalidReference, ref, ref.Service)
+	}
+	raw, ok := rawFieldValue(&credential, ref.Field)
+	if !ok {
+		return "", fmt.Errorf("%w: %s (%s has no field %q)", ErrInvalidReference, ref, ref.Service, ref.Field)
+	}
+	targetSecret := secretField(&credential, ref.Field)
+	if targetSecret && !secret {
+		return "", fmt.Errorf("%w: %s (only the password or a hidden field can reference a secret)", ErrInvalidReference, ref)
+	}
+	if value, ok := r.resolved[key]; ok {
+		return value, nil
+	}
+
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+	value, err := r.resolve(raw, targetSecret)
+	if err != nil {
+		return "", err
+	}
+	r.resolved[key] = value
+	return value, nil
+}
+
+// resolveValue expands the references in one field of owner. owner takes the place of
+// the stored credential with the same name, so unsaved changes can be validated.
+func (v *VaultService) resolveValue(owner *Credential, field, value string) (string, error) {
+	if !strings.Contains(value, referencePrefix) {
+		return value, nil
+	}
+	r := &referenceResolver{
+		credentials: v.vaultData.Credentials,
+		owner:       owner,
+		visiting:    map[string]bool{owner.Service + "." + strings.ToLower(field): true},
+		resolved:    make(map[string]string),
+	}
+	return r.resolve(value, secretField(owner, field))
+}
+
+// resolvedValue is resolveValue for display: unresolvable values are returned as stored
+func (v *VaultService) resolvedValue(owner *Credential, field, value string) string {
+	if resolved, err := v.resolveValue(owner, field, value); err == nil {
+		return resolved
+	}
+	return value
+}
+
+// resolveFields writes the resolved values of owner's fields into dst, a copy of owner.
+// With strict set the first error is returned; otherwise fields whose references
+// cannot be resolved keep their stored value.
+func (v *VaultService) resolveFields(dst, owner *Credential, strict bool) error {
+	resolveString := func(field, value string, target *string) error {
+		resolved, err := v.resolveValue(owner, field, value)
+		if err != nil {
+			if strict {
+				return err
+			}
+			return nil
+		}
+		*target = resolved
+		return nil
+	}
+
+	if err := resolveString("username", owner.Username, &dst.Username); err != nil {
+		return err
+	}
+	if err := resolveString("url", owner.URL, &dst.URL); err != nil {
+		return err
+	}
+	if err := resolveString("notes", owner.Notes, &dst.Notes); err != nil {
+		return err
+	}
+	if bytes.Contains(owner.Password, []byte(referencePrefix)) {
+		password := string(owner.Password)
+		if err := resolveString("password", password, &password); err != nil {
+			return err
+		}
+		dst.Password = []byte(password)
+	}
+	for i, field := range owner.CustomFields {
+		if err := resolveString(field.Name, field.Value, &dst.CustomFields[i].Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateReferences checks that every reference in a credential about to be saved
+// resolves, rejecting missing targets and cycles
+func (v *VaultService) validateReferences(credential *Credential) error {
+	scratch := *credential
+	scratch.Password = nil // Only replaced (not written through) by resolveFields
+	scratch.CustomFields = append([]CustomField(nil), credential.CustomFields...)
+	err := v.resolveFields(&scratch, credential, true)
+	crypto.ClearBytes(scratch.Password)
+	return err
+}
+
+// references returns every reference stored in the credential's fields
+func (c *Credential) references() []Reference {
+	var refs []Reference
+	for _, value := range []string{c.Username, c.URL, c.Notes} {
+		refs = append(refs, ParseReferences(value)...)
+	}
+	if bytes.Contains(c.Password, []byte(referencePrefix)) {
+		refs = append(refs, ParseReferences(string(c.Password))...)
+	}
+	for _, field := range c.CustomFields {
+		refs = append(refs, ParseReferences(field.Value)...)
+	}
+	return refs
+}
+
+// Referrers returns the other credentials whose fields reference service, sorted.
+// Used to warn before a credential is deleted or renamed.
+func (v *VaultService) Referrers(service string) []string {
+	if !v.unlocked {
+		return nil
+	}
+
+	var referrers []string
+	for name, credential := range v.vaultData.Credentials {
+		if name == service {
+			continue
+		}
+		for _, ref := range credential.references() {
+			if ref.Service == service {
+				referrers = append(referrers, name)
+				break
+			}
+		}
+	}
+	sort.Strings(referrers)
+	return referrers
+}
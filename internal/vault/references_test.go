@@ -0,0 +1,211 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	got := ParseReferences("{ref:corp.sso.url}/admin?user={ref:corp.username} {ref:nodot} {ref:}")
+	want := []Reference{{Service: "corp.sso", Field: "url"}, {Service: "corp", Field: "username"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseReferences() = %+v, want %+v", got, want)
+	}
+	if refs := ParseReferences("plain value"); refs != nil {
+		t.Errorf("ParseReferences(plain) = %+v, want none", refs)
+	}
+	if s := want[0].String(); s != "{ref:corp.sso.url}" {
+		t.Errorf("Reference.String() = %q", s)
+	}
+}
+
+func TestCredentialReferences(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	records := []Credential{
+		{Service: "corp", Username: "jdoe", URL: "https://corp.example.com",
+			CustomFields: []CustomField{{Name: "tenant", Value: "acme"}}},
+		{Service: "corp-admin", Username: "{ref:corp.username}", URL: "{ref:corp.url}/admin",
+			Password: []byte("{ref:corp.password}"), Notes: "tenant {ref:corp.tenant}"},
+		{Service: "corp-api", Username: "{ref:corp-admin.username}",
+			CustomFields: []CustomField{{Name: "login", Value: "{ref:corp-api.username}@{ref:corp.tenant}"}}},
+	}
+	for _, record := range records {
+		if record.Password == nil {
+			record.Password = []byte("corp-secret")
+		}
+		if err := vault.AddRecord(record); err != nil {
+			t.Fatalf("AddRecord(%s) failed: %v", record.Service, err)
+		}
+	}
+
+	cred, err := vault.GetCredential("corp-admin", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if cred.Username != "jdoe" || cred.URL != "https://corp.example.com/admin" ||
+		string(cred.Password) != "corp-secret" || cred.Notes != "tenant acme" {
+		t.Errorf("resolved credential = %q %q %q %q", cred.Username, cred.URL, cred.Password, cred.Notes)
+	}
+
+	// Chains and references to the credential's own fields resolve too
+	api, _ := vault.GetCredential("corp-api", false)
+	if api.Username != "jdoe" || api.CustomFields[0].Value != "jdoe@acme" {
+		t.Errorf("corp-api = %q, login %q", api.Username, api.CustomFields[0].Value)
+	}
+
+	raw, _ := vault.GetCredentialRaw("corp-admin")
+	if raw.Username != "{ref:corp.username}" || string(raw.Password) != "{ref:corp.password}" {
+		t.Errorf("raw credential = %q %q, want references", raw.Username, raw.Password)
+	}
+
+	// References follow their target after updates
+	newUser := "john.doe"
+	if err := vault.UpdateCredential("corp", UpdateOpts{Username: &newUser}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("corp-admin", false)
+	if cred.Username != "john.doe" {
+		t.Errorf("Username = %q after target update, want john.doe", cred.Username)
+	}
+
+	metadata, _ := vault.ListCredentialsWithMetadata()
+	for _, meta := range metadata {
+		if meta.Service == "corp-admin" && meta.URL != "https://corp.example.com/admin" {
+			t.Errorf("metadata URL = %q, want resolved", meta.URL)
+		}
+	}
+
+	if got := vault.Referrers("corp"); !reflect.DeepEqual(got, []string{"corp-admin", "corp-api"}) {
+		t.Errorf("Referrers(corp) = %v", got)
+	}
+	if got := vault.Referrers("corp-api"); got != nil {
+		t.Errorf("self references should not count: Referrers(corp-api) = %v", got)
+	}
+}
+
+func TestCredentialReferenceErrors(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	missing := []Credential{
+		{Service: "a", Username: "{ref:nowhere.username}"},
+		{Service: "b", Username: "{ref:github.pin}"},
+	}
+	for _, record := range missing {
+		record.Password = []byte("pass")
+		if err := vault.AddRecord(record); !errors.Is(err, ErrInvalidReference) {
+			t.Errorf("AddRecord(%s): got %v, want ErrInvalidReference", record.Service, err)
+		}
+	}
+
+	self := Credential{Service: "loop", Username: "{ref:loop.notes}", Notes: "{ref:loop.username}", Password: []byte("pass")}
+	if err := vault.AddRecord(self); !errors.Is(err, ErrReferenceCycle) {
+		t.Errorf("self cycle: got %v, want ErrReferenceCycle", err)
+	}
+
+	// A cycle through another credential is caught when the second half is written
+	if err := vault.AddRecord(Credential{Service: "x", Username: "{ref:github.username}", Password: []byte("pass")}); err != nil {
+		t.Fatalf("AddRecord(x) failed: %v", err)
+	}
+	ref := "{ref:x.username}"
+	if err := vault.UpdateCredential("github", UpdateOpts{Username: &ref}); !errors.Is(err, ErrReferenceCycle) {
+		t.Errorf("indirect cycle: got %v, want ErrReferenceCycle", err)
+	}
+	cred, _ := vault.GetCredential("github", false)
+	if cred.Username != "user" {
+		t.Errorf("rejected update should not be stored: username %q", cred.Username)
+	}
+
+	// Deleting a target leaves the reference unresolved rather than failing reads
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	cred, err := vault.GetCredential("x", false)
+	if err != nil {
+		t.Fatalf("GetCredential() with a dangling reference failed: %v", err)
+	}
+	if cred.Username != "{ref:github.username}" {
+		t.Errorf("dangling reference = %q, want it kept as stored", cred.Username)
+	}
+}
+
+func TestCredentialReferenceSecrets(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	db := Credential{Service: "db", Username: "admin", Password: []byte("db-secret"),
+		CustomFields: []CustomField{{Name: "pin", Value: "1234", Hidden: true}}}
+	if err := vault.AddRecord(db); err != nil {
+		t.Fatalf("AddRecord(db) failed: %v", err)
+	}
+
+	// Secret fields can reference secrets
+	shared := Credential{Service: "db-copy", Username: "{ref:db.username}", Password: []byte("{ref:db.password}"),
+		CustomFields: []CustomField{{Name: "pin", Value: "{ref:db.pin}", Hidden: true}}}
+	if err := vault.AddRecord(shared); err != nil {
+		t.Fatalf("AddRecord(db-copy) failed: %v", err)
+	}
+
+	// Visible fields, which listings show, cannot pull in a secret
+	leaks := []Credential{
+		{Service: "notes", Notes: "{ref:db.password}"},
+		{Service: "username", Username: "{ref:db.pin}"},
+		{Service: "custom", CustomFields: []CustomField{{Name: "copy", Value: "{ref:db.password}"}}},
+		// Nor through a secret field on the way
+		{Service: "chained", Username: "{ref:db-copy.password}"},
+	}
+	for _, record := range leaks {
+		record.Password = []byte("pass")
+		if err := vault.AddRecord(record); !errors.Is(err, ErrInvalidReference) {
+			t.Errorf("AddRecord(%s): got %v, want ErrInvalidReference", record.Service, err)
+		}
+	}
+
+	cred, _ := vault.GetCredential("db-copy", false)
+	if string(cred.Password) != "db-secret" || cred.CustomFields[0].Value != "1234" {
+		t.Errorf("db-copy = %q, pin %q", cred.Password, cred.CustomFields[0].Value)
+	}
+
+	// A leaking reference stored before the check existed stays unresolved in listings
+	stored := vault.vaultData.Credentials["github"]
+	stored.Notes = "{ref:db.password}"
+	vault.vaultData.Credentials["github"] = stored
+	metadata, _ := vault.ListCredentialsWithMetadata()
+	for _, meta := range metadata {
+		if meta.Service == "github" && meta.Notes != "{ref:db.password}" {
+			t.Errorf("metadata notes = %q, want the reference unresolved", meta.Notes)
+		}
+	}
+}
+
+func TestCredentialReferenceFanOut(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	// Both fields of every level reference both fields of the next one, so expanding
+	// each reference separately would take 2^levels steps
+	const levels = 25
+	for i := levels; i >= 0; i-- {
+		record := Credential{Service: fmt.Sprintf("level%d", i), Password: []byte("pass")}
+		if i < levels {
+			next := fmt.Sprintf("level%d", i+1)
+			value := "{ref:" + next + ".username}{ref:" + next + ".url}"
+			record.Username, record.URL = value, value
+		}
+		if err := vault.AddRecord(record); err != nil {
+			t.Fatalf("AddRecord(%s) failed: %v", record.Service, err)
+		}
+	}
+
+	cred, err := vault.GetCredential("level0", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if cred.Username != "" || cred.URL != "" {
+		t.Errorf("level0 = %q %q, want empty", cred.Username, cred.URL)
+	}
+}
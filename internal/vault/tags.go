@@ -0,0 +1,69 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrTagNotFound is returned when removing a tag a credential does not have
+var ErrTagNotFound = errors.New("tag not found")
+
+// NormalizeTag trims and lowercases a tag and checks that it is usable.
+// Tags are single words so they can be typed as '#tag' in TUI search and
+// passed comma-separated on the command line.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("%w: tag cannot be empty", ErrInvalidCredential)
+	}
+	if strings.ContainsRune(tag, ',') || strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("%w: tag %q cannot contain commas or whitespace", ErrInvalidCredential, tag)
+	}
+	return tag, nil
+}
+
+// HasTag reports whether the credential carries the given tag (case-insensitive)
+func (c *Credential) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, existing := range c.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds a normalized tag, keeping the list sorted and free of duplicates
+func (c *Credential) AddTag(tag string) {
+	if c.HasTag(tag) {
+		return
+	}
+	c.Tags = append(c.Tags, tag)
+	sort.Strings(c.Tags)
+}
+
+// RemoveTag deletes a tag, reporting whether it existed
+func (c *Credential) RemoveTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for i, existing := range c.Tags {
+		if existing == tag {
+			c.Tags = append(c.Tags[:i], c.Tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the listed credential carries the given tag (case-insensitive)
+func (m *CredentialMetadata) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, existing := range m.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
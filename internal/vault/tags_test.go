@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{"work", "work", false},
+		{"  Prod ", "prod", false},
+		{"team-a", "team-a", false},
+		{"", "", true},
+		{"   ", "", true},
+		{"two words", "", true},
+		{"a,b", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := NormalizeTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeTag(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeTag(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCredentialTags(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("stripe", "billing", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	// Tags are normalized, sorted and deduplicated
+	if err := vault.UpdateCredential("stripe", UpdateOpts{AddTags: []string{"Work", "prod", "work"}}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+
+	cred, err := vault.GetCredential("stripe", false)
+	if err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if len(cred.Tags) != 2 || cred.Tags[0] != "prod" || cred.Tags[1] != "work" {
+		t.Fatalf("Tags = %v, want [prod work]", cred.Tags)
+	}
+
+	metadata, err := vault.ListCredentialsWithMetadata()
+	if err != nil {
+		t.Fatalf("ListCredentialsWithMetadata() failed: %v", err)
+	}
+	if !metadata[0].HasTag("PROD") {
+		t.Errorf("metadata tags = %v, want prod", metadata[0].Tags)
+	}
+
+	// Removal is applied before additions
+	if err := vault.UpdateCredential("stripe", UpdateOpts{RemoveTags: []string{"prod"}, AddTags: []string{"staging"}}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("stripe", false)
+	if len(cred.Tags) != 2 || cred.HasTag("prod") || !cred.HasTag("staging") {
+		t.Errorf("Tags = %v, want [staging work]", cred.Tags)
+	}
+
+	if err := vault.UpdateCredential("stripe", UpdateOpts{RemoveTags: []string{"missing"}}); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("UpdateCredential() error = %v, want ErrTagNotFound", err)
+	}
+	if err := vault.UpdateCredential("stripe", UpdateOpts{AddTags: []string{"bad tag"}}); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("UpdateCredential() error = %v, want ErrInvalidCredential", err)
+	}
+
+	// Tag changes are recorded in history and can be restored
+	history, err := vault.GetHistory("stripe")
+	if err != nil {
+		t.Fatalf("GetHistory() failed: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Tags == nil || len(*last.Tags) != 2 || (*last.Tags)[0] != "prod" {
+		t.Fatalf("last revision tags = %v, want [prod work]", last.Tags)
+	}
+	if err := vault.RestoreRevision("stripe", last.Number); err != nil {
+		t.Fatalf("RestoreRevision() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("stripe", false)
+	if len(cred.Tags) != 2 || !cred.HasTag("prod") || !cred.HasTag("work") {
+		t.Errorf("Tags after restore = %v, want [prod work]", cred.Tags)
+	}
+}
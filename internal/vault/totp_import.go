@@ -0,0 +1,198 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// TOTPImportResult summarizes an import of authenticator accounts
+type TOTPImportResult struct {
+	DryRun    bool
+	Updated   []string // Existing credentials that received the TOTP secret
+	Created   []string // New notes holding the TOTP secret
+	Unchanged []string // Existing credentials that already had the same configuration
+	Failed    []ImportFailure
+}
+
+// ImportTOTP adds authenticator accounts to the vault with a single save. Each
+// record carries the TOTP fields, Service (the issuer, or the account name
+// without one) and Username (the account name).
+//
+// A record updates the TOTP configuration of the existing credential with the
+// same service or TOTP issuer, as long as the match is unambiguous: when several
+// credentials match, the one with the same username is used. Records without a
+// match become notes named after the issuer ("GitHub", or "GitHub (alice)" if the
+// name is taken).
+func (v *VaultService) ImportTOTP(records []Credential, dryRun bool) (*TOTPImportResult, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	result := &TOTPImportResult{DryRun: dryRun}
+	pending := make(map[string]Credential)
+	totpAdded := make(map[string]bool) // Updated credentials that had no TOTP before
+
+	for _, record := range records {
+		if err := ValidateTOTPSecret(record.TOTPSecret); err != nil {
+			result.Failed = append(result.Failed, ImportFailure{Service: record.Service, Err: err})
+			continue
+		}
+
+		if service := v.totpImportTarget(record, pending); service != "" {
+			existing := v.vaultData.Credentials[service]
+			if sameTOTP(&existing, &record) {
+				result.Unchanged = append(result.Unchanged, service)
+				continue
+			}
+			totpAdded[service] = existing.TOTPSecret == ""
+			existing.TOTPSecret = record.TOTPSecret
+			existing.TOTPAlgorithm = record.TOTPAlgorithm
+			existing.TOTPDigits = record.TOTPDigits
+			existing.TOTPPeriod = record.TOTPPeriod
+			existing.TOTPIssuer = record.TOTPIssuer
+			existing.ModifiedCount++
+			existing.UpdatedAt = time.Now()
+			pending[service] = existing
+			result.Updated = append(result.Updated, service)
+			continue
+		}
+
+		record.Type = RecordTypeNote
+		record.Service = v.totpImportName(record, pending)
+		credential, err := v.newRecord(record)
+		if err != nil {
+			result.Failed = append(result.Failed, ImportFailure{Service: record.Service, Err: err})
+			continue
+		}
+		pending[credential.Service] = credential
+		result.Created = append(result.Created, credential.Service)
+	}
+
+	if dryRun || len(pending) == 0 {
+		return result, nil
+	}
+
+	previous := make(map[string]Credential, len(result.Updated))
+	for service, credential := range pending {
+		if existing, exists := v.vaultData.Credentials[service]; exists {
+			previous[service] = existing
+		}
+		v.vaultData.Credentials[service] = credential
+	}
+
+	if err := v.save(); err != nil {
+		// Put the vault back the way it was so memory matches the file
+		for service := range pending {
+			if existing, exists := previous[service]; exists {
+				v.vaultData.Credentials[service] = existing
+			} else {
+				delete(v.vaultData.Credentials, service)
+			}
+		}
+		return nil, err
+	}
+
+	for _, service := range result.Updated {
+		v.LogAudit(security.EventCredentialUpdate, security.OutcomeSuccess, service)
+		if totpAdded[service] {
+			v.LogAudit(security.EventTOTPAdd, security.OutcomeSuccess, service)
+		} else {
+			v.LogAudit(security.EventTOTPUpdate, security.OutcomeSuccess, service)
+		}
+	}
+	for _, service := range result.Created {
+		v.LogAudit(security.EventCredentialAdd, security.OutcomeSuccess, service)
+		v.LogAudit(security.EventTOTPAdd, security.OutcomeSuccess, service)
+	}
+	return result, nil
+}
+
+// totpImportTarget returns the existing credential an authenticator account
+// belongs to, or "" if there is no unambiguous match. Credentials already
+// changed by this import (pending) are not considered again.
+func (v *VaultService) totpImportTarget(record Credential, pending map[string]Credential) string {
+	var candidates []Credential
+	for service, credential := range v.vaultData.Credentials {
+		if _, taken := pending[service]; taken {
+			continue
+		}
+		if strings.EqualFold(service, record.Service) ||
+			(record.TOTPIssuer != "" && (strings.EqualFold(service, record.TOTPIssuer) || strings.EqualFold(credential.TOTPIssuer, record.TOTPIssuer))) {
+			candidates = append(candidates, credential)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Service < candidates[j].Service })
+
+	var sameUser []Credential
+	for _, credential := range candidates {
+		if record.Username != "" && strings.EqualFold(credential.Username, record.Username) {
+			sameUser = append(sameUser, credential)
+		}
+	}
+	switch {
+	case len(sameUser) == 1:
+		return sameUser[0].Service
+	case len(sameUser) == 0 && len(candidates) == 1 && (record.Username == "" || candidates[0].Username == ""):
+		return candidates[0].Service
+	}
+	return ""
+}
+
+// totpImportName returns a free name for a new authenticator note: the issuer,
+// then "issuer (account)", then "issuer (account) (n)", ignoring case
+func (v *VaultService) totpImportName(record Credential, pending map[string]Credential) string {
+	taken := make(map[string]bool, len(pending))
+	for service := range pending {
+		taken[service] = true
+	}
+	// Names differing only in case would be confusing next to each other
+	free := func(name string) bool {
+		for service := range v.vaultData.Credentials {
+			if strings.EqualFold(service, name) {
+				return false
+			}
+		}
+		for service := range taken {
+			if strings.EqualFold(service, name) {
+				return false
+			}
+		}
+		return true
+	}
+
+	name := record.Service
+	if free(name) {
+		return name
+	}
+	if record.Username != "" && !strings.EqualFold(record.Username, record.Service) {
+		name = fmt.Sprintf("%s (%s)", record.Service, record.Username)
+		if free(name) {
+			return name
+		}
+	}
+	return v.importName(name, taken)
+}
+
+// sameTOTP reports whether a credential already has the record's TOTP
+// configuration, treating unset values as the defaults
+func sameTOTP(credential, record *Credential) bool {
+	withDefaults := func(c *Credential) TOTPConfig {
+		config := DefaultTOTPConfig()
+		config.Secret = strings.ToUpper(strings.TrimRight(c.TOTPSecret, "="))
+		if c.TOTPAlgorithm != "" {
+			config.Algorithm = strings.ToUpper(c.TOTPAlgorithm)
+		}
+		if c.TOTPDigits != 0 {
+			config.Digits = c.TOTPDigits
+		}
+		if c.TOTPPeriod != 0 {
+			config.Period = c.TOTPPeriod
+		}
+		return config
+	}
+	return withDefaults(credential) == withDefaults(record)
+}
@@ -0,0 +1,80 @@
+package vault
+
+import "testing"
+
+// totpRecord returns an authenticator account as produced by the importer package
+func totpRecord(issuer, account, secret string) Credential {
+	service := issuer
+	if service == "" {
+		service = account
+	}
+	return Credential{
+		Service: service, Type: RecordTypeNote, Username: account, TOTPIssuer: issuer,
+		TOTPSecret: secret, TOTPAlgorithm: "SHA1", TOTPDigits: 6, TOTPPeriod: 30,
+	}
+}
+
+func TestImportTOTP(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	// "github" (user) exists; add a login whose TOTP is already set up
+	setup := totpRecord("GitLab", "me", "JBSWY3DPEHPK3PXP")
+	if err := vault.AddRecord(Credential{Service: "gitlab", Username: "me", Password: []byte("pw"),
+		TOTPSecret: setup.TOTPSecret, TOTPAlgorithm: "SHA1", TOTPDigits: 6, TOTPPeriod: 30, TOTPIssuer: "GitLab"}); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+
+	records := []Credential{
+		totpRecord("GitHub", "user", "JBSWY3DPEHPK3PXP"),  // Matches "github" by name and username
+		totpRecord("GitHub", "other", "GEZDGNBVGY3TQOJQ"), // Second GitHub account: new note
+		totpRecord("GitLab", "me", "JBSWY3DPEHPK3PXP"),    // Already configured
+		totpRecord("", "carol@example.com", "GEZDGNBVGY3TQOJQ"),
+		totpRecord("Broken", "x", "not base32!"),
+	}
+
+	dry, err := vault.ImportTOTP(records, true)
+	if err != nil {
+		t.Fatalf("ImportTOTP(dry run) failed: %v", err)
+	}
+	if github, _ := vault.GetCredential("github", false); github.TOTPSecret != "" {
+		t.Error("dry run changed the vault")
+	}
+
+	result, err := vault.ImportTOTP(records, false)
+	if err != nil {
+		t.Fatalf("ImportTOTP() failed: %v", err)
+	}
+	for _, r := range []*TOTPImportResult{dry, result} {
+		if len(r.Updated) != 1 || r.Updated[0] != "github" {
+			t.Errorf("Updated = %v, want [github]", r.Updated)
+		}
+		if len(r.Created) != 2 || r.Created[0] != "GitHub (other)" || r.Created[1] != "carol@example.com" {
+			t.Errorf("Created = %v", r.Created)
+		}
+		if len(r.Unchanged) != 1 || r.Unchanged[0] != "gitlab" {
+			t.Errorf("Unchanged = %v, want [gitlab]", r.Unchanged)
+		}
+		if len(r.Failed) != 1 || r.Failed[0].Service != "Broken" {
+			t.Errorf("Failed = %+v", r.Failed)
+		}
+	}
+
+	github, _ := vault.GetCredential("github", false)
+	if github.TOTPSecret != "JBSWY3DPEHPK3PXP" || github.TOTPIssuer != "GitHub" || string(github.Password) != "pass" {
+		t.Errorf("github = %+v, want TOTP added and password kept", github)
+	}
+	if note, err := vault.GetCredential("GitHub (other)", false); err != nil || note.Type != RecordTypeNote || note.Username != "other" {
+		t.Errorf("created note = %+v, %v", note, err)
+	}
+
+	// Ambiguous matches (two GitLab credentials, neither with the account's username) create a note
+	if err := vault.AddRecord(Credential{Service: "gitlab-work", Username: "work", Password: []byte("pw"),
+		TOTPSecret: "GEZDGNBVGY3TQOJQ", TOTPIssuer: "GitLab"}); err != nil {
+		t.Fatalf("AddRecord() failed: %v", err)
+	}
+	result, err = vault.ImportTOTP([]Credential{totpRecord("GitLab", "someone", "JBSWY3DPEHPK3PXP")}, false)
+	if err != nil || len(result.Created) != 1 || result.Created[0] != "GitLab (someone)" {
+		t.Errorf("ambiguous import = %+v, %v", result, err)
+	}
+}
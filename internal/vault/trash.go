@@ -0,0 +1,214 @@
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/security"
+)
+
+// DefaultTrashRetentionDays is how long deleted credentials are kept when not configured
+const DefaultTrashRetentionDays = 30
+
+// ErrNotInTrash is returned when no deleted credential matches the requested service
+var ErrNotInTrash = errors.New("credential not found in trash")
+
+// TrashedCredential is a deleted credential waiting to be restored or purged.
+// The trash is part of VaultData, so deleted credentials stay encrypted.
+type TrashedCredential struct {
+	ID         string     `json:"id"`
+	DeletedAt  time.Time  `json:"deleted_at"`
+	Credential Credential `json:"credential"`
+}
+
+// TrashItem describes a deleted credential without exposing its secrets
+type TrashItem struct {
+	ID        string
+	Service   string
+	Username  string
+	Category  string
+	DeletedAt time.Time
+	PurgeAt   time.Time // When the credential is removed for good
+}
+
+// TrashRetentionDays returns how many days deleted credentials are kept (0 means the trash is disabled)
+func (v *VaultService) TrashRetentionDays() int {
+	return v.trashRetentionDays
+}
+
+// purgeAt returns when a credential deleted at deletedAt expires from the trash
+func (v *VaultService) purgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.AddDate(0, 0, v.trashRetentionDays)
+}
+
+// newTrashID returns a short random hex identifier, used to pick one of several
+// deleted credentials that share a service name
+func newTrashID() (string, error) {
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate trash id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// moveToTrash removes a credential from the vault and keeps it in the trash.
+// Entries past the retention period are dropped and returned so the caller can
+// remove their attachment blobs once the vault is saved.
+func (v *VaultService) moveToTrash(credential Credential, now time.Time) ([]TrashedCredential, error) {
+	id, err := newTrashID()
+	if err != nil {
+		return nil, err
+	}
+
+	delete(v.vaultData.Credentials, credential.Service)
+	v.vaultData.Trash = append(v.vaultData.Trash, TrashedCredential{
+		ID:         id,
+		DeletedAt:  now,
+		Credential: credential,
+	})
+	return v.pruneTrash(now), nil
+}
+
+// pruneTrash drops trashed credentials whose retention period has passed and returns them
+func (v *VaultService) pruneTrash(now time.Time) []TrashedCredential {
+	var kept, expired []TrashedCredential
+	for _, entry := range v.vaultData.Trash {
+		if now.Before(v.purgeAt(entry.DeletedAt)) {
+			kept = append(kept, entry)
+		} else {
+			expired = append(expired, entry)
+		}
+	}
+	v.vaultData.Trash = kept
+	return expired
+}
+
+// removeTrashedBlobs deletes the attachment blobs of purged credentials (best-effort)
+func (v *VaultService) removeTrashedBlobs(entries []TrashedCredential) {
+	for _, entry := range entries {
+		v.removeAttachmentBlobs(entry.Credential.Attachments)
+	}
+}
+
+// ListTrash returns deleted credentials that can still be restored, most recently deleted first
+func (v *VaultService) ListTrash() ([]TrashItem, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	now := time.Now()
+	items := make([]TrashItem, 0, len(v.vaultData.Trash))
+	for _, entry := range v.vaultData.Trash {
+		purgeAt := v.purgeAt(entry.DeletedAt)
+		if !now.Before(purgeAt) {
+			continue // Expired, dropped on the next delete or purge
+		}
+		items = append(items, TrashItem{
+			ID:        entry.ID,
+			Service:   entry.Credential.Service,
+			Username:  entry.Credential.Username,
+			Category:  entry.Credential.Category,
+			DeletedAt: entry.DeletedAt,
+			PurgeAt:   purgeAt,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// findTrashed returns the index of the most recently deleted credential with the
+// given service name (or trash ID) that has not expired, or -1
+func (v *VaultService) findTrashed(serviceOrID string, now time.Time) int {
+	found := -1
+	for i, entry := range v.vaultData.Trash {
+		if entry.ID != serviceOrID && entry.Credential.Service != serviceOrID {
+			continue
+		}
+		if !now.Before(v.purgeAt(entry.DeletedAt)) {
+			continue
+		}
+		if found < 0 || entry.DeletedAt.After(v.vaultData.Trash[found].DeletedAt) {
+			found = i
+		}
+	}
+	return found
+}
+
+// RestoreFromTrash moves a deleted credential back into the vault, selected by service
+// name (the most recent deletion wins) or trash ID. It returns the restored service name.
+// Fails with ErrCredentialExists if a credential with that name has been added since.
+func (v *VaultService) RestoreFromTrash(serviceOrID string) (string, error) {
+	if !v.unlocked {
+		return "", ErrVaultLocked
+	}
+
+	now := time.Now()
+	i := v.findTrashed(serviceOrID, now)
+	if i < 0 {
+		return "", fmt.Errorf("%w: %s", ErrNotInTrash, serviceOrID)
+	}
+
+	entry := v.vaultData.Trash[i]
+	service := entry.Credential.Service
+	if _, exists := v.vaultData.Credentials[service]; exists {
+		return "", fmt.Errorf("%w: %s (delete or update it before restoring)", ErrCredentialExists, service)
+	}
+
+	trash := make([]TrashedCredential, 0, len(v.vaultData.Trash)-1)
+	trash = append(trash, v.vaultData.Trash[:i]...)
+	trash = append(trash, v.vaultData.Trash[i+1:]...)
+	v.vaultData.Trash = trash
+	v.vaultData.Credentials[service] = entry.Credential
+	expired := v.pruneTrash(now)
+
+	if err := v.save(); err != nil {
+		return "", err
+	}
+	v.removeTrashedBlobs(expired)
+
+	v.LogAudit(security.EventTrashRestore, security.OutcomeSuccess, service)
+	return service, nil
+}
+
+// PurgeTrash permanently removes deleted credentials matching a service name or trash ID,
+// or the whole trash when serviceOrID is empty. Returns the number of credentials removed.
+func (v *VaultService) PurgeTrash(serviceOrID string) (int, error) {
+	if !v.unlocked {
+		return 0, ErrVaultLocked
+	}
+
+	var kept, purged []TrashedCredential
+	for _, entry := range v.vaultData.Trash {
+		if serviceOrID == "" || entry.ID == serviceOrID || entry.Credential.Service == serviceOrID {
+			purged = append(purged, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	if len(purged) == 0 {
+		if serviceOrID == "" {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("%w: %s", ErrNotInTrash, serviceOrID)
+	}
+
+	v.vaultData.Trash = kept
+	expired := v.pruneTrash(time.Now())
+
+	if err := v.save(); err != nil {
+		return 0, err
+	}
+	v.removeTrashedBlobs(append(purged, expired...))
+
+	for _, entry := range purged {
+		v.LogAudit(security.EventTrashPurge, security.OutcomeSuccess, entry.Credential.Service)
+	}
+	return len(purged), nil
+}
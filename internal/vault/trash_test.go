@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arimxyer/pass-cli/internal/storage"
+)
+
+func TestDeleteMovesToTrashAndRestore(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if _, err := vault.GetCredential("github", false); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("deleted credential should not be readable, got %v", err)
+	}
+
+	items, err := vault.ListTrash()
+	if err != nil {
+		t.Fatalf("ListTrash() failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Service != "github" || items[0].Username != "user" {
+		t.Fatalf("ListTrash() = %+v, want github", items)
+	}
+	if items[0].ID == "" {
+		t.Error("trash entry should have an ID")
+	}
+	if want := items[0].DeletedAt.AddDate(0, 0, DefaultTrashRetentionDays); !items[0].PurgeAt.Equal(want) {
+		t.Errorf("PurgeAt = %v, want %v", items[0].PurgeAt, want)
+	}
+
+	// Trash survives a lock/unlock round trip
+	vault.Lock()
+	if err := vault.Unlock([]byte("TestPassword123!")); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+
+	service, err := vault.RestoreFromTrash("github")
+	if err != nil {
+		t.Fatalf("RestoreFromTrash() failed: %v", err)
+	}
+	if service != "github" {
+		t.Errorf("RestoreFromTrash() = %q, want github", service)
+	}
+	cred, err := vault.GetCredential("github", false)
+	if err != nil {
+		t.Fatalf("restored credential not found: %v", err)
+	}
+	if !bytes.Equal(cred.Password, []byte("pass")) {
+		t.Error("restored credential lost its password")
+	}
+	if items, _ := vault.ListTrash(); len(items) != 0 {
+		t.Errorf("trash should be empty after restore, got %d", len(items))
+	}
+}
+
+func TestRestoreFromTrashPicksEntry(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	// Delete "github" twice with different usernames
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if err := vault.AddCredential("github", "second", []byte("pass2"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	// A live credential with the same name blocks restoring
+	if _, err := vault.RestoreFromTrash("github"); !errors.Is(err, ErrCredentialExists) {
+		t.Errorf("RestoreFromTrash() with a live credential: got %v, want ErrCredentialExists", err)
+	}
+
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+
+	items, _ := vault.ListTrash()
+	if len(items) != 2 || items[0].Username != "second" {
+		t.Fatalf("ListTrash() should list the most recent deletion first: %+v", items)
+	}
+
+	// The older deletion can be selected by ID
+	if _, err := vault.RestoreFromTrash(items[1].ID); err != nil {
+		t.Fatalf("RestoreFromTrash(id) failed: %v", err)
+	}
+	cred, _ := vault.GetCredential("github", false)
+	if cred.Username != "user" {
+		t.Errorf("restored username = %q, want user", cred.Username)
+	}
+
+	if _, err := vault.RestoreFromTrash("missing"); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("RestoreFromTrash(missing): got %v, want ErrNotInTrash", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	if err := vault.AddCredential("gitlab", "user", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+	for _, service := range []string{"github", "gitlab"} {
+		if err := vault.DeleteCredential(service); err != nil {
+			t.Fatalf("DeleteCredential(%s) failed: %v", service, err)
+		}
+	}
+
+	purged, err := vault.PurgeTrash("github")
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgeTrash(github) = %d, %v; want 1", purged, err)
+	}
+	if _, err := vault.RestoreFromTrash("github"); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("purged credential should not be restorable, got %v", err)
+	}
+	if _, err := vault.PurgeTrash("github"); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("PurgeTrash(github) again: got %v, want ErrNotInTrash", err)
+	}
+
+	purged, err = vault.PurgeTrash("")
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgeTrash(all) = %d, %v; want 1", purged, err)
+	}
+	if purged, err := vault.PurgeTrash(""); err != nil || purged != 0 {
+		t.Errorf("PurgeTrash(all) on empty trash = %d, %v; want 0", purged, err)
+	}
+}
+
+func TestTrashRetention(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+
+	// Age the entry past the retention period
+	vault.vaultData.Trash[0].DeletedAt = time.Now().AddDate(0, 0, -DefaultTrashRetentionDays-1)
+	if items, _ := vault.ListTrash(); len(items) != 0 {
+		t.Errorf("expired entries should not be listed, got %d", len(items))
+	}
+	if _, err := vault.RestoreFromTrash("github"); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("expired entry should not be restorable, got %v", err)
+	}
+
+	// The next delete drops expired entries
+	if err := vault.AddCredential("gitlab", "user", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+	if err := vault.DeleteCredential("gitlab"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if len(vault.vaultData.Trash) != 1 || vault.vaultData.Trash[0].Credential.Service != "gitlab" {
+		t.Errorf("expired entry should have been pruned: %+v", vault.vaultData.Trash)
+	}
+}
+
+func TestDeleteWithTrashDisabled(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	vault.trashRetentionDays = 0
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if len(vault.vaultData.Trash) != 0 {
+		t.Error("delete should be permanent when the trash is disabled")
+	}
+	if _, err := vault.RestoreFromTrash("github"); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("RestoreFromTrash(): got %v, want ErrNotInTrash", err)
+	}
+}
+
+func TestTrashKeepsAttachmentBlobsUntilPurge(t *testing.T) {
+	vault, vaultPath, cleanup := setupUnlockedVault(t, true)
+	defer cleanup()
+
+	attachment, err := vault.AddAttachment("github", "backup.bin", bytes.Repeat([]byte("x"), InlineAttachmentLimit+1))
+	if err != nil {
+		t.Fatalf("AddAttachment() failed: %v", err)
+	}
+	blob := filepath.Join(storage.AttachmentDirFor(vaultPath), attachment.ID+".blob")
+
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if _, err := os.Stat(blob); err != nil {
+		t.Fatalf("blob should be kept while the credential is in the trash: %v", err)
+	}
+
+	if _, err := vault.RestoreFromTrash("github"); err != nil {
+		t.Fatalf("RestoreFromTrash() failed: %v", err)
+	}
+	if _, data, err := vault.GetAttachment("github", "backup.bin"); err != nil || len(data) != InlineAttachmentLimit+1 {
+		t.Fatalf("attachment should be readable after restore: %v", err)
+	}
+
+	if err := vault.DeleteCredential("github"); err != nil {
+		t.Fatalf("DeleteCredential() failed: %v", err)
+	}
+	if _, err := vault.PurgeTrash("github"); err != nil {
+		t.Fatalf("PurgeTrash() failed: %v", err)
+	}
+	if _, err := os.Stat(blob); !os.IsNotExist(err) {
+		t.Errorf("blob should be removed after purge, stat err = %v", err)
+	}
+}
@@ -0,0 +1,393 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// URL match rules decide which page URLs a credential URL applies to
+const (
+	URLMatchDomain = "domain" // Same base domain (default): example.com matches login.example.com
+	URLMatchHost   = "host"   // Same host name (and port, if the rule has one)
+	URLMatchPrefix = "prefix" // Same scheme, host and port, at or below the rule URL's path
+	URLMatchRegex  = "regex"  // Page URL matches a regular expression
+)
+
+// ErrURLNotFound is returned when removing a URL a credential does not have
+var ErrURLNotFound = errors.New("url not found")
+
+// URLMatchRules returns the supported match rules, default first
+func URLMatchRules() []string {
+	return []string{URLMatchDomain, URLMatchHost, URLMatchPrefix, URLMatchRegex}
+}
+
+// URLRule is an additional URL of a credential with the rule used to match page URLs.
+// The credential's primary URL field always uses the domain rule.
+type URLRule struct {
+	URL   string `json:"url"`             // URL, or the pattern for regex rules
+	Match string `json:"match,omitempty"` // One of the URLMatch constants; empty means domain
+}
+
+// MatchRule returns the rule's match type, treating an empty type as domain
+func (r URLRule) MatchRule() string {
+	if r.Match == "" {
+		return URLMatchDomain
+	}
+	return r.Match
+}
+
+// String renders the rule the way ParseURLRule reads it ("host:https://login.example.com").
+// Domain rules are shown as the bare URL.
+func (r URLRule) String() string {
+	if r.MatchRule() == URLMatchDomain {
+		return r.URL
+	}
+	return r.Match + ":" + r.URL
+}
+
+// ParseURLRule reads a URL with an optional rule prefix: "domain:", "host:", "prefix:" or
+// "regex:". Without a prefix the domain rule is used. URLs without a scheme are accepted.
+func ParseURLRule(spec string) (URLRule, error) {
+	spec = strings.TrimSpace(spec)
+	rule := URLRule{URL: spec}
+	if i := strings.Index(spec, ":"); i > 0 {
+		for _, match := range URLMatchRules() {
+			if spec[:i] == match {
+				rule = URLRule{URL: strings.TrimSpace(spec[i+1:]), Match: match}
+				break
+			}
+		}
+	}
+	if rule.Match == URLMatchDomain {
+		rule.Match = ""
+	}
+	if err := rule.validate(); err != nil {
+		return URLRule{}, err
+	}
+	return rule, nil
+}
+
+// validate checks that the rule can be matched against page URLs
+func (r URLRule) validate() error {
+	if r.URL == "" {
+		return fmt.Errorf("%w: url cannot be empty", ErrInvalidCredential)
+	}
+	switch r.MatchRule() {
+	case URLMatchRegex:
+		if _, err := compileURLPattern(r.URL); err != nil {
+			return fmt.Errorf("%w: invalid url pattern %q: %v", ErrInvalidCredential, r.URL, err)
+		}
+	case URLMatchDomain, URLMatchHost, URLMatchPrefix:
+		if _, err := parsePageURL(r.URL); err != nil {
+			return fmt.Errorf("%w: invalid url %q", ErrInvalidCredential, r.URL)
+		}
+	default:
+		return fmt.Errorf("%w: unknown url match rule %q (valid: %s)", ErrInvalidCredential, r.Match, strings.Join(URLMatchRules(), ", "))
+	}
+	return nil
+}
+
+// urlPatterns caches compiled regex rules by pattern, so lookups don't compile
+// every rule of every credential again
+var urlPatterns sync.Map
+
+// compileURLPattern compiles a regex rule's pattern once
+func compileURLPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := urlPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	urlPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// parsePageURL parses a URL, assuming https when no scheme is given, and
+// requires a host name
+func parsePageURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("url has no host: %s", raw)
+	}
+	u.Host = strings.ToLower(u.Host)
+	return u, nil
+}
+
+// twoLevelSuffixes are second-level labels commonly used under country code
+// domains (example.co.uk), where the base domain has three labels
+var twoLevelSuffixes = map[string]bool{
+	"co": true, "com": true, "net": true, "org": true, "gov": true,
+	"edu": true, "ac": true, "or": true, "ne": true, "go": true,
+}
+
+// baseDomain returns the registrable part of a host name ("login.example.com" ->
+// "example.com"). IP addresses and single-label hosts are returned unchanged.
+func baseDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	keep := 2
+	if n := len(labels); n > 2 && len(labels[n-1]) == 2 && twoLevelSuffixes[labels[n-2]] {
+		keep = 3
+	}
+	if len(labels) <= keep {
+		return host
+	}
+	return strings.Join(labels[len(labels)-keep:], ".")
+}
+
+// Match scores: more specific rules rank higher. Prefix scores also grow with
+// the prefix length so the longest prefix wins.
+const (
+	scoreDomain    = 10
+	scoreSameHost  = 20
+	scoreRegex     = 30
+	scorePrefixMin = 40
+)
+
+// score reports how well the rule matches a page URL, 0 meaning no match
+func (r URLRule) score(page *url.URL, raw string) int {
+	switch r.MatchRule() {
+	case URLMatchRegex:
+		if re, err := compileURLPattern(r.URL); err == nil && re.MatchString(raw) {
+			return scoreRegex
+		}
+	case URLMatchPrefix:
+		prefix, err := parsePageURL(r.URL)
+		if err == nil && hasURLPrefix(page, prefix) {
+			return scorePrefixMin + len(prefix.String())
+		}
+	case URLMatchHost:
+		ruleURL, err := parsePageURL(r.URL)
+		if err == nil && ruleURL.Hostname() == page.Hostname() &&
+			(ruleURL.Port() == "" || ruleURL.Port() == page.Port()) {
+			return scoreSameHost
+		}
+	default:
+		ruleURL, err := parsePageURL(r.URL)
+		if err != nil {
+			return 0
+		}
+		if ruleURL.Hostname() == page.Hostname() {
+			return scoreSameHost
+		}
+		if baseDomain(ruleURL.Hostname()) == baseDomain(page.Hostname()) {
+			return scoreDomain
+		}
+	}
+	return 0
+}
+
+// hasURLPrefix reports whether page is on the prefix URL's server (same scheme,
+// host name and port) and at or below its path. Paths are compared by segment,
+// so /app is a prefix of /app/login but not of /application.
+func hasURLPrefix(page, prefix *url.URL) bool {
+	if page.Scheme != prefix.Scheme || page.Hostname() != prefix.Hostname() || page.Port() != prefix.Port() {
+		return false
+	}
+	prefixPath := strings.TrimSuffix(prefix.Path, "/")
+	return prefixPath == "" || page.Path == prefixPath || strings.HasPrefix(page.Path, prefixPath+"/")
+}
+
+// URLRules returns every URL of the credential: the primary URL (domain rule) first,
+// then the additional URLs
+func (c *Credential) URLRules() []URLRule {
+	rules := make([]URLRule, 0, len(c.URLs)+1)
+	if c.URL != "" {
+		rules = append(rules, URLRule{URL: c.URL})
+	}
+	return append(rules, c.URLs...)
+}
+
+// HasURL reports whether the credential has an additional URL, given as the URL
+// or in its rule form ("host:https://login.example.com")
+func (c *Credential) HasURL(spec string) bool {
+	return c.urlIndex(spec) >= 0
+}
+
+// AddURL adds an additional URL, replacing an existing entry for the same URL
+func (c *Credential) AddURL(rule URLRule) {
+	if i := c.urlIndex(rule.URL); i >= 0 {
+		c.URLs[i] = rule
+		return
+	}
+	c.URLs = append(c.URLs, rule)
+}
+
+// RemoveURL deletes an additional URL, reporting whether it existed
+func (c *Credential) RemoveURL(spec string) bool {
+	i := c.urlIndex(spec)
+	if i < 0 {
+		return false
+	}
+	c.URLs = append(c.URLs[:i], c.URLs[i+1:]...)
+	return true
+}
+
+// urlIndex returns the position of an additional URL in c.URLs, or -1
+func (c *Credential) urlIndex(spec string) int {
+	spec = strings.TrimSpace(spec)
+	for i, rule := range c.URLs {
+		if rule.URL == spec || rule.String() == spec {
+			return i
+		}
+	}
+	return -1
+}
+
+// URLMatch is a credential whose URLs match a page URL
+type URLMatch struct {
+	Service  string
+	Username string
+	Category string
+	Rule     URLRule // The best-matching URL of the credential
+	score    int
+}
+
+// FindByURL returns the credentials with a URL matching the given page URL, best match
+// first. Exact host and prefix matches rank above credentials that only share the base domain.
+func (v *VaultService) FindByURL(pageURL string) ([]URLMatch, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	page, err := parsePageURL(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid url %q", ErrInvalidCredential, pageURL)
+	}
+	raw := strings.TrimSpace(pageURL)
+
+	var matches []URLMatch
+	for _, credential := range v.vaultData.Credentials {
+		best := URLMatch{}
+		resolved := credential
+		resolved.URL = v.resolvedValue(&credential, "url", credential.URL)
+		for _, rule := range resolved.URLRules() {
+			if score := rule.score(page, raw); score > best.score {
+				best = URLMatch{
+					Service:  credential.Service,
+					Username: v.resolvedValue(&credential, "username", credential.Username),
+					Category: credential.Category,
+					Rule:     rule,
+					score:    score,
+				}
+			}
+		}
+		if best.score > 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].Service < matches[j].Service
+	})
+	return matches, nil
+}
+
+// BestURLMatches returns the matches that share the highest score (matches must come
+// from FindByURL, which sorts best first)
+func BestURLMatches(matches []URLMatch) []URLMatch {
+	for i := range matches {
+		if matches[i].score != matches[0].score {
+			return matches[:i]
+		}
+	}
+	return matches
+}
+
+// FindByRemote returns the credentials with a URL for the same server as
+// remoteURL, most specific first, for credential helpers that must not hand a
+// secret to another server. A URL matches when its scheme, host and port are the
+// remote's and its path is empty or a parent of the remote's path; a URL without
+// a scheme is an https URL and a trailing ".git" is ignored. The longest matching
+// path ranks first, prefix rules winning ties. Regex rules and other hosts of
+// the same domain never match.
+func (v *VaultService) FindByRemote(remoteURL string) ([]URLMatch, error) {
+	if !v.unlocked {
+		return nil, ErrVaultLocked
+	}
+
+	remote, err := parsePageURL(remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid url %q", ErrInvalidCredential, remoteURL)
+	}
+
+	var matches []URLMatch
+	for _, credential := range v.vaultData.Credentials {
+		best := URLMatch{}
+		resolved := credential
+		resolved.URL = v.resolvedValue(&credential, "url", credential.URL)
+		for _, rule := range resolved.URLRules() {
+			if score := rule.remoteScore(remote); score > best.score {
+				best = URLMatch{
+					Service:  credential.Service,
+					Username: v.resolvedValue(&credential, "username", credential.Username),
+					Category: credential.Category,
+					Rule:     rule,
+					score:    score,
+				}
+			}
+		}
+		if best.score > 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].Service < matches[j].Service
+	})
+	return matches, nil
+}
+
+// remoteScore reports how well the rule matches a remote server URL for
+// FindByRemote, 0 meaning no match. Longer matching paths score higher; at
+// the same length a prefix rule scores one more.
+func (r URLRule) remoteScore(remote *url.URL) int {
+	if r.MatchRule() == URLMatchRegex {
+		return 0
+	}
+
+	// Prefix rules get the same server and path checks. parsePageURL gives
+	// URLs without a scheme https, so they never match an http remote.
+	ruleURL, err := parsePageURL(r.URL)
+	if err != nil || ruleURL.Scheme != remote.Scheme ||
+		ruleURL.Hostname() != remote.Hostname() || ruleURL.Port() != remote.Port() {
+		return 0
+	}
+	rulePath, remotePath := remotePath(ruleURL), remotePath(remote)
+	if rulePath != "" && rulePath != remotePath && !strings.HasPrefix(remotePath, rulePath+"/") {
+		return 0
+	}
+	score := 2 * (len(rulePath) + 1)
+	if r.MatchRule() == URLMatchPrefix {
+		score++
+	}
+	return score
+}
+
+// remotePath returns a URL's path without surrounding slashes and ".git" suffix
+func remotePath(u *url.URL) string {
+	return strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+}
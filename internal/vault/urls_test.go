@@ -0,0 +1,249 @@
+package vault
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseURLRule(t *testing.T) {
+	tests := []struct {
+		spec string
+		want URLRule
+	}{
+		{"https://example.com", URLRule{URL: "https://example.com"}},
+		{"example.com", URLRule{URL: "example.com"}},
+		{"domain:example.com", URLRule{URL: "example.com"}},
+		{"host: login.example.com", URLRule{URL: "login.example.com", Match: URLMatchHost}},
+		{"prefix:https://git.example.com/acme/", URLRule{URL: "https://git.example.com/acme/", Match: URLMatchPrefix}},
+		{`regex:^https://[a-z]+\.corp\.example\.com/`, URLRule{URL: `^https://[a-z]+\.corp\.example\.com/`, Match: URLMatchRegex}},
+	}
+	for _, tt := range tests {
+		got, err := ParseURLRule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseURLRule(%q) failed: %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseURLRule(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"", "host:", "regex:[unclosed", "https://"} {
+		if _, err := ParseURLRule(spec); !errors.Is(err, ErrInvalidCredential) {
+			t.Errorf("ParseURLRule(%q): got %v, want ErrInvalidCredential", spec, err)
+		}
+	}
+}
+
+func TestBaseDomain(t *testing.T) {
+	tests := map[string]string{
+		"example.com":           "example.com",
+		"login.example.com":     "example.com",
+		"a.b.example.com":       "example.com",
+		"shop.example.co.uk":    "example.co.uk",
+		"localhost":             "localhost",
+		"192.168.1.10":          "192.168.1.10",
+		"deep.sub.example.io":   "example.io",
+		"www.example.com.au":    "example.com.au",
+		"accounts.google.co.jp": "google.co.jp",
+	}
+	for host, want := range tests {
+		if got := baseDomain(host); got != want {
+			t.Errorf("baseDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestFindByURL(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	records := []Credential{
+		{Service: "example", Username: "me", URL: "https://www.example.com"},
+		{Service: "example-login", Username: "me", URLs: []URLRule{{URL: "login.example.com", Match: URLMatchHost}}},
+		{Service: "example-admin", Username: "admin", URLs: []URLRule{{URL: "https://login.example.com/admin", Match: URLMatchPrefix}}},
+		{Service: "corp", Username: "me", URLs: []URLRule{{URL: `^https://[a-z]+\.example\.com/sso`, Match: URLMatchRegex}}},
+		{Service: "other", Username: "me", URL: "https://example.org"},
+	}
+	for _, record := range records {
+		record.Password = []byte("pass")
+		if err := vault.AddRecord(record); err != nil {
+			t.Fatalf("AddRecord(%s) failed: %v", record.Service, err)
+		}
+	}
+
+	matches, err := vault.FindByURL("https://login.example.com/admin/users")
+	if err != nil {
+		t.Fatalf("FindByURL() failed: %v", err)
+	}
+	var services []string
+	for _, match := range matches {
+		services = append(services, match.Service)
+	}
+	want := []string{"example-admin", "example-login", "example"}
+	if len(services) != len(want) {
+		t.Fatalf("FindByURL() = %v, want %v", services, want)
+	}
+	for i := range want {
+		if services[i] != want[i] {
+			t.Fatalf("FindByURL() = %v, want %v", services, want)
+		}
+	}
+	if best := BestURLMatches(matches); len(best) != 1 || best[0].Service != "example-admin" {
+		t.Errorf("BestURLMatches() = %+v, want example-admin", best)
+	}
+
+	// Regex rules see the page URL as given
+	matches, _ = vault.FindByURL("https://portal.example.com/sso/start")
+	if len(matches) != 2 || matches[0].Service != "corp" || matches[0].Rule.MatchRule() != URLMatchRegex {
+		t.Errorf("regex match should rank above the domain match: %+v", matches)
+	}
+
+	// Host rules need the exact host; the domain rule still applies
+	matches, _ = vault.FindByURL("example.com")
+	if len(matches) != 1 || matches[0].Service != "example" {
+		t.Errorf("FindByURL(example.com) = %+v, want example", matches)
+	}
+
+	if matches, _ := vault.FindByURL("https://unrelated.net"); len(matches) != 0 {
+		t.Errorf("FindByURL(unrelated) = %+v, want none", matches)
+	}
+	if _, err := vault.FindByURL("https://"); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("FindByURL(no host): got %v, want ErrInvalidCredential", err)
+	}
+}
+
+func TestURLRulePrefixScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		page   string
+		match  bool
+	}{
+		{"same URL", "https://example.com/app", "https://example.com/app", true},
+		{"below path", "https://example.com/app", "https://example.com/app/login?next=1", true},
+		{"trailing slash", "https://example.com/app/", "https://example.com/app/login", true},
+		{"host only", "https://github.com", "https://github.com/acme/api", true},
+		{"no scheme means https", "example.com/app", "https://example.com/app/x", true},
+		{"string prefix of path", "https://example.com/app", "https://example.com/application-evil", false},
+		{"lookalike host", "https://github.com", "https://github.com.evil.net/", false},
+		{"longer host", "https://github.com", "https://github.company.io/", false},
+		{"userinfo", "https://bank.com", "https://bank.com@evil.com/", false},
+		{"other scheme", "https://example.com/app", "http://example.com/app", false},
+		{"other port", "https://example.com/app", "https://example.com:8443/app", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := parsePageURL(tt.page)
+			if err != nil {
+				t.Fatalf("parsePageURL(%s) failed: %v", tt.page, err)
+			}
+			rule := URLRule{URL: tt.prefix, Match: URLMatchPrefix}
+			if got := rule.score(page, tt.page) > 0; got != tt.match {
+				t.Errorf("prefix %s matching %s = %v, want %v", tt.prefix, tt.page, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestFindByRemote(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	records := []Credential{
+		{Service: "github-me", Username: "me", URL: "https://github.com"},
+		{Service: "github-work", Username: "bot", URL: "https://github.com/acme"},
+		{Service: "github-no-scheme", Username: "me", URL: "github.com/acme/api.git"},
+		{Service: "gist", Username: "me", URL: "https://gist.github.com"},
+		{Service: "github-regex", Username: "me", URLs: []URLRule{{URL: `github\.com`, Match: URLMatchRegex}}},
+		{Service: "gitea", Username: "me", URL: "http://git.internal:3000"},
+		{Service: "github-prefix", Username: "ci", URLs: []URLRule{{URL: "https://github.com/acme", Match: URLMatchPrefix}}},
+		{Service: "gitlab-any", Username: "me", URLs: []URLRule{{URL: "https://gitlab.com/", Match: URLMatchPrefix}}},
+		{Service: "gitlab-repo", Username: "deploy", URL: "https://gitlab.com/org/repo"},
+	}
+	for _, record := range records {
+		record.Password = []byte("pass")
+		if err := vault.AddRecord(record); err != nil {
+			t.Fatalf("AddRecord(%s) failed: %v", record.Service, err)
+		}
+	}
+
+	tests := []struct {
+		remote string
+		want   []string
+	}{
+		{"https://github.com", []string{"github-me"}},
+		{"https://github.com/acme/web.git", []string{"github-prefix", "github-work", "github-me"}},
+		{"https://github.com/acme/api", []string{"github-no-scheme", "github-prefix", "github-work", "github-me"}},
+		{"https://github.com/acmecorp/api", []string{"github-me"}},
+		// URLs without a scheme only match https remotes
+		{"http://github.com/acme/api", nil},
+		// The longer path wins over a shorter prefix rule
+		{"https://gitlab.com/org/repo.git", []string{"gitlab-repo", "gitlab-any"}},
+		{"https://gitlab.com/org/other", []string{"gitlab-any"}},
+		{"http://git.internal:3000/team/repo", []string{"gitea"}},
+		{"https://git.internal:3000", nil},
+		{"http://git.internal", nil},
+		{"https://github.com.evil.net/acme/web.git", nil},
+		{"https://github.com@evil.net/acme/web.git", nil},
+		{"https://github.com/acme-evil/web.git", []string{"github-me"}},
+	}
+	for _, tt := range tests {
+		matches, err := vault.FindByRemote(tt.remote)
+		if err != nil {
+			t.Fatalf("FindByRemote(%s) failed: %v", tt.remote, err)
+		}
+		var services []string
+		for _, match := range matches {
+			services = append(services, match.Service)
+		}
+		if strings.Join(services, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("FindByRemote(%s) = %v, want %v", tt.remote, services, tt.want)
+		}
+	}
+}
+
+func TestUpdateCredentialURLs(t *testing.T) {
+	vault, _, cleanup := setupUnlockedVault(t, false)
+	defer cleanup()
+
+	host, _ := ParseURLRule("host:login.github.com")
+	if err := vault.UpdateCredential("github", UpdateOpts{AddURLs: []URLRule{host, {URL: "github.io"}}}); err != nil {
+		t.Fatalf("UpdateCredential(add) failed: %v", err)
+	}
+	cred, _ := vault.GetCredential("github", false)
+	if len(cred.URLs) != 2 {
+		t.Fatalf("URLs = %+v, want 2 entries", cred.URLs)
+	}
+
+	// Removal accepts the rule form as well as the bare URL
+	if err := vault.UpdateCredential("github", UpdateOpts{RemoveURLs: []string{"host:login.github.com"}}); err != nil {
+		t.Fatalf("UpdateCredential(remove) failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("github", false)
+	if len(cred.URLs) != 1 || cred.URLs[0].URL != "github.io" {
+		t.Errorf("URLs = %+v, want github.io", cred.URLs)
+	}
+
+	if err := vault.UpdateCredential("github", UpdateOpts{RemoveURLs: []string{"missing.com"}}); !errors.Is(err, ErrURLNotFound) {
+		t.Errorf("removing a missing URL: got %v, want ErrURLNotFound", err)
+	}
+	if err := vault.UpdateCredential("github", UpdateOpts{AddURLs: []URLRule{{URL: "x", Match: "fuzzy"}}}); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("unknown match rule: got %v, want ErrInvalidCredential", err)
+	}
+
+	// URL changes are part of history and can be restored
+	history, _ := vault.GetHistory("github")
+	last := history[len(history)-1]
+	if last.URLs == nil || len(*last.URLs) != 2 {
+		t.Fatalf("last revision should hold the previous URLs: %+v", last)
+	}
+	if err := vault.RestoreRevision("github", last.Number); err != nil {
+		t.Fatalf("RestoreRevision() failed: %v", err)
+	}
+	cred, _ = vault.GetCredential("github", false)
+	if len(cred.URLs) != 2 {
+		t.Errorf("restored URLs = %+v, want 2 entries", cred.URLs)
+	}
+}
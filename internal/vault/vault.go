@@ -69,6 +69,7 @@ type UsageRecord struct {
 // T020c: Password field changed from string to []byte for secure memory handling
 type Credential struct {
 	Service       string                 `json:"service"`
+	Type          string                 `json:"type,omitempty"` // Record type (see RecordTypes); empty means login
 	Username      string                 `json:"username"`
 	Password      []byte                 `json:"password"` // T020c: Changed to []byte for memory security
 	Category      string                 `json:"category,omitempty"`
@@ -85,11 +86,32 @@ type Credential struct {
 	TOTPDigits    int    `json:"totp_digits,omitempty"`    // 6 or 8 (default: 6)
 	TOTPPeriod    int    `json:"totp_period,omitempty"`    // Period in seconds (default: 30)
 	TOTPIssuer    string `json:"totp_issuer,omitempty"`    // Issuer name for display
+
+	// User-defined fields (API keys, account IDs, security questions, etc.)
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
+
+	// Free-form labels, lowercase and sorted (a credential may have many, unlike Category)
+	Tags []string `json:"tags,omitempty"`
+
+	// Additional URLs with match rules, used by URL lookup alongside the primary URL
+	URLs []URLRule `json:"urls,omitempty"`
+
+	// Previous field values, oldest first (bounded by history.max_revisions)
+	Revisions []Revision `json:"revisions,omitempty"`
+
+	// Attached files (small ones inline, large ones as sidecar blobs)
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// Expiry and rotation reminders (all optional)
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`    // Fixed expiry date (e.g. API token lifetime)
+	RotationDays int        `json:"rotation_days,omitempty"` // Rotate the secret every N days
+	RotatedAt    *time.Time `json:"rotated_at,omitempty"`    // Last password or hidden field change
 }
 
 // VaultData is the decrypted vault structure
 type VaultData struct {
-	Credentials map[string]Credential `json:"credentials"` // Map of service name -> Credential
+	Credentials map[string]Credential `json:"credentials"`     // Map of service name -> Credential
+	Trash       []TrashedCredential   `json:"trash,omitempty"` // Deleted credentials, restorable until purged
 	Version     int                   `json:"version"`
 	// Audit configuration persistence (fix for DISC-013)
 	AuditEnabled bool   `json:"audit_enabled,omitempty"`  // Whether audit logging is enabled
@@ -103,12 +125,15 @@ type VaultService struct {
 	cryptoService   *crypto.CryptoService
 	storageService  *storage.StorageService
 	keychainService *keychain.KeychainService
+	keychainOff     bool // Keychain unlock turned off by the vault's registry profile
 
 	// In-memory state
 	unlocked       bool
 	masterPassword []byte // Byte array for secure memory clearing (T009)
 	vaultData      *VaultData
 	recoveryDEK    []byte // DEK from recovery unlock (for SetPasswordAfterRecovery)
+	identityDEK    []byte // DEK from a recipient identity unlock (no master password)
+	identityName   string // Recipient name of the identity used to unlock
 
 	// T066: Audit logging configuration (FR-025: default disabled)
 	auditEnabled bool
@@ -120,6 +145,12 @@ type VaultService struct {
 	// Smart sync service (nil if sync disabled)
 	syncService          *intsync.Service
 	syncConflictDetected bool // prevents auto-push after conflict
+
+	// Number of revisions kept per credential (0 disables history)
+	maxRevisions int
+
+	// Days deleted credentials stay in the trash (0 deletes immediately)
+	trashRetentionDays int
 }
 
 // New creates a new VaultService
@@ -151,6 +182,9 @@ func New(vaultPath string) (*VaultService, error) {
 		unlocked:        false,
 		auditEnabled:    false,                               // T066: Default disabled per FR-025
 		rateLimiter:     security.NewValidationRateLimiter(), // T051a: Initialize rate limiter
+		maxRevisions:    DefaultMaxRevisions,
+
+		trashRetentionDays: DefaultTrashRetentionDays,
 	}
 
 	// Initialize sync service from config (if sync enabled)
@@ -158,6 +192,13 @@ func New(vaultPath string) (*VaultService, error) {
 	if cfg != nil && cfg.Sync.Enabled {
 		v.syncService = intsync.NewService(cfg.Sync)
 	}
+	if cfg != nil {
+		v.maxRevisions = cfg.History.MaxRevisions
+		v.trashRetentionDays = cfg.Trash.RetentionDays
+		if profile, ok := cfg.ActiveProfile(); ok {
+			v.keychainOff = !profile.KeychainEnabled()
+		}
+	}
 
 	// T010: Load metadata file (if exists) to enable audit logging before vault unlock
 	meta, err := LoadMetadata(vaultPath)
@@ -821,6 +862,7 @@ func (v *VaultService) Unlock(masterPassword []byte) error {
 			fmt.Fprintf(os.Stderr, "Warning: failed to remove backup file: %v\n", err)
 		}
 	}
+	_ = os.RemoveAll(storage.AttachmentDirFor(backupPath))
 
 	// T068: Log unlock success (FR-019)
 	v.LogAudit(security.EventVaultUnlock, security.OutcomeSuccess, "")
@@ -862,6 +904,17 @@ func (v *VaultService) UnlockWithKey(vaultKey []byte) error {
 	v.recoveryDEK = make([]byte, len(vaultKey))
 	copy(v.recoveryDEK, vaultKey)
 
+	v.finishKeyUnlock(&vaultData)
+
+	// Log unlock success
+	v.LogAudit(security.EventVaultUnlock, security.OutcomeSuccess, "recovery")
+
+	return nil
+}
+
+// finishKeyUnlock restores audit logging, syncs metadata and removes the
+// backup after an unlock that did not use the master password.
+func (v *VaultService) finishKeyUnlock(vaultData *VaultData) {
 	// Restore audit logging if enabled
 	if vaultData.AuditEnabled && vaultData.AuditLogPath != "" && vaultData.VaultID != "" {
 		if err := v.EnableAudit(vaultData.AuditLogPath, vaultData.VaultID); err != nil {
@@ -912,15 +965,15 @@ func (v *VaultService) UnlockWithKey(vaultKey []byte) error {
 			fmt.Fprintf(os.Stderr, "Warning: failed to remove backup file: %v\n", err)
 		}
 	}
-
-	// Log unlock success
-	v.LogAudit(security.EventVaultUnlock, security.OutcomeSuccess, "recovery")
-
-	return nil
+	_ = os.RemoveAll(storage.AttachmentDirFor(backupPath))
 }
 
 // UnlockWithKeychain attempts to unlock using keychain-stored password
 func (v *VaultService) UnlockWithKeychain() error {
+	if v.keychainOff {
+		return ErrKeychainNotEnabled
+	}
+
 	// T018: Check metadata to see if keychain is enabled (FR-007)
 	metadata, err := v.LoadMetadata()
 	if err != nil {
@@ -981,6 +1034,13 @@ func (v *VaultService) Lock() {
 		v.recoveryDEK = nil
 	}
 
+	// Clear identity DEK if present
+	if v.identityDEK != nil {
+		crypto.ClearBytes(v.identityDEK)
+		v.identityDEK = nil
+		v.identityName = ""
+	}
+
 	v.vaultData = nil
 }
 
@@ -1000,6 +1060,14 @@ func (v *VaultService) save() error {
 		return fmt.Errorf("failed to marshal vault data: %w", err)
 	}
 
+	// Unlocked with a recipient identity: there is no password, save with the DEK
+	if v.masterPassword == nil && v.identityDEK != nil {
+		if err := v.storageService.SaveVaultWithDEK(data, v.identityDEK, v.createAuditCallback()); err != nil {
+			return fmt.Errorf("failed to save vault: %w", err)
+		}
+		return nil
+	}
+
 	// Convert to string for storage service (TODO: Phase 4 will update storage.go to accept []byte)
 	masterPasswordStr := string(v.masterPassword)
 
@@ -1015,60 +1083,37 @@ func (v *VaultService) save() error {
 // T020d: Password parameter changed to []byte for memory security
 // T020e: Added deferred cleanup for password parameter
 func (v *VaultService) AddCredential(service, username string, password []byte, category, url, notes string) error {
-	defer crypto.ClearBytes(password) // T020e: Ensure cleanup even on error
-
-	if !v.unlocked {
-		return ErrVaultLocked
-	}
-
-	// Validate inputs
-	if service == "" {
-		return fmt.Errorf("%w: service name cannot be empty", ErrInvalidCredential)
-	}
-	if len(password) == 0 {
-		return fmt.Errorf("%w: password cannot be empty", ErrInvalidCredential)
-	}
-
-	// Check for duplicates
-	if _, exists := v.vaultData.Credentials[service]; exists {
-		return fmt.Errorf("%w: %s", ErrCredentialExists, service)
-	}
-
-	// Create credential (make a copy of password to store)
-	now := time.Now()
-	passwordCopy := make([]byte, len(password))
-	copy(passwordCopy, password)
-
-	credential := Credential{
-		Service:       service,
-		Username:      username,
-		Password:      passwordCopy, // T020d: Store []byte password
-		Category:      category,
-		URL:           url,
-		Notes:         notes,
-		CreatedAt:     now,
-		UpdatedAt:     now,
-		ModifiedCount: 0, // Initialize modification counter
-		UsageRecord:   make(map[string]UsageRecord),
-	}
-
-	// Add to vault
-	v.vaultData.Credentials[service] = credential
-
-	// Save to disk
-	if err := v.save(); err != nil {
-		return err
-	}
-
-	// T071: Log credential add (FR-020)
-	v.LogAudit(security.EventCredentialAdd, security.OutcomeSuccess, service)
-	return nil
+	// T020e: AddRecord clears the password, even on error
+	return v.AddRecord(Credential{
+		Service:  service,
+		Type:     RecordTypeLogin,
+		Username: username,
+		Password: password,
+		Category: category,
+		URL:      url,
+		Notes:    notes,
+	})
 }
 
 // GetCredential retrieves a credential without automatic tracking
 // Callers should explicitly track field access using RecordFieldAccess
 // Deprecated trackUsage parameter is ignored (kept for backward compatibility)
+// {ref:service.field} references are resolved; use GetCredentialRaw for stored values.
 func (v *VaultService) GetCredential(service string, trackUsage bool) (*Credential, error) {
+	cred, err := v.GetCredentialRaw(service)
+	if err != nil {
+		return nil, err
+	}
+
+	// References that cannot be resolved are left as stored
+	credential := v.vaultData.Credentials[service]
+	_ = v.resolveFields(cred, &credential, false)
+	return cred, nil
+}
+
+// GetCredentialRaw retrieves a credential with references left unresolved, for
+// editing and for showing where a value comes from
+func (v *VaultService) GetCredentialRaw(service string) (*Credential, error) {
 	if !v.unlocked {
 		return nil, ErrVaultLocked
 	}
@@ -1091,6 +1136,34 @@ func (v *VaultService) GetCredential(service string, trackUsage bool) (*Credenti
 		cred.Password = make([]byte, len(credential.Password))
 		copy(cred.Password, credential.Password)
 	}
+	if credential.CustomFields != nil {
+		cred.CustomFields = make([]CustomField, len(credential.CustomFields))
+		copy(cred.CustomFields, credential.CustomFields)
+	}
+	if credential.Tags != nil {
+		cred.Tags = append([]string(nil), credential.Tags...)
+	}
+	if credential.URLs != nil {
+		cred.URLs = append([]URLRule(nil), credential.URLs...)
+	}
+	if credential.ExpiresAt != nil {
+		expiresAt := *credential.ExpiresAt
+		cred.ExpiresAt = &expiresAt
+	}
+	if credential.RotatedAt != nil {
+		rotatedAt := *credential.RotatedAt
+		cred.RotatedAt = &rotatedAt
+	}
+	// Revisions hold previous passwords - only exposed through GetHistory
+	cred.Revisions = nil
+	// Attachment content is only exposed through GetAttachment
+	if credential.Attachments != nil {
+		cred.Attachments = make([]Attachment, len(credential.Attachments))
+		for i, attachment := range credential.Attachments {
+			attachment.Data = nil
+			cred.Attachments[i] = attachment
+		}
+	}
 	return &cred, nil
 }
 
@@ -1201,11 +1274,29 @@ type UpdateOpts struct {
 	TOTPPeriod    *int    // Period in seconds
 	TOTPIssuer    *string // Issuer name
 	ClearTOTP     bool    // If true, clears all TOTP fields
+
+	// Custom fields (removals are applied before sets, so a field can be replaced in one update)
+	SetCustomFields    []CustomField // Add or replace fields by name
+	RemoveCustomFields []string      // Names of fields to delete
+
+	// Tags (removals are applied before additions)
+	AddTags    []string // Tags to add (normalized to lowercase)
+	RemoveTags []string // Tags to remove
+
+	// Additional URLs (removals are applied before additions)
+	AddURLs    []URLRule // URLs to add, replacing entries with the same URL
+	RemoveURLs []string  // URLs to remove, as the URL or its rule form
+
+	// Expiry (nil = don't change)
+	ExpiresAt    *time.Time // Fixed expiry date
+	ClearExpiry  bool       // If true, removes the fixed expiry date
+	RotationDays *int       // Rotation interval in days (0 disables)
 }
 
 // CredentialMetadata contains non-sensitive credential information for listing
 type CredentialMetadata struct {
 	Service         string
+	Type            string // Record type, always set (login for legacy credentials)
 	Username        string
 	Category        string
 	URL             string
@@ -1221,6 +1312,20 @@ type CredentialMetadata struct {
 	// TOTP metadata (non-sensitive)
 	HasTOTP    bool   // Whether TOTP is configured for this credential
 	TOTPIssuer string // Issuer name for display
+
+	// Custom field names only - values may be secret and are never exposed in listings
+	CustomFieldNames []string
+
+	RevisionCount int // Number of revisions in the credential's history
+
+	Tags []string // Credential tags, sorted
+
+	URLs []URLRule // Additional URLs with match rules
+
+	AttachmentCount int // Number of attached files
+
+	ExpiresAt    time.Time // Effective expiry (fixed date or rotation due date); zero when none
+	RotationDays int       // Rotation interval in days (0 when not set)
 }
 
 // ListCredentialsWithMetadata returns all credentials with metadata (no passwords)
@@ -1233,10 +1338,11 @@ func (v *VaultService) ListCredentialsWithMetadata() ([]CredentialMetadata, erro
 	for _, cred := range v.vaultData.Credentials {
 		meta := CredentialMetadata{
 			Service:       cred.Service,
-			Username:      cred.Username,
+			Type:          cred.RecordType(),
+			Username:      v.resolvedValue(&cred, "username", cred.Username),
 			Category:      cred.Category,
-			URL:           cred.URL,
-			Notes:         cred.Notes,
+			URL:           v.resolvedValue(&cred, "url", cred.URL),
+			Notes:         v.resolvedValue(&cred, "notes", cred.Notes),
 			CreatedAt:     cred.CreatedAt,
 			UpdatedAt:     cred.UpdatedAt,
 			ModifiedCount: cred.ModifiedCount,
@@ -1275,6 +1381,22 @@ func (v *VaultService) ListCredentialsWithMetadata() ([]CredentialMetadata, erro
 		meta.HasTOTP = cred.TOTPSecret != ""
 		meta.TOTPIssuer = cred.TOTPIssuer
 
+		if len(cred.CustomFields) > 0 {
+			meta.CustomFieldNames = cred.CustomFieldNames()
+		}
+		meta.RevisionCount = len(cred.Revisions)
+		if len(cred.Tags) > 0 {
+			meta.Tags = append([]string(nil), cred.Tags...)
+		}
+		if len(cred.URLs) > 0 {
+			meta.URLs = append([]URLRule(nil), cred.URLs...)
+		}
+		meta.AttachmentCount = len(cred.Attachments)
+		if expiry, ok := cred.ExpiryDate(); ok {
+			meta.ExpiresAt = expiry
+		}
+		meta.RotationDays = cred.RotationDays
+
 		metadata = append(metadata, meta)
 	}
 
@@ -1299,6 +1421,57 @@ func (v *VaultService) UpdateCredential(service string, opts UpdateOpts) error {
 		return fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
 	}
 
+	// Validate custom field changes before touching the credential
+	for _, name := range opts.RemoveCustomFields {
+		if _, found := credential.GetCustomField(name); !found {
+			return fmt.Errorf("%w: %s", ErrCustomFieldNotFound, name)
+		}
+	}
+	for _, field := range opts.SetCustomFields {
+		if err := ValidateCustomFieldName(field.Name); err != nil {
+			return err
+		}
+	}
+	schema, _ := GetRecordSchema(credential.RecordType())
+	setCustomFields := append([]CustomField(nil), opts.SetCustomFields...)
+	schema.applyFieldFlags(setCustomFields)
+	if opts.Password != nil && len(*opts.Password) > 0 && schema.PasswordLabel == "" {
+		return fmt.Errorf("%w: %s records have no password", ErrInvalidCredential, schema.Type)
+	}
+
+	// Validate tag changes the same way
+	for _, tag := range opts.RemoveTags {
+		if !credential.HasTag(tag) {
+			return fmt.Errorf("%w: %s", ErrTagNotFound, tag)
+		}
+	}
+	addTags := make([]string, 0, len(opts.AddTags))
+	for _, tag := range opts.AddTags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return err
+		}
+		addTags = append(addTags, normalized)
+	}
+	if opts.RotationDays != nil && *opts.RotationDays < 0 {
+		return fmt.Errorf("%w: rotation interval cannot be negative", ErrInvalidCredential)
+	}
+
+	// And URL changes
+	for _, spec := range opts.RemoveURLs {
+		if !credential.HasURL(spec) {
+			return fmt.Errorf("%w: %s", ErrURLNotFound, spec)
+		}
+	}
+	for _, rule := range opts.AddURLs {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Keep the pre-update state for revision history
+	before := credential
+
 	// Track if any field was actually updated
 	fieldUpdated := false
 
@@ -1315,7 +1488,7 @@ func (v *VaultService) UpdateCredential(service string, opts UpdateOpts) error {
 		fieldUpdated = true
 	}
 	if opts.Category != nil {
-		credential.Category = *opts.Category
+		credential.Category = NormalizeFolder(*opts.Category)
 		fieldUpdated = true
 	}
 	if opts.URL != nil {
@@ -1372,11 +1545,87 @@ func (v *VaultService) UpdateCredential(service string, opts UpdateOpts) error {
 		}
 	}
 
+	// Custom field updates: copy first so the stored slice is never shared
+	if len(opts.RemoveCustomFields) > 0 || len(opts.SetCustomFields) > 0 {
+		credential.CustomFields = append([]CustomField(nil), credential.CustomFields...)
+		for _, name := range opts.RemoveCustomFields {
+			credential.RemoveCustomField(name)
+		}
+		for _, field := range setCustomFields {
+			credential.SetCustomField(field)
+		}
+		fieldUpdated = true
+
+		// Type-specific required fields cannot be removed or emptied
+		if err := schema.validateFields(&credential); err != nil {
+			return err
+		}
+	}
+
+	// Tag updates: copy first for the same reason
+	if len(opts.RemoveTags) > 0 || len(addTags) > 0 {
+		credential.Tags = append([]string(nil), credential.Tags...)
+		for _, tag := range opts.RemoveTags {
+			credential.RemoveTag(tag)
+		}
+		for _, tag := range addTags {
+			credential.AddTag(tag)
+		}
+		if len(credential.Tags) == 0 {
+			credential.Tags = nil
+		}
+		fieldUpdated = true
+	}
+
+	// URL updates: copy first for the same reason
+	if len(opts.RemoveURLs) > 0 || len(opts.AddURLs) > 0 {
+		credential.URLs = append([]URLRule(nil), credential.URLs...)
+		for _, spec := range opts.RemoveURLs {
+			credential.RemoveURL(spec)
+		}
+		for _, rule := range opts.AddURLs {
+			credential.AddURL(rule)
+		}
+		if len(credential.URLs) == 0 {
+			credential.URLs = nil
+		}
+		fieldUpdated = true
+	}
+
+	// Expiry updates
+	if opts.ClearExpiry {
+		credential.ExpiresAt = nil
+		fieldUpdated = true
+	} else if opts.ExpiresAt != nil {
+		expiresAt := *opts.ExpiresAt
+		credential.ExpiresAt = &expiresAt
+		fieldUpdated = true
+	}
+	if opts.RotationDays != nil {
+		credential.RotationDays = *opts.RotationDays
+		fieldUpdated = true
+	}
+	if secretChanged(&before, &credential) {
+		rotatedAt := time.Now()
+		credential.RotatedAt = &rotatedAt
+	}
+
+	// References must resolve against the updated values
+	if err := v.validateReferences(&credential); err != nil {
+		return err
+	}
+
 	// Only increment counter if something was actually modified
 	if fieldUpdated {
 		credential.ModifiedCount++
 	}
 
+	// Record replaced values so the update can be undone via history restore
+	if rev := snapshotChanges(&before, &credential); rev != nil {
+		rev.Timestamp = time.Now()
+		credential.appendRevision(*rev, v.maxRevisions)
+	}
+
 	credential.UpdatedAt = time.Now()
 	v.vaultData.Credentials[service] = credential
 
@@ -1425,21 +1674,69 @@ func (v *VaultService) UpdateCredentialFields(service, username, password, categ
 	return v.UpdateCredential(service, opts)
 }
 
-// DeleteCredential removes a credential from the vault
+// RenameCredential changes a credential's service name. The credential is re-keyed
+// as is, so usage records, history, TOTP, attachments and timestamps are kept.
+func (v *VaultService) RenameCredential(oldService, newService string) error {
+	if !v.unlocked {
+		return ErrVaultLocked
+	}
+
+	credential, exists := v.vaultData.Credentials[oldService]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrCredentialNotFound, oldService)
+	}
+	if newService == "" {
+		return fmt.Errorf("%w: service name cannot be empty", ErrInvalidCredential)
+	}
+	if newService == oldService {
+		return nil
+	}
+	if _, exists := v.vaultData.Credentials[newService]; exists {
+		return fmt.Errorf("%w: %s", ErrCredentialExists, newService)
+	}
+
+	credential.Service = newService
+	credential.ModifiedCount++
+	credential.UpdatedAt = time.Now()
+	delete(v.vaultData.Credentials, oldService)
+	v.vaultData.Credentials[newService] = credential
+
+	if err := v.save(); err != nil {
+		return err
+	}
+
+	v.LogAudit(security.EventCredentialRename, security.OutcomeSuccess, oldService+" -> "+newService)
+	return nil
+}
+
+// DeleteCredential removes a credential from the vault. The credential is moved to
+// the trash and can be restored until trash.retention_days have passed; with the
+// trash disabled it is deleted permanently.
 func (v *VaultService) DeleteCredential(service string) error {
 	if !v.unlocked {
 		return ErrVaultLocked
 	}
 
-	if _, exists := v.vaultData.Credentials[service]; !exists {
+	credential, exists := v.vaultData.Credentials[service]
+	if !exists {
 		return fmt.Errorf("%w: %s", ErrCredentialNotFound, service)
 	}
 
-	delete(v.vaultData.Credentials, service)
+	var purged []TrashedCredential
+	if v.trashRetentionDays > 0 {
+		var err error
+		if purged, err = v.moveToTrash(credential, time.Now()); err != nil {
+			return err
+		}
+	} else {
+		delete(v.vaultData.Credentials, service)
+		purged = []TrashedCredential{{Credential: credential}}
+	}
 
 	if err := v.save(); err != nil {
 		return err
 	}
+	v.removeTrashedBlobs(purged)
 
 	// T071: Log credential delete (FR-020)
 	v.LogAudit(security.EventCredentialDelete, security.OutcomeSuccess, service)
@@ -1537,6 +1834,9 @@ func (v *VaultService) ChangePassword(newPassword []byte) error {
 	if vaultVersion == 2 {
 		// T041: V2 vault - use ChangePasswordV2 to re-wrap DEK
 		// V2 vaults require the old password to unwrap the DEK
+		if v.identityDEK != nil {
+			return fmt.Errorf("cannot change password: %w", ErrPasswordRequired)
+		}
 		if v.masterPassword == nil {
 			return errors.New("cannot change password: vault was unlocked via recovery, set a new password first")
 		}
@@ -1780,6 +2080,11 @@ func (v *VaultService) RemoveVault(force bool, removeAll bool) (*RemoveVaultResu
 		result.FileDeleted = true
 	}
 
+	// Sidecar attachments are useless without the vault that holds their key
+	if err := os.RemoveAll(storage.AttachmentDirFor(v.vaultPath)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to delete attachments: %v\n", err)
+	}
+
 	// Attempt to delete keychain entry
 	if v.keychainService.IsAvailable() {
 		err = v.keychainService.Delete()
@@ -3,6 +3,7 @@ package vault
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -80,6 +81,35 @@ func setupTestVaultWithStorage(t *testing.T) (*VaultService, *storage.StorageSer
 	return vault, vault.storageService, cleanup
 }
 
+// setupUnlockedVault creates an unlocked vault holding one "github" login
+// (user / pass). With v2 the vault is created with a DEK, as attachments,
+// recipients and DEK-encrypted exports need.
+func setupUnlockedVault(t *testing.T, v2 bool) (*VaultService, string, func()) {
+	t.Helper()
+	vault, vaultPath, cleanup := setupTestVault(t)
+
+	password := "TestPassword123!"
+	var err error
+	if v2 {
+		_, err = vault.InitializeWithRecovery([]byte(password), false, "", "", nil)
+	} else {
+		err = vault.Initialize([]byte(password), false, "", "")
+	}
+	if err != nil {
+		cleanup()
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		cleanup()
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("github", "user", []byte("pass"), "", "", ""); err != nil {
+		cleanup()
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+	return vault, vaultPath, cleanup
+}
+
 func TestNew(t *testing.T) {
 	vault, _, cleanup := setupTestVault(t)
 	defer cleanup()
@@ -740,6 +770,79 @@ func TestDeleteNonExistentCredential(t *testing.T) {
 	}
 }
 
+func TestRenameCredential(t *testing.T) {
+	vault, _, cleanup := setupTestVault(t)
+	defer cleanup()
+
+	password := "TestPassword123!"
+
+	if err := vault.Initialize([]byte(password), false, "", ""); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	if err := vault.Unlock([]byte(password)); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := vault.AddCredential("github", "user", []byte("pass"), "Dev", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+	if err := vault.AddCredential("gitlab", "user", []byte("pass"), "", "", ""); err != nil {
+		t.Fatalf("AddCredential() failed: %v", err)
+	}
+
+	secret := "JBSWY3DPEHPK3PXP"
+	newPassword := []byte("pass2")
+	if err := vault.UpdateCredential("github", UpdateOpts{Password: &newPassword, TOTPSecret: &secret}); err != nil {
+		t.Fatalf("UpdateCredential() failed: %v", err)
+	}
+	if _, err := vault.GetCredential("github", true); err != nil {
+		t.Fatalf("GetCredential() failed: %v", err)
+	}
+	if err := vault.RecordFieldAccess("github", "password"); err != nil {
+		t.Fatalf("RecordFieldAccess() failed: %v", err)
+	}
+	before, _ := vault.GetCredential("github", false)
+
+	if err := vault.RenameCredential("github", "github-work"); err != nil {
+		t.Fatalf("RenameCredential() failed: %v", err)
+	}
+
+	if _, err := vault.GetCredential("github", false); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("old name should be gone, got %v", err)
+	}
+	after, err := vault.GetCredential("github-work", false)
+	if err != nil {
+		t.Fatalf("renamed credential not found: %v", err)
+	}
+	if after.Service != "github-work" {
+		t.Errorf("Service = %q, want github-work", after.Service)
+	}
+	if !after.CreatedAt.Equal(before.CreatedAt) {
+		t.Error("rename should keep CreatedAt")
+	}
+	if after.ModifiedCount != before.ModifiedCount+1 {
+		t.Errorf("ModifiedCount = %d, want %d", after.ModifiedCount, before.ModifiedCount+1)
+	}
+	if after.TOTPSecret != secret || after.Category != "Dev" {
+		t.Error("rename should keep TOTP and category")
+	}
+	if len(after.UsageRecord) != len(before.UsageRecord) || len(after.UsageRecord) == 0 {
+		t.Errorf("rename should keep usage records: %v", after.UsageRecord)
+	}
+	if history, _ := vault.GetHistory("github-work"); len(history) != 1 {
+		t.Errorf("rename should keep history, got %d revisions", len(history))
+	}
+
+	if err := vault.RenameCredential("github-work", "gitlab"); !errors.Is(err, ErrCredentialExists) {
+		t.Errorf("rename onto an existing credential: got %v, want ErrCredentialExists", err)
+	}
+	if err := vault.RenameCredential("missing", "other"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("rename of a missing credential: got %v, want ErrCredentialNotFound", err)
+	}
+	if err := vault.RenameCredential("github-work", ""); !errors.Is(err, ErrInvalidCredential) {
+		t.Errorf("rename to an empty name: got %v, want ErrInvalidCredential", err)
+	}
+}
+
 func TestPersistence(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "vault-test-*")
 	if err != nil {
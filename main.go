@@ -9,6 +9,12 @@ import (
 )
 
 func main() {
+	// Docker and Podman run the credential helper as docker-credential-pass-cli
+	if cmd.IsDockerCredentialHelper(os.Args[0]) {
+		cmd.ExecuteDockerCredential(os.Args[1:])
+		return
+	}
+
 	// Default to TUI if no subcommand provided
 	shouldUseTUI := true
 	vaultPath := ""
@@ -831,9 +831,9 @@ func TestCustomVaultPath_Operations(t *testing.T) {
 	t.Log("✓ Commands successfully use custom vault_path from config")
 }
 
-// T036: Integration test for --vault flag rejection with helpful error
+// T036: Integration test for --vault rejecting a path with a helpful error
 func TestVaultFlagRejection(t *testing.T) {
-	// Attempt to use --vault flag (which has been removed)
+	// --vault takes a vault registry name; a path (the old meaning) is not a registered vault
 	cmd := exec.Command(binaryPath, "init", "--vault", "/test/path/vault.enc")
 
 	var stdout, stderr bytes.Buffer
@@ -844,15 +844,15 @@ func TestVaultFlagRejection(t *testing.T) {
 
 	// Command should fail
 	if err == nil {
-		t.Fatal("Expected command to fail with --vault flag, but it succeeded")
+		t.Fatal("Expected command to fail with a path passed to --vault, but it succeeded")
 	}
 
-	// Error message should mention the flag is not supported
+	// Error message should explain that --vault takes a name
 	output := stdout.String() + stderr.String()
 
-	if !strings.Contains(output, "vault") && !strings.Contains(output, "unknown flag") {
-		t.Errorf("Expected error message about unknown/unsupported flag, got: %s", output)
+	if !strings.Contains(output, "unknown vault") || !strings.Contains(output, "vault add") {
+		t.Errorf("Expected error message about an unknown vault name, got: %s", output)
 	}
 
-	t.Logf("✓ --vault flag correctly rejected with error: %s", output)
+	t.Logf("✓ path passed to --vault correctly rejected with error: %s", output)
 }